@@ -0,0 +1,216 @@
+package api
+
+import (
+	"database/sql"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	user_domain "github.com/ot07/next-bazaar/api/domain/user"
+	"github.com/ot07/next-bazaar/api/validation"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type adminHandler struct {
+	userService    *user_domain.UserService
+	productService *product_domain.ProductService
+}
+
+func newAdminHandler(userService *user_domain.UserService, productService *product_domain.ProductService) *adminHandler {
+	return &adminHandler{
+		userService:    userService,
+		productService: productService,
+	}
+}
+
+// @Summary      List users
+// @Tags         Admin
+// @Param        query query user_domain.ListUsersRequest true "query"
+// @Success      200 {object} user_domain.ListUsersResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /admin/users [get]
+func (h *adminHandler) listUsers(c *fiber.Ctx) error {
+	req := new(user_domain.ListUsersRequest)
+	if err := c.QueryParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	users, err := h.userService.GetUsers(c.UserContext(), user_domain.GetUsersServiceParams{
+		PageID:   req.PageID,
+		PageSize: req.PageSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	totalCount, err := h.userService.CountUsers(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	pageCount := int64(math.Ceil(float64(totalCount) / float64(req.PageSize)))
+
+	rsp := user_domain.ListUsersResponse{
+		Meta: user_domain.ListUsersResponseMeta{
+			PageID:     req.PageID,
+			PageSize:   req.PageSize,
+			PageCount:  pageCount,
+			TotalCount: totalCount,
+		},
+		Data: user_domain.NewAdminUsersResponse(users),
+	}
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Get user
+// @Tags         Admin
+// @Param        id path string true "User ID"
+// @Success      200 {object} user_domain.AdminUserResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /admin/users/{id} [get]
+func (h *adminHandler) getUser(c *fiber.Ctx) error {
+	req := new(user_domain.GetUserRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	user, err := h.userService.GetUser(c.UserContext(), req.ID)
+	if err != nil {
+		return err
+	}
+
+	rsp := user_domain.NewAdminUserResponse(user)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Update user role
+// @Tags         Admin
+// @Param        id path string true "User ID"
+// @Param        body body user_domain.UpdateRoleRequestBody true "Role object"
+// @Success      200 {object} user_domain.AdminUserResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /admin/users/{id}/role [patch]
+func (h *adminHandler) updateUserRole(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	reqParams := new(user_domain.UpdateRoleRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(user_domain.UpdateRoleRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	user, err := h.userService.UpdateUserRole(c.UserContext(), user_domain.UpdateUserRoleServiceParams{
+		ID:          reqParams.ID,
+		Role:        db.UserRole(reqBody.Role),
+		RequesterID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := user_domain.NewAdminUserResponse(user)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Update any product
+// @Tags         Admin
+// @Param        id path string true "Product ID"
+// @Param        body body product_domain.UpdateProductRequestBody true "Product object"
+// @Success      200 {object} messageResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /admin/products/{id} [put]
+func (h *adminHandler) updateProduct(c *fiber.Ctx) error {
+	reqParams := new(product_domain.UpdateProductRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(product_domain.UpdateProductRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	price, err := decimal.NewFromString(reqBody.Price)
+	if err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.productService.UpdateProductAsAdmin(c.UserContext(), product_domain.UpdateProductAsAdminServiceParams{
+		ID:            reqParams.ProductID,
+		Name:          reqBody.Name,
+		Description:   sql.NullString{String: reqBody.Description, Valid: len(reqBody.Description) > 0},
+		Price:         price,
+		StockQuantity: reqBody.StockQuantity,
+		CategoryID:    reqBody.CategoryID,
+		ImageUrl:      sql.NullString{String: reqBody.ImageUrl, Valid: len(reqBody.ImageUrl) > 0},
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := newMessageResponse("Product updated successfully")
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Delete any product
+// @Tags         Admin
+// @Param        id path string true "Product ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /admin/products/{id} [delete]
+func (h *adminHandler) deleteProduct(c *fiber.Ctx) error {
+	req := new(product_domain.DeleteProductRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err := h.productService.DeleteProductAsAdmin(c.UserContext(), req.ProductID)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
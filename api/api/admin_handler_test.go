@@ -0,0 +1,817 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	user_domain "github.com/ot07/next-bazaar/api/domain/user"
+	"github.com/ot07/next-bazaar/api/test_util"
+	mockdb "github.com/ot07/next-bazaar/db/mock"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func newAdminTestSession(sessionToken *token.Token) db.Session {
+	return db.Session{
+		ID:                    util.RandomUUID(),
+		UserID:                util.RandomUUID(),
+		SessionTokenHash:      sessionToken.Hash(),
+		SessionTokenExpiredAt: sessionToken.ExpiredAt,
+		CreatedAt:             time.Now(),
+	}
+}
+
+// buildAdminMockStore returns a mock store whose session user has the given role.
+// The returned session can be used to set further expectations.
+func buildAdminMockStore(
+	t *testing.T,
+	sessionToken *token.Token,
+	role db.UserRole,
+) (*mockdb.MockStore, db.Session, func()) {
+	mockStore, cleanup := test_util.NewMockStore(t)
+
+	session := newAdminTestSession(sessionToken)
+	test_util.BuildValidRoleStubs(mockStore, session, role)
+
+	return mockStore, session, cleanup
+}
+
+func TestAdminListUsers(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	defaultQuery := test_util.Query{
+		"page_id":   "1",
+		"page_size": "2",
+	}
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		query         test_util.Query
+		setupAuth     func(request *http.Request, sessionToken string)
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					ListUsers(gomock.Any(), gomock.Eq(db.ListUsersParams{Limit: 2, Offset: 0})).
+					Return([]db.User{
+						{ID: util.RandomUUID(), Name: "testuser1", Email: "test1@example.com", Role: db.UserRoleCustomer},
+						{ID: util.RandomUUID(), Name: "testuser2", Email: "test2@example.com", Role: db.UserRoleSeller},
+					}, nil)
+
+				mockStore.EXPECT().
+					CountUsers(gomock.Any()).
+					Return(int64(3), nil)
+
+				return mockStore, cleanup
+			},
+			query:     defaultQuery,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListUsersResponse(t, response.Body)
+
+				require.Equal(t, int64(2), gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, 2)
+				require.Equal(t, "testuser1", gotResponse.Data[0].Name)
+				require.Equal(t, "customer", gotResponse.Data[0].Role)
+				require.Equal(t, "seller", gotResponse.Data[1].Role)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
+			},
+			query:     defaultQuery,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "NoAuthorization",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				return test_util.NewMockStore(t)
+			},
+			query:     defaultQuery,
+			setupAuth: test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "PageSizeMoreThanUpperLimit",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, cleanup
+			},
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": "101",
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					ListUsers(gomock.Any(), gomock.Any()).
+					Return([]db.User{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			query:     defaultQuery,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/admin/users",
+				Query:  tc.query,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminUpdateUserRole(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func())
+		body          test_util.Body
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				userID = util.RandomUUID()
+				mockStore.EXPECT().
+					UpdateUserRole(gomock.Any(), gomock.Eq(db.UpdateUserRoleParams{
+						ID:   userID,
+						Role: db.UserRoleSeller,
+					})).
+					Return(db.User{ID: userID, Name: "testuser", Role: db.UserRoleSeller}, nil)
+
+				return mockStore, userID, cleanup
+			},
+			body: test_util.Body{"role": "seller"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalAdminUserResponse(t, response.Body)
+				require.Equal(t, "testuser", gotResponse.Name)
+				require.Equal(t, "seller", gotResponse.Role)
+			},
+		},
+		{
+			name: "ChangeOwnRole",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, session, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, session.UserID, cleanup
+			},
+			body: test_util.Body{"role": "customer"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InvalidRole",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, util.RandomUUID(), cleanup
+			},
+			body: test_util.Body{"role": "superuser"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "UserNotFound",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					UpdateUserRole(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrNoRows)
+
+				return mockStore, util.RandomUUID(), cleanup
+			},
+			body: test_util.Body{"role": "seller"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, session, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleCustomer)
+				return mockStore, session.UserID, cleanup
+			},
+			body: test_util.Body{"role": "admin"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, userID uuid.UUID, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					UpdateUserRole(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrConnDone)
+
+				return mockStore, util.RandomUUID(), cleanup
+			},
+			body: test_util.Body{"role": "seller"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, userID, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPatch,
+				URL:    fmt.Sprintf("/api/v1/admin/users/%s/role", userID),
+				Body:   tc.body,
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminCreateCategory(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		body          test_util.Body
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Eq(db.CreateCategoryWithParentParams{Name: "test-category"})).
+					Return(db.Category{ID: util.RandomUUID(), Name: "test-category"}, nil)
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+
+				var gotResponse product_domain.ProductCategoryResponse
+				require.NoError(t, json.Unmarshal(data, &gotResponse))
+				require.NotEmpty(t, gotResponse.ID)
+				require.Equal(t, "test-category", gotResponse.Name)
+			},
+		},
+		{
+			name: "AlreadyExists",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, &pq.Error{Code: "23505"})
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name: "NameNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, cleanup
+			},
+			body: test_util.Body{},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/admin/categories",
+				Body:   tc.body,
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminUpdateCategory(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	categoryID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(db.UpdateCategoryParams{
+						ID:   categoryID,
+						Name: "new-name",
+					})).
+					Return(db.Category{ID: categoryID, Name: "new-name"}, nil)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPatch,
+				URL:    fmt.Sprintf("/api/v1/admin/categories/%s", categoryID),
+				Body:   test_util.Body{"name": "new-name"},
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminDeleteCategory(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	categoryID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID}, nil)
+
+				mockStore.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				requireNoContent(t, response)
+			},
+		},
+		{
+			name: "CategoryNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "CategoryInUse",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Return(db.Category{ID: categoryID}, nil)
+
+				mockStore.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Any()).
+					Return(&pq.Error{Code: "23503"})
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleCustomer)
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/admin/categories/%s", categoryID),
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminUpdateProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	productID := util.RandomUUID()
+	sellerID := util.RandomUUID()
+	categoryID := util.RandomUUID()
+
+	defaultBody := test_util.Body{
+		"name":           "test-product-updated",
+		"price":          "200.00",
+		"stock_quantity": 20,
+		"category_id":    categoryID.String(),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		body          test_util.Body
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(productID)).
+					Return(db.Product{ID: productID, SellerID: sellerID}, nil)
+
+				mockStore.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Eq(db.UpdateProductParams{
+						ID:            productID,
+						Name:          "test-product-updated",
+						Price:         "200",
+						StockQuantity: 20,
+						CategoryID:    categoryID,
+						SellerID:      sellerID,
+					})).
+					Return(db.Product{}, nil)
+
+				return mockStore, cleanup
+			},
+			body: defaultBody,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			body: defaultBody,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
+			},
+			body: defaultBody,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{ID: productID, SellerID: sellerID}, nil)
+
+				mockStore.EXPECT().
+					UpdateProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			body: defaultBody,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPut,
+				URL:    fmt.Sprintf("/api/v1/admin/products/%s", productID),
+				Body:   tc.body,
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAdminDeleteProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	productID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(productID)).
+					Return(db.Product{ID: productID, SellerID: util.RandomUUID()}, nil)
+
+				mockStore.EXPECT().
+					SoftDeleteProduct(gomock.Any(), gomock.Eq(productID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				requireNoContent(t, response)
+			},
+		},
+		{
+			name: "ProductNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleCustomer)
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{ID: productID}, nil)
+
+				mockStore.EXPECT().
+					SoftDeleteProduct(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/admin/products/%s", productID),
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func unmarshalListUsersResponse(t *testing.T, body io.ReadCloser) user_domain.ListUsersResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed user_domain.ListUsersResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalAdminUserResponse(t *testing.T, body io.ReadCloser) user_domain.AdminUserResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed user_domain.AdminUserResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
@@ -0,0 +1,101 @@
+// Package apperror defines the errors the domain services return to the transport layer.
+// Each error has a kind, which decides the HTTP status, and a stable code clients can rely on.
+package apperror
+
+import "errors"
+
+// Kind classifies an Error, so that it can be mapped to a status without knowing the error itself.
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindTooManyRequests
+)
+
+// CodeInternal is the code of every error that is not an Error. Their details are never sent to clients.
+const CodeInternal = "internal_error"
+
+// Error is an error that is safe to report to clients.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	// Err is the cause of the error. It is only logged and never sent to clients.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	if len(e.Message) == 0 {
+		return e.Err.Error()
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func newError(kind Kind, code string, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Code:    code,
+		Message: message,
+	}
+}
+
+// Validation returns an error for a request that is well-formed but cannot be processed as is.
+func Validation(code string, message string) *Error {
+	return newError(KindValidation, code, message)
+}
+
+// Unauthorized returns an error for a request without valid credentials.
+func Unauthorized(code string, message string) *Error {
+	return newError(KindUnauthorized, code, message)
+}
+
+// Forbidden returns an error for a request whose credentials do not grant the action.
+func Forbidden(code string, message string) *Error {
+	return newError(KindForbidden, code, message)
+}
+
+// NotFound returns an error for a request on a resource that does not exist,
+// or that the requester must not know about.
+func NotFound(code string, message string) *Error {
+	return newError(KindNotFound, code, message)
+}
+
+// Conflict returns an error for a request that conflicts with the current state of a resource.
+func Conflict(code string, message string) *Error {
+	return newError(KindConflict, code, message)
+}
+
+// TooManyRequests returns an error for a request that has been rejected to protect the service.
+func TooManyRequests(code string, message string) *Error {
+	return newError(KindTooManyRequests, code, message)
+}
+
+// InvalidRequest returns a validation error for a request that could not be parsed or failed validation.
+// The code and message are derived from the cause when the error is reported.
+func InvalidRequest(err error) *Error {
+	return &Error{
+		Kind: KindValidation,
+		Err:  err,
+	}
+}
+
+// As returns the Error in the chain of err, if any.
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
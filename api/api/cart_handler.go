@@ -1,20 +1,22 @@
 package api
 
 import (
-	"database/sql"
-
 	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
 	cart_domain "github.com/ot07/next-bazaar/api/domain/cart"
 	"github.com/ot07/next-bazaar/api/validation"
+	"github.com/ot07/next-bazaar/metrics"
 )
 
 type cartHandler struct {
 	service *cart_domain.CartService
+	metrics *metrics.Metrics
 }
 
-func newCartHandler(s *cart_domain.CartService) *cartHandler {
+func newCartHandler(s *cart_domain.CartService, m *metrics.Metrics) *cartHandler {
 	return &cartHandler{
 		service: s,
+		metrics: m,
 	}
 }
 
@@ -28,12 +30,12 @@ func newCartHandler(s *cart_domain.CartService) *cartHandler {
 func (h *cartHandler) getCart(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
-	cartProducts, err := h.service.GetProductsByUserID(c.Context(), session.UserID)
+	cartProducts, err := h.service.GetProductsByUserID(c.UserContext(), session.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := cart_domain.NewCartResponse(cartProducts)
@@ -50,12 +52,12 @@ func (h *cartHandler) getCart(c *fiber.Ctx) error {
 func (h *cartHandler) getCartProductsCount(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
-	cartProducts, err := h.service.GetProductsByUserID(c.Context(), session.UserID)
+	cartProducts, err := h.service.GetProductsByUserID(c.UserContext(), session.UserID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	var cartProductsCount int32
@@ -73,33 +75,37 @@ func (h *cartHandler) getCartProductsCount(c *fiber.Ctx) error {
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
 // @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} insufficientStockResponse
 // @Failure      500 {object} errorResponse
 // @Router       /cart/add-product [post]
 func (h *cartHandler) addProduct(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(cart_domain.AddProductRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.AddProduct(c.Context(), cart_domain.AddProductServiceParams{
+	err = h.service.AddProduct(c.UserContext(), cart_domain.AddProductServiceParams{
 		UserID:    session.UserID,
 		ProductID: req.ProductID,
 		Quantity:  req.Quantity,
 	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
+	h.metrics.CartProductAdded()
+
 	rsp := newMessageResponse("Cart product added successfully")
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -111,39 +117,38 @@ func (h *cartHandler) addProduct(c *fiber.Ctx) error {
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
 // @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} insufficientStockResponse
 // @Failure      500 {object} errorResponse
 // @Router       /cart/{product_id} [put]
 func (h *cartHandler) updateProductQuantity(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	reqParams := new(cart_domain.UpdateProductQuantityRequestParams)
 	if err := c.ParamsParser(reqParams); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	reqBody := new(cart_domain.UpdateProductQuantityRequestBody)
 	if err := c.BodyParser(reqBody); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(reqBody); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.UpdateProductQuantity(c.Context(), cart_domain.UpdateProductQuantityServiceParams{
+	err = h.service.UpdateProductQuantity(c.UserContext(), cart_domain.UpdateProductQuantityServiceParams{
 		UserID:    session.UserID,
 		ProductID: reqParams.ProductID,
 		Quantity:  reqBody.Quantity,
 	})
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(newErrorResponse(err))
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := newMessageResponse("Cart product added successfully")
@@ -161,21 +166,21 @@ func (h *cartHandler) updateProductQuantity(c *fiber.Ctx) error {
 func (h *cartHandler) deleteProduct(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(cart_domain.DeleteProductRequest)
 	if err := c.ParamsParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.DeleteProduct(c.Context(), cart_domain.DeleteProductServiceParams{
+	err = h.service.DeleteProduct(c.UserContext(), cart_domain.DeleteProductServiceParams{
 		UserID:    session.UserID,
 		ProductID: req.ProductID,
 	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
-	return c.Status(fiber.StatusNoContent).JSON(nil)
+	return c.SendStatus(fiber.StatusNoContent)
 }
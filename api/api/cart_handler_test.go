@@ -105,7 +105,7 @@ func TestGetCart(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
@@ -140,7 +140,7 @@ func TestGetCart(t *testing.T) {
 				URL:    "/api/v1/cart",
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -256,7 +256,7 @@ func TestGetCartProductsCount(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
@@ -291,7 +291,7 @@ func TestGetCartProductsCount(t *testing.T) {
 				URL:    "/api/v1/cart/count",
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -414,6 +414,36 @@ func TestAddProduct(t *testing.T) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
 			},
 		},
+		{
+			name:           "ProductNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"product_id": util.RandomUUID().String(),
+					"quantity":   1,
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "InsufficientStock",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"product_id": seedData["product_id"].(string),
+					"quantity":   11,
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
 		{
 			name:           "ProductIDNotFound",
 			buildStore:     test_util.BuildTestDBStore,
@@ -465,13 +495,13 @@ func TestAddProduct(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
 
 				mockStore.EXPECT().
-					GetCartProductByUserIDAndProductID(gomock.Any(), gomock.Any()).
+					AddCartProductTx(gomock.Any(), gomock.Any()).
 					Return(db.CartProduct{}, sql.ErrConnDone)
 
 				return mockStore, cleanup
@@ -507,7 +537,7 @@ func TestAddProduct(t *testing.T) {
 				Body:   tc.createBody(seedData),
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -605,6 +635,18 @@ func TestUpdateProductQuantity(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, response.StatusCode)
 			},
 		},
+		{
+			name:           "InsufficientStock",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"quantity": 11,
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
 		{
 			name:           "QuantityNotFound",
 			buildStore:     test_util.BuildTestDBStore,
@@ -635,11 +677,15 @@ func TestUpdateProductQuantity(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
 
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{StockQuantity: 10}, nil)
+
 				mockStore.EXPECT().
 					UpdateCartProduct(gomock.Any(), gomock.Any()).
 					Return(db.CartProduct{}, sql.ErrConnDone)
@@ -676,7 +722,7 @@ func TestUpdateProductQuantity(t *testing.T) {
 				Body:   tc.body,
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -774,7 +820,7 @@ func TestDeleteProduct(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
@@ -813,7 +859,7 @@ func TestDeleteProduct(t *testing.T) {
 				URL:    fmt.Sprintf("/api/v1/cart/%s", seedData["product_id"].(string)),
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -842,7 +888,7 @@ func TestCartAPIScenario(t *testing.T) {
 	})
 
 	setupAuth := func(request *http.Request) {
-		test_util.AddSessionTokenInCookie(request, sessionToken.ID.String())
+		test_util.AddSessionTokenInCookie(request, sessionToken.Value)
 	}
 
 	// Create get cart function
@@ -919,7 +965,7 @@ func TestCartAPIScenario(t *testing.T) {
 	})
 	setupAuth(request)
 	response = test_util.SendRequest(t, server.app, request)
-	require.Equal(t, http.StatusNoContent, response.StatusCode)
+	requireNoContent(t, response)
 
 	// Get cart
 	response = getCart()
@@ -26,6 +26,21 @@ type Cart struct {
 	Total    decimal.Decimal
 }
 
+func NewCart(products []CartProduct) Cart {
+	subtotal := calculateSubtotal(products)
+	shipping := calculateShipping()
+	tax := calculateTax(subtotal)
+	total := calculateTotal(subtotal, shipping, tax)
+
+	return Cart{
+		Products: products,
+		Subtotal: subtotal,
+		Shipping: shipping,
+		Tax:      tax,
+		Total:    total,
+	}
+}
+
 type GetProductsRequest struct {
 	ID uuid.UUID `params:"user_id"`
 }
@@ -83,17 +98,14 @@ func NewCartResponse(products []CartProduct) CartResponse {
 		productsRsp = append(productsRsp, NewCartProductResponse(product))
 	}
 
-	subtotal := calculateSubtotal(products)
-	shipping := calculateShipping()
-	tax := calculateTax(subtotal)
-	total := calculateTotal(subtotal, shipping, tax)
+	cart := NewCart(products)
 
 	return CartResponse{
 		Products: productsRsp,
-		Subtotal: db.Decimal{Decimal: subtotal},
-		Shipping: db.Decimal{Decimal: shipping},
-		Tax:      db.Decimal{Decimal: tax},
-		Total:    db.Decimal{Decimal: total},
+		Subtotal: db.Decimal{Decimal: cart.Subtotal},
+		Shipping: db.Decimal{Decimal: cart.Shipping},
+		Tax:      db.Decimal{Decimal: cart.Tax},
+		Total:    db.Decimal{Decimal: cart.Total},
 	}
 }
 
@@ -5,10 +5,17 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/api/apperror"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/tracing"
 	"github.com/shopspring/decimal"
 )
 
+var (
+	ErrCartProductNotFound = apperror.NotFound("cart_product_not_found", "product is not in the cart")
+)
+
 type CartService struct {
 	store db.Store
 }
@@ -20,6 +27,9 @@ func NewCartService(store db.Store) *CartService {
 }
 
 func (s *CartService) GetProductsByUserID(ctx context.Context, userID uuid.UUID) ([]CartProduct, error) {
+	ctx, span := tracing.Start(ctx, "CartService.GetProductsByUserID")
+	defer span.End()
+
 	cartProducts, err := s.store.GetCartProductsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -53,22 +63,6 @@ func (s *CartService) GetProductsByUserID(ctx context.Context, userID uuid.UUID)
 	return rsp, nil
 }
 
-type createServiceParams struct {
-	UserID    uuid.UUID
-	ProductID uuid.UUID
-	Quantity  int32
-}
-
-func (s *CartService) createProduct(ctx context.Context, params createServiceParams) error {
-	_, err := s.store.CreateCartProduct(ctx, db.CreateCartProductParams{
-		UserID:    params.UserID,
-		ProductID: params.ProductID,
-		Quantity:  params.Quantity,
-	})
-
-	return err
-}
-
 type updateServiceParams struct {
 	UserID    uuid.UUID
 	ProductID uuid.UUID
@@ -81,6 +75,9 @@ func (s *CartService) updateProduct(ctx context.Context, params updateServicePar
 		ProductID: params.ProductID,
 		Quantity:  params.Quantity,
 	})
+	if err == sql.ErrNoRows {
+		return ErrCartProductNotFound
+	}
 
 	return err
 }
@@ -92,28 +89,40 @@ type AddProductServiceParams struct {
 }
 
 func (s *CartService) AddProduct(ctx context.Context, params AddProductServiceParams) error {
-	arg := db.GetCartProductByUserIDAndProductIDParams{
-		UserID:    params.UserID,
-		ProductID: params.ProductID,
-	}
+	ctx, span := tracing.Start(ctx, "CartService.AddProduct")
+	defer span.End()
 
-	cartProduct, err := s.store.GetCartProductByUserIDAndProductID(ctx, arg)
-	if err != nil && err != sql.ErrNoRows {
-		return err
-	} else if err != nil && err == sql.ErrNoRows {
-		return s.createProduct(ctx, createServiceParams(params))
-	}
-
-	return s.updateProduct(ctx, updateServiceParams{
+	_, err := s.store.AddCartProductTx(ctx, db.AddCartProductTxParams{
 		UserID:    params.UserID,
 		ProductID: params.ProductID,
-		Quantity:  params.Quantity + cartProduct.Quantity,
+		Quantity:  params.Quantity,
 	})
+	if err == sql.ErrNoRows {
+		return product_domain.ErrProductNotFound
+	}
+
+	return err
 }
 
 type UpdateProductQuantityServiceParams = updateServiceParams
 
 func (s *CartService) UpdateProductQuantity(ctx context.Context, params UpdateProductQuantityServiceParams) error {
+	ctx, span := tracing.Start(ctx, "CartService.UpdateProductQuantity")
+	defer span.End()
+
+	product, err := s.store.GetProduct(ctx, params.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return product_domain.ErrProductNotFound
+		}
+		return err
+	}
+
+	err = db.CheckProductStock(product, params.Quantity)
+	if err != nil {
+		return err
+	}
+
 	return s.updateProduct(ctx, updateServiceParams(params))
 }
 
@@ -123,6 +132,9 @@ type DeleteProductServiceParams struct {
 }
 
 func (s *CartService) DeleteProduct(ctx context.Context, params DeleteProductServiceParams) error {
+	ctx, span := tracing.Start(ctx, "CartService.DeleteProduct")
+	defer span.End()
+
 	return s.store.DeleteCartProduct(ctx, db.DeleteCartProductParams{
 		UserID:    params.UserID,
 		ProductID: params.ProductID,
@@ -0,0 +1,112 @@
+package order_domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type OrderItem struct {
+	ProductID   uuid.UUID
+	Name        string
+	Description sql.NullString
+	Price       decimal.Decimal
+	Quantity    int32
+	Subtotal    decimal.Decimal
+	ImageUrl    sql.NullString
+}
+
+type Order struct {
+	ID        uuid.UUID
+	Items     []OrderItem
+	Subtotal  decimal.Decimal
+	Shipping  decimal.Decimal
+	Tax       decimal.Decimal
+	Total     decimal.Decimal
+	CreatedAt time.Time
+}
+
+type GetOrderRequest struct {
+	ID uuid.UUID `params:"id"`
+}
+
+type ListOrdersRequest struct {
+	PageID   int32 `query:"page_id" json:"page_id" validate:"required,min=1"`
+	PageSize int32 `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+}
+
+type OrderItemResponse struct {
+	ProductID   uuid.UUID     `json:"product_id"`
+	Name        string        `json:"name"`
+	Description db.NullString `json:"description" swaggertype:"string"`
+	Price       db.Decimal    `json:"price" swaggertype:"string"`
+	Quantity    int32         `json:"quantity"`
+	Subtotal    db.Decimal    `json:"subtotal" swaggertype:"string"`
+	ImageUrl    db.NullString `json:"image_url" swaggertype:"string"`
+}
+
+func NewOrderItemResponse(item OrderItem) OrderItemResponse {
+	return OrderItemResponse{
+		ProductID:   item.ProductID,
+		Name:        item.Name,
+		Description: db.NullString{NullString: item.Description},
+		Price:       db.Decimal{Decimal: item.Price},
+		Quantity:    item.Quantity,
+		Subtotal:    db.Decimal{Decimal: item.Subtotal},
+		ImageUrl:    db.NullString{NullString: item.ImageUrl},
+	}
+}
+
+type OrderResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	Items     []OrderItemResponse `json:"items"`
+	Subtotal  db.Decimal          `json:"subtotal" swaggertype:"string"`
+	Shipping  db.Decimal          `json:"shipping" swaggertype:"string"`
+	Tax       db.Decimal          `json:"tax" swaggertype:"string"`
+	Total     db.Decimal          `json:"total" swaggertype:"string"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+func NewOrderResponse(order Order) OrderResponse {
+	itemsRsp := make([]OrderItemResponse, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemsRsp = append(itemsRsp, NewOrderItemResponse(item))
+	}
+
+	return OrderResponse{
+		ID:        order.ID,
+		Items:     itemsRsp,
+		Subtotal:  db.Decimal{Decimal: order.Subtotal},
+		Shipping:  db.Decimal{Decimal: order.Shipping},
+		Tax:       db.Decimal{Decimal: order.Tax},
+		Total:     db.Decimal{Decimal: order.Total},
+		CreatedAt: order.CreatedAt,
+	}
+}
+
+type OrdersResponse []OrderResponse
+
+func NewOrdersResponse(orders []Order) OrdersResponse {
+	rsp := make(OrdersResponse, 0, len(orders))
+
+	for _, order := range orders {
+		rsp = append(rsp, NewOrderResponse(order))
+	}
+
+	return rsp
+}
+
+type ListOrdersResponseMeta struct {
+	PageID     int32 `json:"page_id"`
+	PageSize   int32 `json:"page_size"`
+	PageCount  int64 `json:"page_count"`
+	TotalCount int64 `json:"total_count"`
+}
+
+type ListOrdersResponse struct {
+	Meta ListOrdersResponseMeta `json:"meta"`
+	Data OrdersResponse         `json:"data"`
+}
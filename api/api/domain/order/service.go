@@ -0,0 +1,164 @@
+package order_domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/api/apperror"
+	cart_domain "github.com/ot07/next-bazaar/api/domain/cart"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+	"github.com/ot07/next-bazaar/tracing"
+)
+
+var (
+	ErrEmptyCart     = apperror.Validation("empty_cart", "cart is empty")
+	ErrOrderNotFound = apperror.NotFound("order_not_found", "order not found")
+	ErrCartChanged   = apperror.Conflict("cart_changed", "cart has changed during the checkout, please review it and try again")
+)
+
+type OrderService struct {
+	store db.Store
+	cart  *cart_domain.CartService
+}
+
+func NewOrderService(store db.Store) *OrderService {
+	return &OrderService{
+		store: store,
+		cart:  cart_domain.NewCartService(store),
+	}
+}
+
+func (s *OrderService) Checkout(ctx context.Context, userID uuid.UUID) (Order, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.Checkout")
+	defer span.End()
+
+	cartProducts, err := s.cart.GetProductsByUserID(ctx, userID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	if len(cartProducts) == 0 {
+		return Order{}, ErrEmptyCart
+	}
+
+	cart := cart_domain.NewCart(cartProducts)
+
+	items := make([]db.CreateOrderItemParams, len(cartProducts))
+	for i, cartProduct := range cartProducts {
+		items[i] = db.CreateOrderItemParams{
+			ProductID:   cartProduct.ID,
+			Name:        cartProduct.Name,
+			Description: cartProduct.Description,
+			Price:       cartProduct.Price.String(),
+			Quantity:    cartProduct.Quantity,
+			ImageUrl:    cartProduct.ImageUrl,
+		}
+	}
+
+	result, err := s.store.CheckoutTx(ctx, db.CheckoutTxParams{
+		CreateOrderParams: db.CreateOrderParams{
+			UserID:   userID,
+			Subtotal: cart.Subtotal.String(),
+			Shipping: cart.Shipping.String(),
+			Tax:      cart.Tax.String(),
+			Total:    cart.Total.String(),
+		},
+		Items: items,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrCartChanged) {
+			return Order{}, ErrCartChanged
+		}
+		return Order{}, err
+	}
+
+	logging.FromContext(ctx).Info("order placed",
+		"order_id", result.Order.ID,
+		"user_id", userID,
+		"total", result.Order.Total,
+	)
+
+	return toOrderDomain(result.Order, result.Items)
+}
+
+type GetOrderServiceParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (s *OrderService) GetOrder(ctx context.Context, params GetOrderServiceParams) (Order, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.GetOrder")
+	defer span.End()
+
+	order, err := s.store.GetOrder(ctx, params.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Order{}, ErrOrderNotFound
+		}
+		return Order{}, err
+	}
+
+	// Orders of other users are treated as missing so that their existence isn't leaked.
+	if order.UserID != params.UserID {
+		return Order{}, ErrOrderNotFound
+	}
+
+	items, err := s.store.GetOrderItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return Order{}, err
+	}
+
+	return toOrderDomain(order, items)
+}
+
+type GetOrdersServiceParams struct {
+	PageID   int32
+	PageSize int32
+	UserID   uuid.UUID
+}
+
+func (s *OrderService) GetOrders(ctx context.Context, params GetOrdersServiceParams) ([]Order, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.GetOrders")
+	defer span.End()
+
+	arg := db.ListOrdersByUserParams{
+		Limit:  params.PageSize,
+		Offset: (params.PageID - 1) * params.PageSize,
+		UserID: params.UserID,
+	}
+
+	orders, err := s.store.ListOrdersByUser(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.store.GetOrderItemsByOrderIDs(ctx, ordersToIDs(orders))
+	if err != nil {
+		return nil, err
+	}
+
+	itemsMap := make(map[uuid.UUID][]db.OrderItem)
+	for _, item := range items {
+		itemsMap[item.OrderID] = append(itemsMap[item.OrderID], item)
+	}
+
+	rsp := make([]Order, len(orders))
+	for i, order := range orders {
+		rsp[i], err = toOrderDomain(order, itemsMap[order.ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rsp, nil
+}
+
+func (s *OrderService) CountOrders(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "OrderService.CountOrders")
+	defer span.End()
+
+	return s.store.CountOrdersByUser(ctx, userID)
+}
@@ -0,0 +1,75 @@
+package order_domain
+
+import (
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+func ordersToIDs(orders []db.Order) []uuid.UUID {
+	ids := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+
+	return ids
+}
+
+func toOrderItemDomain(item db.OrderItem) (OrderItem, error) {
+	price, err := decimal.NewFromString(item.Price)
+	if err != nil {
+		return OrderItem{}, err
+	}
+
+	quantity := decimal.NewFromInt32(item.Quantity)
+
+	return OrderItem{
+		ProductID:   item.ProductID,
+		Name:        item.Name,
+		Description: item.Description,
+		Price:       price,
+		Quantity:    item.Quantity,
+		Subtotal:    price.Mul(quantity),
+		ImageUrl:    item.ImageUrl,
+	}, nil
+}
+
+func toOrderDomain(order db.Order, items []db.OrderItem) (Order, error) {
+	subtotal, err := decimal.NewFromString(order.Subtotal)
+	if err != nil {
+		return Order{}, err
+	}
+
+	shipping, err := decimal.NewFromString(order.Shipping)
+	if err != nil {
+		return Order{}, err
+	}
+
+	tax, err := decimal.NewFromString(order.Tax)
+	if err != nil {
+		return Order{}, err
+	}
+
+	total, err := decimal.NewFromString(order.Total)
+	if err != nil {
+		return Order{}, err
+	}
+
+	orderItems := make([]OrderItem, len(items))
+	for i, item := range items {
+		orderItems[i], err = toOrderItemDomain(item)
+		if err != nil {
+			return Order{}, err
+		}
+	}
+
+	return Order{
+		ID:        order.ID,
+		Items:     orderItems,
+		Subtotal:  subtotal,
+		Shipping:  shipping,
+		Tax:       tax,
+		Total:     total,
+		CreatedAt: order.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,80 @@
+package product_domain
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/api/apperror"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+)
+
+var (
+	ErrInvalidCursor          = apperror.Validation("invalid_cursor", "invalid cursor")
+	ErrCursorSortNotSupported = apperror.Validation("cursor_sort_not_supported", "cursor pagination only supports the default and newest sort orders")
+)
+
+// IsCursorSortSupported reports whether the sort order is compatible with the (created_at, id) keyset.
+func IsCursorSortSupported(sort string) bool {
+	return sort == "" || sort == "newest"
+}
+
+// productCursor is the keyset position of a product in a listing ordered by (created_at, id).
+// It is exposed to clients only as an opaque string.
+type productCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+func encodeProductCursor(product db.Product) string {
+	data, _ := json.Marshal(productCursor{
+		CreatedAt: product.CreatedAt,
+		ID:        product.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeProductCursor decodes a cursor created by encodeProductCursor.
+// An empty cursor denotes the first page and decodes to the zero productCursor.
+func decodeProductCursor(cursor string) (productCursor, error) {
+	if len(cursor) == 0 {
+		return productCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return productCursor{}, ErrInvalidCursor
+	}
+
+	var c productCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return productCursor{}, ErrInvalidCursor
+	}
+
+	if c.CreatedAt.IsZero() || c.ID == uuid.Nil {
+		return productCursor{}, ErrInvalidCursor
+	}
+
+	return c, nil
+}
+
+func (c productCursor) nullCreatedAt() sql.NullTime {
+	return sql.NullTime{Time: c.CreatedAt, Valid: !c.CreatedAt.IsZero()}
+}
+
+func (c productCursor) nullID() uuid.NullUUID {
+	return uuid.NullUUID{UUID: c.ID, Valid: c.ID != uuid.Nil}
+}
+
+// nextProductCursor trims the extra product fetched to detect whether another page exists,
+// and returns the cursor of the next page, or an empty string if this is the last page.
+func nextProductCursor(products []db.Product, pageSize int32) ([]db.Product, string) {
+	if int32(len(products)) <= pageSize {
+		return products, ""
+	}
+
+	products = products[:pageSize]
+	return products, encodeProductCursor(products[len(products)-1])
+}
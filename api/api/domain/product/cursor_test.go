@@ -0,0 +1,94 @@
+package product_domain
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	product := db.Product{
+		ID:        util.RandomUUID(),
+		CreatedAt: time.Date(2023, 9, 1, 12, 34, 56, 789000, time.UTC),
+	}
+
+	cursor, err := decodeProductCursor(encodeProductCursor(product))
+	require.NoError(t, err)
+
+	require.Equal(t, product.ID, cursor.ID)
+	require.True(t, product.CreatedAt.Equal(cursor.CreatedAt))
+	require.True(t, cursor.nullCreatedAt().Valid)
+	require.True(t, cursor.nullID().Valid)
+}
+
+func TestDecodeProductCursor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		cursor    string
+		expectErr bool
+	}{
+		{
+			name:      "empty",
+			cursor:    "",
+			expectErr: false,
+		},
+		{
+			name:      "not base64",
+			cursor:    "not a cursor!",
+			expectErr: true,
+		},
+		{
+			name:      "not json",
+			cursor:    base64.RawURLEncoding.EncodeToString([]byte("cursor")),
+			expectErr: true,
+		},
+		{
+			name:      "missing fields",
+			cursor:    base64.RawURLEncoding.EncodeToString([]byte("{}")),
+			expectErr: true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cursor, err := decodeProductCursor(tc.cursor)
+			if tc.expectErr {
+				require.ErrorIs(t, err, ErrInvalidCursor)
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, cursor.nullCreatedAt().Valid)
+			require.False(t, cursor.nullID().Valid)
+		})
+	}
+}
+
+func TestNextProductCursor(t *testing.T) {
+	t.Parallel()
+
+	products := make([]db.Product, 3)
+	for i := range products {
+		products[i] = db.Product{ID: util.RandomUUID(), CreatedAt: time.Now()}
+	}
+
+	page, nextCursor := nextProductCursor(products, 3)
+	require.Len(t, page, 3)
+	require.Empty(t, nextCursor)
+
+	page, nextCursor = nextProductCursor(products, 2)
+	require.Len(t, page, 2)
+	require.Equal(t, encodeProductCursor(products[1]), nextCursor)
+}
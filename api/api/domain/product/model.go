@@ -2,6 +2,7 @@ package product_domain
 
 import (
 	"database/sql"
+	"math"
 
 	"github.com/google/uuid"
 	db "github.com/ot07/next-bazaar/db/sqlc"
@@ -19,11 +20,37 @@ type Product struct {
 	SellerID      uuid.UUID
 	Seller        string
 	ImageUrl      sql.NullString
+	AverageRating float64
+	ReviewCount   int64
 }
 
 type Category struct {
-	ID   uuid.UUID
-	Name string
+	ID       uuid.UUID
+	Name     string
+	ParentID uuid.NullUUID
+}
+
+type CategoryNode struct {
+	ID       uuid.UUID
+	Name     string
+	Children []CategoryNode
+}
+
+type CategoryFacet struct {
+	CategoryID uuid.UUID
+	Category   string
+	Count      int64
+}
+
+type PriceBucketFacet struct {
+	MinPrice decimal.Decimal
+	MaxPrice decimal.NullDecimal
+	Count    int64
+}
+
+type ProductFacets struct {
+	Categories   []CategoryFacet
+	PriceBuckets []PriceBucketFacet
 }
 
 type GetProductRequest struct {
@@ -31,14 +58,25 @@ type GetProductRequest struct {
 }
 
 type ListProductsRequest struct {
-	PageID     int32         `query:"page_id" json:"page_id" validate:"required,min=1"`
+	PageID     int32         `query:"page_id" json:"page_id" validate:"required_unless=CursorMode true,omitempty,min=1"`
 	PageSize   int32         `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+	Cursor     string        `query:"cursor" json:"cursor"`
+	CursorMode bool          `query:"-" json:"-" swaggerignore:"true"`
 	CategoryID uuid.NullUUID `query:"category_id" json:"category_id" swaggertype:"string"`
+	Query      string        `query:"q" json:"q" validate:"omitempty,max=200"`
+	Sort       string        `query:"sort" json:"sort" validate:"omitempty,oneof=price_asc price_desc newest name" enums:"price_asc,price_desc,newest,name"`
+	MinPrice   string        `query:"min_price" json:"min_price" validate:"omitempty,decimal,decimal_gte=0"`
+	MaxPrice   string        `query:"max_price" json:"max_price" validate:"omitempty,decimal,decimal_gte=0,decimal_gtefield=min_price"`
+	InStock    bool          `query:"in_stock" json:"in_stock"`
+	SellerID   uuid.NullUUID `query:"seller_id" json:"seller_id" swaggertype:"string"`
 }
 
 type ListProductsBySellerRequest struct {
-	PageID   int32 `query:"page_id" json:"page_id" validate:"required,min=1"`
-	PageSize int32 `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+	PageID     int32  `query:"page_id" json:"page_id" validate:"required_unless=CursorMode true,omitempty,min=1"`
+	PageSize   int32  `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+	Cursor     string `query:"cursor" json:"cursor"`
+	CursorMode bool   `query:"-" json:"-" swaggerignore:"true"`
+	Sort       string `query:"sort" json:"sort" validate:"omitempty,oneof=price_asc price_desc newest name" enums:"price_asc,price_desc,newest,name"`
 }
 
 type AddProductRequest struct {
@@ -63,6 +101,10 @@ type UpdateProductRequestBody struct {
 	ImageUrl      string    `json:"image_url" validate:"omitempty,http_url"`
 }
 
+type DeleteProductRequest struct {
+	ProductID uuid.UUID `params:"id"`
+}
+
 type ProductResponse struct {
 	ID            uuid.UUID     `json:"id"`
 	Name          string        `json:"name"`
@@ -73,6 +115,8 @@ type ProductResponse struct {
 	Category      string        `json:"category"`
 	Seller        string        `json:"seller"`
 	ImageUrl      db.NullString `json:"image_url" swaggertype:"string"`
+	AverageRating float64       `json:"average_rating"`
+	ReviewCount   int64         `json:"review_count"`
 }
 
 func NewProductResponse(product Product) (ProductResponse, error) {
@@ -91,6 +135,8 @@ func NewProductResponse(product Product) (ProductResponse, error) {
 		Category:      product.Category,
 		Seller:        product.Seller,
 		ImageUrl:      db.NullString{NullString: product.ImageUrl},
+		AverageRating: product.AverageRating,
+		ReviewCount:   product.ReviewCount,
 	}, nil
 }
 
@@ -111,16 +157,83 @@ func NewProductsResponse(products []Product) (ProductsResponse, error) {
 	return rsp, nil
 }
 
+// ListProductsResponseMeta describes the page of the listed products.
+// Pages listed by cursor only have the page size and the next cursor, the others only the page fields.
 type ListProductsResponseMeta struct {
-	PageID     int32 `json:"page_id"`
-	PageSize   int32 `json:"page_size"`
-	PageCount  int64 `json:"page_count"`
-	TotalCount int64 `json:"total_count"`
+	PageID     *int32 `json:"page_id,omitempty"`
+	PageSize   int32  `json:"page_size"`
+	PageCount  *int64 `json:"page_count,omitempty"`
+	TotalCount *int64 `json:"total_count,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// NewPageListProductsResponseMeta returns the meta of a page listed by page id.
+func NewPageListProductsResponseMeta(pageID int32, pageSize int32, totalCount int64) ListProductsResponseMeta {
+	pageCount := int64(math.Ceil(float64(totalCount) / float64(pageSize)))
+
+	return ListProductsResponseMeta{
+		PageID:     &pageID,
+		PageSize:   pageSize,
+		PageCount:  &pageCount,
+		TotalCount: &totalCount,
+	}
+}
+
+// NewCursorListProductsResponseMeta returns the meta of a page listed by cursor.
+func NewCursorListProductsResponseMeta(pageSize int32, nextCursor string) ListProductsResponseMeta {
+	return ListProductsResponseMeta{
+		PageSize:   pageSize,
+		NextCursor: nextCursor,
+	}
+}
+
+type CategoryFacetResponse struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Category   string    `json:"category"`
+	Count      int64     `json:"count"`
+}
+
+type PriceBucketFacetResponse struct {
+	MinPrice db.Decimal  `json:"min_price" swaggertype:"string"`
+	MaxPrice *db.Decimal `json:"max_price" swaggertype:"string"`
+	Count    int64       `json:"count"`
+}
+
+type ProductFacetsResponse struct {
+	Categories   []CategoryFacetResponse    `json:"categories"`
+	PriceBuckets []PriceBucketFacetResponse `json:"price_buckets"`
+}
+
+func NewProductFacetsResponse(facets ProductFacets) *ProductFacetsResponse {
+	categories := make([]CategoryFacetResponse, 0, len(facets.Categories))
+	for _, facet := range facets.Categories {
+		categories = append(categories, CategoryFacetResponse(facet))
+	}
+
+	priceBuckets := make([]PriceBucketFacetResponse, 0, len(facets.PriceBuckets))
+	for _, facet := range facets.PriceBuckets {
+		var maxPrice *db.Decimal
+		if facet.MaxPrice.Valid {
+			maxPrice = &db.Decimal{Decimal: facet.MaxPrice.Decimal}
+		}
+
+		priceBuckets = append(priceBuckets, PriceBucketFacetResponse{
+			MinPrice: db.Decimal{Decimal: facet.MinPrice},
+			MaxPrice: maxPrice,
+			Count:    facet.Count,
+		})
+	}
+
+	return &ProductFacetsResponse{
+		Categories:   categories,
+		PriceBuckets: priceBuckets,
+	}
 }
 
 type ListProductsResponse struct {
-	Meta ListProductsResponseMeta `json:"meta"`
-	Data ProductsResponse         `json:"data"`
+	Meta   ListProductsResponseMeta `json:"meta"`
+	Data   ProductsResponse         `json:"data"`
+	Facets *ProductFacetsResponse   `json:"facets,omitempty"`
 }
 
 type ListProductCategoriesRequest struct {
@@ -128,14 +241,37 @@ type ListProductCategoriesRequest struct {
 	PageSize int32 `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
 }
 
+type CreateCategoryRequest struct {
+	Name     string        `json:"name" validate:"required,max=50"`
+	ParentID uuid.NullUUID `json:"parent_id" swaggertype:"string"`
+}
+
+type UpdateCategoryRequestParams struct {
+	ID uuid.UUID `params:"id"`
+}
+
+type UpdateCategoryRequestBody struct {
+	Name     string        `json:"name" validate:"required,max=50"`
+	ParentID uuid.NullUUID `json:"parent_id" swaggertype:"string"`
+}
+
+type DeleteCategoryRequest struct {
+	ID uuid.UUID `params:"id"`
+}
+
 type ListProductCategoriesResponseMeta struct {
 	PageID   int32 `json:"page_id"`
 	PageSize int32 `json:"page_size"`
 }
 
 type ProductCategoryResponse struct {
-	ID   uuid.UUID `json:"id"`
-	Name string    `json:"name"`
+	ID       uuid.UUID     `json:"id"`
+	Name     string        `json:"name"`
+	ParentID uuid.NullUUID `json:"parent_id" swaggertype:"string"`
+}
+
+func NewProductCategoryResponse(category Category) ProductCategoryResponse {
+	return ProductCategoryResponse(category)
 }
 
 type ProductCategoriesResponse []ProductCategoryResponse
@@ -150,6 +286,26 @@ func NewProductCategoriesResponse(categories []Category) ProductCategoriesRespon
 	return rsp
 }
 
+type CategoryTreeResponse struct {
+	ID       uuid.UUID              `json:"id"`
+	Name     string                 `json:"name"`
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+func NewCategoryTreeResponse(nodes []CategoryNode) []CategoryTreeResponse {
+	rsp := make([]CategoryTreeResponse, 0, len(nodes))
+
+	for _, node := range nodes {
+		rsp = append(rsp, CategoryTreeResponse{
+			ID:       node.ID,
+			Name:     node.Name,
+			Children: NewCategoryTreeResponse(node.Children),
+		})
+	}
+
+	return rsp
+}
+
 type ListProductCategoriesResponse struct {
 	Meta ListProductCategoriesResponseMeta `json:"meta"`
 	Data ProductCategoriesResponse         `json:"data"`
@@ -5,10 +5,25 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/api/apperror"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/tracing"
 	"github.com/shopspring/decimal"
 )
 
+var (
+	ErrProductNotFound         = apperror.NotFound("product_not_found", "product not found")
+	ErrProductAlreadyExists    = apperror.Conflict("product_already_exists", "product with the same name already exists")
+	ErrProductCategoryNotFound = apperror.Validation("product_category_not_found", "category of the product not found")
+	ErrNotProductSeller        = apperror.Forbidden("not_product_seller", "product is not sold by the user")
+	ErrCategoryNotFound        = apperror.NotFound("category_not_found", "category not found")
+	ErrCategoryAlreadyExists   = apperror.Conflict("category_already_exists", "category already exists")
+	ErrCategoryInUse           = apperror.Conflict("category_in_use", "category is used by products or subcategories")
+	ErrParentCategoryNotFound  = apperror.Validation("parent_category_not_found", "parent category not found")
+	ErrCategoryCycle           = apperror.Validation("category_cycle", "category cannot be moved under itself or its subcategories")
+)
+
 type ProductService struct {
 	store db.Store
 }
@@ -20,8 +35,14 @@ func NewProductService(store db.Store) *ProductService {
 }
 
 func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProduct")
+	defer span.End()
+
 	product, err := s.store.GetProduct(ctx, id)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return Product{}, ErrProductNotFound
+		}
 		return Product{}, err
 	}
 
@@ -35,20 +56,49 @@ func (s *ProductService) GetProduct(ctx context.Context, id uuid.UUID) (Product,
 		return Product{}, err
 	}
 
-	return toProductDomain(product, category, seller), nil
+	var rating db.GetProductRatingsByProductIDsRow
+	ratings, err := s.store.GetProductRatingsByProductIDs(ctx, []uuid.UUID{product.ID})
+	if err != nil {
+		return Product{}, err
+	}
+	if len(ratings) > 0 {
+		rating = ratings[0]
+	}
+
+	return toProductDomain(product, category, seller, rating), nil
 }
 
 type GetProductsServiceParams struct {
 	PageID     int32
 	PageSize   int32
 	CategoryID uuid.NullUUID
+	Query      sql.NullString
+	MinPrice   decimal.NullDecimal
+	MaxPrice   decimal.NullDecimal
+	InStock    bool
+	SellerID   uuid.NullUUID
+	Sort       string
 }
 
 func (s *ProductService) GetProducts(ctx context.Context, params GetProductsServiceParams) ([]Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProducts")
+	defer span.End()
+
+	categoryIDs, err := s.categoryIDsFilter(ctx, params.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
 	arg := db.ListProductsParams{
-		Limit:      params.PageSize,
-		Offset:     (params.PageID - 1) * params.PageSize,
-		CategoryID: params.CategoryID,
+		Limit:       params.PageSize,
+		Offset:      (params.PageID - 1) * params.PageSize,
+		CategoryIds: categoryIDs,
+		Query:       params.Query,
+		MinPrice:    nullDecimalToNullString(params.MinPrice),
+		MaxPrice:    nullDecimalToNullString(params.MaxPrice),
+		InStock:     params.InStock,
+		SellerID:    params.SellerID,
+		Sort:        params.Sort,
 	}
 
 	products, err := s.store.ListProducts(ctx, arg)
@@ -56,51 +106,153 @@ func (s *ProductService) GetProducts(ctx context.Context, params GetProductsServ
 		return nil, err
 	}
 
-	categoryIDs := productsToCategoryIDs(products)
-	categories, err := s.store.GetCategoriesByIDs(ctx, categoryIDs)
+	return s.toProductsDomain(ctx, products)
+}
+
+type GetProductsByCursorServiceParams struct {
+	Cursor     string
+	PageSize   int32
+	CategoryID uuid.NullUUID
+	Query      sql.NullString
+	MinPrice   decimal.NullDecimal
+	MaxPrice   decimal.NullDecimal
+	InStock    bool
+	SellerID   uuid.NullUUID
+	Newest     bool
+}
+
+func (s *ProductService) GetProductsByCursor(ctx context.Context, params GetProductsByCursorServiceParams) ([]Product, string, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductsByCursor")
+	defer span.End()
+
+	cursor, err := decodeProductCursor(params.Cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	categoriesMap := make(map[uuid.UUID]db.Category)
-	for _, category := range categories {
-		categoriesMap[category.ID] = category
+	categoryIDs, err := s.categoryIDsFilter(ctx, params.CategoryID)
+	if err != nil {
+		return nil, "", err
 	}
 
-	sellersIDs := productsToSellersIDs(products)
-	sellers, err := s.store.GetUsersByIDs(ctx, sellersIDs)
+	products, err := s.store.ListProductsByCursor(ctx, db.ListProductsByCursorParams{
+		Limit:           params.PageSize + 1,
+		CategoryIds:     categoryIDs,
+		Query:           params.Query,
+		MinPrice:        nullDecimalToNullString(params.MinPrice),
+		MaxPrice:        nullDecimalToNullString(params.MaxPrice),
+		InStock:         params.InStock,
+		SellerID:        params.SellerID,
+		CursorCreatedAt: cursor.nullCreatedAt(),
+		CursorID:        cursor.nullID(),
+		Newest:          params.Newest,
+	})
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	sellersMap := make(map[uuid.UUID]db.User)
-	for _, seller := range sellers {
-		sellersMap[seller.ID] = seller
+	products, nextCursor := nextProductCursor(products, params.PageSize)
+
+	rsp, err := s.toProductsDomain(ctx, products)
+	if err != nil {
+		return nil, "", err
 	}
 
-	rsp := make([]Product, len(products))
-	for i, product := range products {
-		rsp[i] = toProductDomain(product, categoriesMap[product.CategoryID], sellersMap[product.SellerID])
+	return rsp, nextCursor, nil
+}
+
+type CountProductsServiceParams struct {
+	CategoryID uuid.NullUUID
+	Query      sql.NullString
+	MinPrice   decimal.NullDecimal
+	MaxPrice   decimal.NullDecimal
+	InStock    bool
+	SellerID   uuid.NullUUID
+}
+
+func (s *ProductService) CountProducts(ctx context.Context, params CountProductsServiceParams) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.CountProducts")
+	defer span.End()
+
+	categoryIDs, err := s.categoryIDsFilter(ctx, params.CategoryID)
+	if err != nil {
+		return 0, err
 	}
 
-	return rsp, nil
+	return s.store.CountProducts(ctx, db.CountProductsParams{
+		CategoryIds: categoryIDs,
+		Query:       params.Query,
+		MinPrice:    nullDecimalToNullString(params.MinPrice),
+		MaxPrice:    nullDecimalToNullString(params.MaxPrice),
+		InStock:     params.InStock,
+		SellerID:    params.SellerID,
+	})
 }
 
-func (s *ProductService) CountProducts(ctx context.Context) (int64, error) {
-	return s.store.CountProducts(ctx)
+type GetProductFacetsServiceParams struct {
+	CategoryID uuid.NullUUID
+	Query      sql.NullString
+	MinPrice   decimal.NullDecimal
+	MaxPrice   decimal.NullDecimal
+	InStock    bool
+	SellerID   uuid.NullUUID
+}
+
+// GetProductFacets counts the products matching the filters per category and per price bucket.
+// Each facet ignores its own filter, so that the other options of the facet keep their counts.
+func (s *ProductService) GetProductFacets(ctx context.Context, params GetProductFacetsServiceParams) (ProductFacets, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductFacets")
+	defer span.End()
+
+	categoryRows, err := s.store.CountProductsByCategory(ctx, db.CountProductsByCategoryParams{
+		Query:    params.Query,
+		MinPrice: nullDecimalToNullString(params.MinPrice),
+		MaxPrice: nullDecimalToNullString(params.MaxPrice),
+		InStock:  params.InStock,
+		SellerID: params.SellerID,
+	})
+	if err != nil {
+		return ProductFacets{}, err
+	}
+
+	categoryIDs, err := s.categoryIDsFilter(ctx, params.CategoryID)
+	if err != nil {
+		return ProductFacets{}, err
+	}
+
+	priceBucketRows, err := s.store.CountProductsByPriceBucket(ctx, db.CountProductsByPriceBucketParams{
+		Bounds:      priceBucketBoundsToStrings(),
+		CategoryIds: categoryIDs,
+		Query:       params.Query,
+		InStock:     params.InStock,
+		SellerID:    params.SellerID,
+	})
+	if err != nil {
+		return ProductFacets{}, err
+	}
+
+	return ProductFacets{
+		Categories:   toCategoryFacetsDomain(categoryRows),
+		PriceBuckets: toPriceBucketFacetsDomain(priceBucketRows),
+	}, nil
 }
 
 type GetProductsBySellerServiceParams struct {
 	PageID   int32
 	PageSize int32
 	SellerID uuid.UUID
+	Sort     string
 }
 
 func (s *ProductService) GetProductsBySeller(ctx context.Context, params GetProductsBySellerServiceParams) ([]Product, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductsBySeller")
+	defer span.End()
+
 	arg := db.ListProductsBySellerParams{
 		Limit:    params.PageSize,
 		Offset:   (params.PageID - 1) * params.PageSize,
 		SellerID: params.SellerID,
+		Sort:     params.Sort,
 	}
 
 	products, err := s.store.ListProductsBySeller(ctx, arg)
@@ -108,37 +260,50 @@ func (s *ProductService) GetProductsBySeller(ctx context.Context, params GetProd
 		return nil, err
 	}
 
-	categoryIDs := productsToCategoryIDs(products)
-	categories, err := s.store.GetCategoriesByIDs(ctx, categoryIDs)
-	if err != nil {
-		return nil, err
-	}
+	return s.toProductsDomain(ctx, products)
+}
 
-	categoriesMap := make(map[uuid.UUID]db.Category)
-	for _, category := range categories {
-		categoriesMap[category.ID] = category
-	}
+type GetProductsBySellerByCursorServiceParams struct {
+	Cursor   string
+	PageSize int32
+	SellerID uuid.UUID
+	Newest   bool
+}
 
-	sellersIDs := productsToSellersIDs(products)
-	sellers, err := s.store.GetUsersByIDs(ctx, sellersIDs)
+func (s *ProductService) GetProductsBySellerByCursor(ctx context.Context, params GetProductsBySellerByCursorServiceParams) ([]Product, string, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductsBySellerByCursor")
+	defer span.End()
+
+	cursor, err := decodeProductCursor(params.Cursor)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	sellersMap := make(map[uuid.UUID]db.User)
-	for _, seller := range sellers {
-		sellersMap[seller.ID] = seller
+	products, err := s.store.ListProductsBySellerByCursor(ctx, db.ListProductsBySellerByCursorParams{
+		Limit:           params.PageSize + 1,
+		SellerID:        params.SellerID,
+		CursorCreatedAt: cursor.nullCreatedAt(),
+		CursorID:        cursor.nullID(),
+		Newest:          params.Newest,
+	})
+	if err != nil {
+		return nil, "", err
 	}
 
-	rsp := make([]Product, len(products))
-	for i, product := range products {
-		rsp[i] = toProductDomain(product, categoriesMap[product.CategoryID], sellersMap[product.SellerID])
+	products, nextCursor := nextProductCursor(products, params.PageSize)
+
+	rsp, err := s.toProductsDomain(ctx, products)
+	if err != nil {
+		return nil, "", err
 	}
 
-	return rsp, nil
+	return rsp, nextCursor, nil
 }
 
 func (s *ProductService) CountProductsBySeller(ctx context.Context, sellerID uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.CountProductsBySeller")
+	defer span.End()
+
 	return s.store.CountProductsBySeller(ctx, sellerID)
 }
 
@@ -148,6 +313,9 @@ type GetProductCategoriesServiceParams struct {
 }
 
 func (s *ProductService) GetProductCategories(ctx context.Context, params GetProductCategoriesServiceParams) ([]Category, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetProductCategories")
+	defer span.End()
+
 	arg := db.ListCategoriesParams{
 		Limit:  params.PageSize,
 		Offset: (params.PageID - 1) * params.PageSize,
@@ -166,6 +334,130 @@ func (s *ProductService) GetProductCategories(ctx context.Context, params GetPro
 	return rsp, nil
 }
 
+type CreateCategoryServiceParams struct {
+	Name     string
+	ParentID uuid.NullUUID
+}
+
+func (s *ProductService) CreateCategory(ctx context.Context, params CreateCategoryServiceParams) (Category, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.CreateCategory")
+	defer span.End()
+
+	if params.ParentID.Valid {
+		err := s.checkParentCategoryExists(ctx, params.ParentID.UUID)
+		if err != nil {
+			return Category{}, err
+		}
+	}
+
+	category, err := s.store.CreateCategoryWithParent(ctx, db.CreateCategoryWithParentParams{
+		Name:     params.Name,
+		ParentID: params.ParentID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return Category{}, ErrCategoryAlreadyExists
+		}
+		return Category{}, err
+	}
+
+	return toCategoryDomain(category), nil
+}
+
+type UpdateCategoryServiceParams struct {
+	ID       uuid.UUID
+	Name     string
+	ParentID uuid.NullUUID
+}
+
+func (s *ProductService) UpdateCategory(ctx context.Context, params UpdateCategoryServiceParams) (Category, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateCategory")
+	defer span.End()
+
+	_, err := s.store.GetCategory(ctx, params.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Category{}, ErrCategoryNotFound
+		}
+		return Category{}, err
+	}
+
+	if params.ParentID.Valid {
+		err = s.checkParentCategoryExists(ctx, params.ParentID.UUID)
+		if err != nil {
+			return Category{}, err
+		}
+
+		// A category cannot be moved under itself or one of its descendants.
+		descendantIDs, err := s.store.GetCategoryDescendantIDs(ctx, params.ID)
+		if err != nil {
+			return Category{}, err
+		}
+		for _, descendantID := range descendantIDs {
+			if descendantID == params.ParentID.UUID {
+				return Category{}, ErrCategoryCycle
+			}
+		}
+	}
+
+	category, err := s.store.UpdateCategory(ctx, db.UpdateCategoryParams{
+		ID:       params.ID,
+		Name:     params.Name,
+		ParentID: params.ParentID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return Category{}, ErrCategoryAlreadyExists
+		}
+		return Category{}, err
+	}
+
+	return toCategoryDomain(category), nil
+}
+
+func (s *ProductService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteCategory")
+	defer span.End()
+
+	_, err := s.store.GetCategory(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrCategoryNotFound
+		}
+		return err
+	}
+
+	err = s.store.DeleteCategory(ctx, id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			return ErrCategoryInUse
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *ProductService) GetCategoryTree(ctx context.Context) ([]CategoryNode, error) {
+	ctx, span := tracing.Start(ctx, "ProductService.GetCategoryTree")
+	defer span.End()
+
+	categories, err := s.store.ListAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCategoryTree(categories), nil
+}
+
+func (s *ProductService) checkParentCategoryExists(ctx context.Context, parentID uuid.UUID) error {
+	_, err := s.store.GetCategory(ctx, parentID)
+	if err == sql.ErrNoRows {
+		return ErrParentCategoryNotFound
+	}
+	return err
+}
+
 type AddProductServiceParams struct {
 	Name          string
 	Description   sql.NullString
@@ -177,6 +469,9 @@ type AddProductServiceParams struct {
 }
 
 func (s *ProductService) AddProduct(ctx context.Context, params AddProductServiceParams) error {
+	ctx, span := tracing.Start(ctx, "ProductService.AddProduct")
+	defer span.End()
+
 	_, err := s.store.AddProduct(ctx, db.AddProductParams{
 		Name:          params.Name,
 		Description:   params.Description,
@@ -187,7 +482,7 @@ func (s *ProductService) AddProduct(ctx context.Context, params AddProductServic
 		ImageUrl:      params.ImageUrl,
 	})
 
-	return err
+	return productWriteError(err)
 }
 
 type UpdateProductServiceParams struct {
@@ -202,7 +497,15 @@ type UpdateProductServiceParams struct {
 }
 
 func (s *ProductService) UpdateProduct(ctx context.Context, params UpdateProductServiceParams) error {
-	_, err := s.store.UpdateProduct(ctx, db.UpdateProductParams{
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateProduct")
+	defer span.End()
+
+	err := s.checkProductSeller(ctx, params.ID, params.SellerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.store.UpdateProduct(ctx, db.UpdateProductParams{
 		ID:            params.ID,
 		Name:          params.Name,
 		Description:   params.Description,
@@ -213,5 +516,155 @@ func (s *ProductService) UpdateProduct(ctx context.Context, params UpdateProduct
 		ImageUrl:      params.ImageUrl,
 	})
 
+	return productWriteError(err)
+}
+
+type DeleteProductServiceParams struct {
+	ID       uuid.UUID
+	SellerID uuid.UUID
+}
+
+func (s *ProductService) DeleteProduct(ctx context.Context, params DeleteProductServiceParams) error {
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteProduct")
+	defer span.End()
+
+	err := s.checkProductSeller(ctx, params.ID, params.SellerID)
+	if err != nil {
+		return err
+	}
+
+	return s.store.SoftDeleteProduct(ctx, params.ID)
+}
+
+type UpdateProductAsAdminServiceParams struct {
+	ID            uuid.UUID
+	Name          string
+	Description   sql.NullString
+	Price         decimal.Decimal
+	StockQuantity int32
+	CategoryID    uuid.UUID
+	ImageUrl      sql.NullString
+}
+
+// UpdateProductAsAdmin updates any product regardless of its seller, who is kept unchanged.
+func (s *ProductService) UpdateProductAsAdmin(ctx context.Context, params UpdateProductAsAdminServiceParams) error {
+	ctx, span := tracing.Start(ctx, "ProductService.UpdateProductAsAdmin")
+	defer span.End()
+
+	product, err := s.store.GetProduct(ctx, params.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	_, err = s.store.UpdateProduct(ctx, db.UpdateProductParams{
+		ID:            params.ID,
+		Name:          params.Name,
+		Description:   params.Description,
+		Price:         params.Price.String(),
+		StockQuantity: params.StockQuantity,
+		CategoryID:    params.CategoryID,
+		SellerID:      product.SellerID,
+		ImageUrl:      params.ImageUrl,
+	})
+
+	return productWriteError(err)
+}
+
+// DeleteProductAsAdmin soft deletes any product regardless of its seller.
+func (s *ProductService) DeleteProductAsAdmin(ctx context.Context, id uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "ProductService.DeleteProductAsAdmin")
+	defer span.End()
+
+	_, err := s.store.GetProduct(ctx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	return s.store.SoftDeleteProduct(ctx, id)
+}
+
+// categoryIDsFilter expands the category filter to the category and all of its descendants.
+// A nil slice disables the filter.
+func (s *ProductService) categoryIDsFilter(ctx context.Context, categoryID uuid.NullUUID) ([]uuid.UUID, error) {
+	if !categoryID.Valid {
+		return nil, nil
+	}
+
+	return s.store.GetCategoryDescendantIDs(ctx, categoryID.UUID)
+}
+
+func (s *ProductService) checkProductSeller(ctx context.Context, productID uuid.UUID, sellerID uuid.UUID) error {
+	product, err := s.store.GetProduct(ctx, productID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrProductNotFound
+		}
+		return err
+	}
+
+	if product.SellerID != sellerID {
+		return ErrNotProductSeller
+	}
+
+	return nil
+}
+
+// productWriteError translates the constraint violations of adding or updating a product
+func productWriteError(err error) error {
+	if pqErr, ok := err.(*pq.Error); ok {
+		switch pqErr.Code.Name() {
+		case "unique_violation":
+			return ErrProductAlreadyExists
+		case "foreign_key_violation":
+			return ErrProductCategoryNotFound
+		}
+	}
 	return err
 }
+
+func (s *ProductService) toProductsDomain(ctx context.Context, products []db.Product) ([]Product, error) {
+	categoryIDs := productsToCategoryIDs(products)
+	categories, err := s.store.GetCategoriesByIDs(ctx, categoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesMap := make(map[uuid.UUID]db.Category)
+	for _, category := range categories {
+		categoriesMap[category.ID] = category
+	}
+
+	sellersIDs := productsToSellersIDs(products)
+	sellers, err := s.store.GetUsersByIDs(ctx, sellersIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	sellersMap := make(map[uuid.UUID]db.User)
+	for _, seller := range sellers {
+		sellersMap[seller.ID] = seller
+	}
+
+	ratings, err := s.store.GetProductRatingsByProductIDs(ctx, productsToIDs(products))
+	if err != nil {
+		return nil, err
+	}
+
+	ratingsMap := make(map[uuid.UUID]db.GetProductRatingsByProductIDsRow)
+	for _, rating := range ratings {
+		ratingsMap[rating.ProductID] = rating
+	}
+
+	rsp := make([]Product, len(products))
+	for i, product := range products {
+		rsp[i] = toProductDomain(product, categoriesMap[product.CategoryID], sellersMap[product.SellerID], ratingsMap[product.ID])
+	}
+
+	return rsp, nil
+}
@@ -1,10 +1,32 @@
 package product_domain
 
 import (
+	"database/sql"
+
 	"github.com/google/uuid"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
 )
 
+// priceBucketBounds are the lower bounds of the price buckets of the product facets.
+// The last bucket has no upper bound.
+var priceBucketBounds = []decimal.Decimal{
+	decimal.NewFromInt(0),
+	decimal.NewFromInt(10),
+	decimal.NewFromInt(50),
+	decimal.NewFromInt(100),
+	decimal.NewFromInt(500),
+}
+
+func priceBucketBoundsToStrings() []string {
+	bounds := make([]string, len(priceBucketBounds))
+	for i, bound := range priceBucketBounds {
+		bounds[i] = bound.String()
+	}
+
+	return bounds
+}
+
 func productsToCategoryIDs(products []db.Product) []uuid.UUID {
 	categoryIDs := make([]uuid.UUID, len(products))
 	for i, product := range products {
@@ -14,6 +36,15 @@ func productsToCategoryIDs(products []db.Product) []uuid.UUID {
 	return categoryIDs
 }
 
+func productsToIDs(products []db.Product) []uuid.UUID {
+	ids := make([]uuid.UUID, len(products))
+	for i, product := range products {
+		ids[i] = product.ID
+	}
+
+	return ids
+}
+
 func productsToSellersIDs(products []db.Product) []uuid.UUID {
 	sellersIDs := make([]uuid.UUID, len(products))
 	for i, product := range products {
@@ -23,7 +54,7 @@ func productsToSellersIDs(products []db.Product) []uuid.UUID {
 	return sellersIDs
 }
 
-func toProductDomain(product db.Product, category db.Category, seller db.User) Product {
+func toProductDomain(product db.Product, category db.Category, seller db.User, rating db.GetProductRatingsByProductIDsRow) Product {
 	return Product{
 		ID:            product.ID,
 		Name:          product.Name,
@@ -35,12 +66,95 @@ func toProductDomain(product db.Product, category db.Category, seller db.User) P
 		SellerID:      seller.ID,
 		Seller:        seller.Name,
 		ImageUrl:      product.ImageUrl,
+		AverageRating: rating.AverageRating,
+		ReviewCount:   rating.ReviewCount,
 	}
 }
 
 func toCategoryDomain(category db.Category) Category {
 	return Category{
-		ID:   category.ID,
-		Name: category.Name,
+		ID:       category.ID,
+		Name:     category.Name,
+		ParentID: category.ParentID,
 	}
 }
+
+// buildCategoryTree arranges the categories into trees under their parents.
+// Categories whose parent is not in the list become roots. The order of the list is kept among siblings.
+func buildCategoryTree(categories []db.Category) []CategoryNode {
+	ids := make(map[uuid.UUID]bool, len(categories))
+	for _, category := range categories {
+		ids[category.ID] = true
+	}
+
+	childrenMap := make(map[uuid.UUID][]db.Category)
+	var roots []db.Category
+	for _, category := range categories {
+		if category.ParentID.Valid && ids[category.ParentID.UUID] {
+			childrenMap[category.ParentID.UUID] = append(childrenMap[category.ParentID.UUID], category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+
+	var build func(categories []db.Category) []CategoryNode
+	build = func(categories []db.Category) []CategoryNode {
+		nodes := make([]CategoryNode, len(categories))
+		for i, category := range categories {
+			nodes[i] = CategoryNode{
+				ID:       category.ID,
+				Name:     category.Name,
+				Children: build(childrenMap[category.ID]),
+			}
+		}
+		return nodes
+	}
+
+	return build(roots)
+}
+
+func nullDecimalToNullString(d decimal.NullDecimal) sql.NullString {
+	if !d.Valid {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: d.Decimal.String(), Valid: true}
+}
+
+func toCategoryFacetsDomain(rows []db.CountProductsByCategoryRow) []CategoryFacet {
+	facets := make([]CategoryFacet, len(rows))
+	for i, row := range rows {
+		facets[i] = CategoryFacet{
+			CategoryID: row.CategoryID,
+			Category:   row.Category,
+			Count:      row.Count,
+		}
+	}
+
+	return facets
+}
+
+// toPriceBucketFacetsDomain returns a facet for every price bucket, including the empty ones.
+// Rows are keyed by the bucket number of width_bucket, which starts from 1 for the first bound.
+func toPriceBucketFacetsDomain(rows []db.CountProductsByPriceBucketRow) []PriceBucketFacet {
+	counts := make(map[int32]int64)
+	for _, row := range rows {
+		counts[row.Bucket] = row.Count
+	}
+
+	facets := make([]PriceBucketFacet, len(priceBucketBounds))
+	for i, bound := range priceBucketBounds {
+		var maxPrice decimal.NullDecimal
+		if i+1 < len(priceBucketBounds) {
+			maxPrice = decimal.NullDecimal{Decimal: priceBucketBounds[i+1], Valid: true}
+		}
+
+		facets[i] = PriceBucketFacet{
+			MinPrice: bound,
+			MaxPrice: maxPrice,
+			Count:    counts[int32(i+1)],
+		}
+	}
+
+	return facets
+}
@@ -0,0 +1,60 @@
+package product_domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToPriceBucketFacetsDomain(t *testing.T) {
+	t.Parallel()
+
+	facets := toPriceBucketFacetsDomain([]db.CountProductsByPriceBucketRow{
+		{Bucket: 2, Count: 3},
+		{Bucket: 5, Count: 1},
+	})
+
+	require.Len(t, facets, len(priceBucketBounds))
+
+	expectedCounts := []int64{0, 3, 0, 0, 1}
+	for i, facet := range facets {
+		require.True(t, priceBucketBounds[i].Equal(facet.MinPrice))
+		require.Equal(t, expectedCounts[i], facet.Count)
+	}
+
+	require.True(t, facets[0].MaxPrice.Valid)
+	require.True(t, decimal.NewFromInt(10).Equal(facets[0].MaxPrice.Decimal))
+	require.False(t, facets[len(facets)-1].MaxPrice.Valid)
+}
+
+func TestBuildCategoryTree(t *testing.T) {
+	t.Parallel()
+
+	rootID := uuid.New()
+	childID := uuid.New()
+	grandchildID := uuid.New()
+	orphanID := uuid.New()
+	otherRootID := uuid.New()
+
+	nodes := buildCategoryTree([]db.Category{
+		{ID: otherRootID, Name: "Books"},
+		{ID: childID, Name: "Computers", ParentID: uuid.NullUUID{UUID: rootID, Valid: true}},
+		{ID: rootID, Name: "Electronics"},
+		{ID: grandchildID, Name: "Laptops", ParentID: uuid.NullUUID{UUID: childID, Valid: true}},
+		{ID: orphanID, Name: "Orphan", ParentID: uuid.NullUUID{UUID: uuid.New(), Valid: true}},
+	})
+
+	require.Len(t, nodes, 3)
+	require.Equal(t, otherRootID, nodes[0].ID)
+	require.Empty(t, nodes[0].Children)
+	require.Equal(t, rootID, nodes[1].ID)
+	require.Equal(t, orphanID, nodes[2].ID)
+
+	require.Len(t, nodes[1].Children, 1)
+	require.Equal(t, childID, nodes[1].Children[0].ID)
+	require.Len(t, nodes[1].Children[0].Children, 1)
+	require.Equal(t, grandchildID, nodes[1].Children[0].Children[0].ID)
+}
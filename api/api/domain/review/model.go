@@ -0,0 +1,102 @@
+package review_domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+)
+
+type Review struct {
+	ID        uuid.UUID
+	ProductID uuid.UUID
+	UserID    uuid.UUID
+	Reviewer  string
+	Rating    int32
+	Title     string
+	Body      sql.NullString
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ListReviewsRequestParams struct {
+	ProductID uuid.UUID `params:"id"`
+}
+
+type ListReviewsRequestQuery struct {
+	PageID   int32 `query:"page_id" json:"page_id" validate:"required,min=1"`
+	PageSize int32 `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+}
+
+type CreateReviewRequestParams struct {
+	ProductID uuid.UUID `params:"id"`
+}
+
+type CreateReviewRequestBody struct {
+	Rating int32  `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"required,max=100"`
+	Body   string `json:"body" validate:"omitempty,max=2000"`
+}
+
+type UpdateReviewRequestParams struct {
+	ProductID uuid.UUID `params:"id"`
+}
+
+type UpdateReviewRequestBody struct {
+	Rating int32  `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"required,max=100"`
+	Body   string `json:"body" validate:"omitempty,max=2000"`
+}
+
+type DeleteReviewRequest struct {
+	ProductID uuid.UUID `params:"id"`
+}
+
+type ReviewResponse struct {
+	ID        uuid.UUID     `json:"id"`
+	ProductID uuid.UUID     `json:"product_id"`
+	Reviewer  string        `json:"reviewer"`
+	Rating    int32         `json:"rating"`
+	Title     string        `json:"title"`
+	Body      db.NullString `json:"body" swaggertype:"string"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+func NewReviewResponse(review Review) ReviewResponse {
+	return ReviewResponse{
+		ID:        review.ID,
+		ProductID: review.ProductID,
+		Reviewer:  review.Reviewer,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      db.NullString{NullString: review.Body},
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
+
+type ReviewsResponse []ReviewResponse
+
+func NewReviewsResponse(reviews []Review) ReviewsResponse {
+	rsp := make(ReviewsResponse, 0, len(reviews))
+
+	for _, review := range reviews {
+		rsp = append(rsp, NewReviewResponse(review))
+	}
+
+	return rsp
+}
+
+type ListReviewsResponseMeta struct {
+	PageID     int32 `json:"page_id"`
+	PageSize   int32 `json:"page_size"`
+	PageCount  int64 `json:"page_count"`
+	TotalCount int64 `json:"total_count"`
+}
+
+type ListReviewsResponse struct {
+	Meta ListReviewsResponseMeta `json:"meta"`
+	Data ReviewsResponse         `json:"data"`
+}
@@ -0,0 +1,186 @@
+package review_domain
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/api/apperror"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/tracing"
+)
+
+var (
+	ErrReviewNotFound      = apperror.NotFound("review_not_found", "review not found")
+	ErrOwnProductReview    = apperror.Forbidden("own_product_review", "sellers cannot review their own products")
+	ErrReviewAlreadyExists = apperror.Conflict("review_already_exists", "product has already been reviewed by the user")
+)
+
+type ReviewService struct {
+	store db.Store
+}
+
+func NewReviewService(store db.Store) *ReviewService {
+	return &ReviewService{
+		store: store,
+	}
+}
+
+type GetReviewsServiceParams struct {
+	ProductID uuid.UUID
+	PageID    int32
+	PageSize  int32
+}
+
+func (s *ReviewService) GetReviews(ctx context.Context, params GetReviewsServiceParams) ([]Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.GetReviews")
+	defer span.End()
+
+	_, err := s.store.GetProduct(ctx, params.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, product_domain.ErrProductNotFound
+		}
+		return nil, err
+	}
+
+	reviews, err := s.store.ListReviewsByProductID(ctx, db.ListReviewsByProductIDParams{
+		Limit:     params.PageSize,
+		Offset:    (params.PageID - 1) * params.PageSize,
+		ProductID: params.ProductID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.store.GetUsersByIDs(ctx, reviewsToUserIDs(reviews))
+	if err != nil {
+		return nil, err
+	}
+
+	usersMap := make(map[uuid.UUID]db.User)
+	for _, user := range users {
+		usersMap[user.ID] = user
+	}
+
+	rsp := make([]Review, len(reviews))
+	for i, review := range reviews {
+		rsp[i] = toReviewDomain(review, usersMap[review.UserID])
+	}
+
+	return rsp, nil
+}
+
+func (s *ReviewService) CountReviews(ctx context.Context, productID uuid.UUID) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CountReviews")
+	defer span.End()
+
+	return s.store.CountReviewsByProductID(ctx, productID)
+}
+
+type CreateReviewServiceParams struct {
+	ProductID uuid.UUID
+	UserID    uuid.UUID
+	Rating    int32
+	Title     string
+	Body      sql.NullString
+}
+
+func (s *ReviewService) CreateReview(ctx context.Context, params CreateReviewServiceParams) (Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.CreateReview")
+	defer span.End()
+
+	product, err := s.store.GetProduct(ctx, params.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Review{}, product_domain.ErrProductNotFound
+		}
+		return Review{}, err
+	}
+
+	if product.SellerID == params.UserID {
+		return Review{}, ErrOwnProductReview
+	}
+
+	review, err := s.store.CreateReview(ctx, db.CreateReviewParams{
+		ProductID: params.ProductID,
+		UserID:    params.UserID,
+		Rating:    params.Rating,
+		Title:     params.Title,
+		Body:      params.Body,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return Review{}, ErrReviewAlreadyExists
+		}
+		return Review{}, err
+	}
+
+	return s.toReviewDomain(ctx, review)
+}
+
+type UpdateReviewServiceParams struct {
+	ProductID uuid.UUID
+	UserID    uuid.UUID
+	Rating    int32
+	Title     string
+	Body      sql.NullString
+}
+
+func (s *ReviewService) UpdateReview(ctx context.Context, params UpdateReviewServiceParams) (Review, error) {
+	ctx, span := tracing.Start(ctx, "ReviewService.UpdateReview")
+	defer span.End()
+
+	review, err := s.store.UpdateReview(ctx, db.UpdateReviewParams{
+		ProductID: params.ProductID,
+		UserID:    params.UserID,
+		Rating:    params.Rating,
+		Title:     params.Title,
+		Body:      params.Body,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Review{}, ErrReviewNotFound
+		}
+		return Review{}, err
+	}
+
+	return s.toReviewDomain(ctx, review)
+}
+
+type DeleteReviewServiceParams struct {
+	ProductID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (s *ReviewService) DeleteReview(ctx context.Context, params DeleteReviewServiceParams) error {
+	ctx, span := tracing.Start(ctx, "ReviewService.DeleteReview")
+	defer span.End()
+
+	_, err := s.store.GetReviewByProductIDAndUserID(ctx, db.GetReviewByProductIDAndUserIDParams{
+		ProductID: params.ProductID,
+		UserID:    params.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+
+	return s.store.DeleteReview(ctx, db.DeleteReviewParams{
+		ProductID: params.ProductID,
+		UserID:    params.UserID,
+	})
+}
+
+func (s *ReviewService) toReviewDomain(ctx context.Context, review db.Review) (Review, error) {
+	user, err := s.store.GetUser(ctx, review.UserID)
+	if err != nil {
+		return Review{}, err
+	}
+
+	return toReviewDomain(review, user), nil
+}
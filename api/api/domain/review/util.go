@@ -0,0 +1,29 @@
+package review_domain
+
+import (
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+)
+
+func reviewsToUserIDs(reviews []db.Review) []uuid.UUID {
+	userIDs := make([]uuid.UUID, len(reviews))
+	for i, review := range reviews {
+		userIDs[i] = review.UserID
+	}
+
+	return userIDs
+}
+
+func toReviewDomain(review db.Review, user db.User) Review {
+	return Review{
+		ID:        review.ID,
+		ProductID: review.ProductID,
+		UserID:    review.UserID,
+		Reviewer:  user.Name,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      review.Body,
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
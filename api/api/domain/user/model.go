@@ -1,9 +1,12 @@
 package user_domain
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/token"
 )
 
 type User struct {
@@ -11,16 +14,53 @@ type User struct {
 	Name              string
 	Email             string
 	HashedPassword    string
+	Role              db.UserRole
 	PasswordChangedAt time.Time
 	CreatedAt         time.Time
 }
 
 type Session struct {
-	ID           uuid.UUID
-	SessionToken uuid.UUID
-	UserID       uuid.UUID
-	ExpiredAt    time.Time
-	CreatedAt    time.Time
+	ID               uuid.UUID
+	SessionTokenHash []byte
+	UserID           uuid.UUID
+	ExpiredAt        time.Time
+	UserAgent        string
+	ClientIP         string
+	LastUsedAt       time.Time
+	CreatedAt        time.Time
+}
+
+// API key scopes limit what an API key may access. Session authentication is not limited by scopes.
+const (
+	// APIKeyScopeCatalogRead allows reading the product catalogue of the user
+	APIKeyScopeCatalogRead = "catalog:read"
+	// APIKeyScopeProductsManage allows managing the products the user sells
+	APIKeyScopeProductsManage = "products:manage"
+	// APIKeyScopeCartManage allows reading and changing the cart of the user
+	APIKeyScopeCartManage = "cart:manage"
+)
+
+const (
+	// LoginFailureScopeEmail counts failed logins per email
+	LoginFailureScopeEmail = "email"
+	// LoginFailureScopeIP counts failed logins per client IP
+	LoginFailureScopeIP = "ip"
+)
+
+type APIKey struct {
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Name       string
+	KeyPrefix  string
+	Scopes     []string
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type SessionTokens struct {
+	SessionID    uuid.UUID
+	SessionToken *token.Token
+	RefreshToken *token.Token
 }
 
 type RegisterRequest struct {
@@ -44,14 +84,167 @@ type UpdatePasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=8"`
 }
 
+type ListUsersRequest struct {
+	PageID   int32 `query:"page_id" json:"page_id" validate:"required,min=1"`
+	PageSize int32 `query:"page_size" json:"page_size" validate:"required,min=1,max=100"`
+}
+
+type GetUserRequest struct {
+	ID uuid.UUID `params:"id"`
+}
+
+type UpdateRoleRequestParams struct {
+	ID uuid.UUID `params:"id"`
+}
+
+type UpdateRoleRequestBody struct {
+	Role string `json:"role" validate:"required,oneof=customer seller admin" enums:"customer,seller,admin"`
+}
+
+type RevokeSessionRequest struct {
+	ID uuid.UUID `params:"id"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=catalog:read products:manage cart:manage" enums:"catalog:read,products:manage,cart:manage"`
+}
+
+type DeleteAPIKeyRequest struct {
+	ID uuid.UUID `params:"id"`
+}
+
 type UserResponse struct {
 	Name  string `json:"name"`
 	Email string `json:"email" swaggertype:"string"`
+	Role  string `json:"role"`
 }
 
 func NewUserResponse(user User) UserResponse {
 	return UserResponse{
 		Name:  user.Name,
 		Email: user.Email,
+		Role:  string(user.Role),
+	}
+}
+
+type AdminUserResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email" swaggertype:"string"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewAdminUserResponse(user User) AdminUserResponse {
+	return AdminUserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      string(user.Role),
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+type AdminUsersResponse []AdminUserResponse
+
+func NewAdminUsersResponse(users []User) AdminUsersResponse {
+	rsp := make(AdminUsersResponse, 0, len(users))
+
+	for _, user := range users {
+		rsp = append(rsp, NewAdminUserResponse(user))
+	}
+
+	return rsp
+}
+
+type ListUsersResponseMeta struct {
+	PageID     int32 `json:"page_id"`
+	PageSize   int32 `json:"page_size"`
+	PageCount  int64 `json:"page_count"`
+	TotalCount int64 `json:"total_count"`
+}
+
+type ListUsersResponse struct {
+	Meta ListUsersResponseMeta `json:"meta"`
+	Data AdminUsersResponse    `json:"data"`
+}
+
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+}
+
+func NewSessionResponse(session Session, currentSessionID uuid.UUID) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.ClientIP,
+		Current:    session.ID == currentSessionID,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiredAt:  session.ExpiredAt,
+	}
+}
+
+type SessionsResponse []SessionResponse
+
+func NewSessionsResponse(sessions []Session, currentSessionID uuid.UUID) SessionsResponse {
+	rsp := make(SessionsResponse, 0, len(sessions))
+
+	for _, session := range sessions {
+		rsp = append(rsp, NewSessionResponse(session, currentSessionID))
+	}
+
+	return rsp
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID   `json:"id"`
+	Name       string      `json:"name"`
+	Prefix     string      `json:"prefix"`
+	Scopes     []string    `json:"scopes"`
+	LastUsedAt db.NullTime `json:"last_used_at" swaggertype:"string"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+func NewAPIKeyResponse(apiKey APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.KeyPrefix,
+		Scopes:     apiKey.Scopes,
+		LastUsedAt: db.NullTime{NullTime: apiKey.LastUsedAt},
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
+
+type APIKeysResponse []APIKeyResponse
+
+func NewAPIKeysResponse(apiKeys []APIKey) APIKeysResponse {
+	rsp := make(APIKeysResponse, 0, len(apiKeys))
+
+	for _, apiKey := range apiKeys {
+		rsp = append(rsp, NewAPIKeyResponse(apiKey))
+	}
+
+	return rsp
+}
+
+// CreatedAPIKeyResponse contains the API key itself, which is only shown once
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func NewCreatedAPIKeyResponse(apiKey APIKey, key string) CreatedAPIKeyResponse {
+	return CreatedAPIKeyResponse{
+		APIKeyResponse: NewAPIKeyResponse(apiKey),
+		Key:            key,
 	}
 }
@@ -2,14 +2,45 @@ package user_domain
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/api/apperror"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
 	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/tracing"
 	"github.com/ot07/next-bazaar/util"
+	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrUserNotFound       = apperror.NotFound("user_not_found", "user not found")
+	ErrUserAlreadyExists  = apperror.Conflict("user_already_exists", "user with the same name or email already exists")
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials", "email or password is incorrect")
+	ErrIncorrectPassword  = apperror.Unauthorized("incorrect_password", "current password is incorrect")
+	ErrChangeOwnRole      = apperror.Forbidden("change_own_role", "users cannot change their own role")
+	ErrLoginLocked        = apperror.TooManyRequests("login_locked", "too many failed login attempts")
+	ErrSessionNotFound    = apperror.NotFound("session_not_found", "session not found")
+	ErrAPIKeyNotFound     = apperror.NotFound("api_key_not_found", "api key not found")
+)
+
+// LoginLockedError is returned when logins are locked after too many failed attempts.
+// It unwraps to ErrLoginLocked.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return ErrLoginLocked.Message
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return ErrLoginLocked
+}
+
 type UserService struct {
 	store db.Store
 }
@@ -21,39 +52,65 @@ func NewUserService(store db.Store) *UserService {
 }
 
 func (s *UserService) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUser")
+	defer span.End()
+
 	user, err := s.store.GetUser(ctx, id)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return User{}, ErrUserNotFound
+		}
 		return User{}, err
 	}
 
-	rsp := User{
-		ID:                user.ID,
-		Name:              user.Name,
-		Email:             user.Email,
-		HashedPassword:    user.HashedPassword,
-		PasswordChangedAt: user.PasswordChangedAt,
-		CreatedAt:         user.CreatedAt,
-	}
-
-	return rsp, err
+	return toUserDomain(user), nil
 }
 
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByEmail")
+	defer span.End()
+
 	user, err := s.store.GetUserByEmail(ctx, email)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return User{}, ErrUserNotFound
+		}
 		return User{}, err
 	}
 
-	rsp := User{
-		ID:                user.ID,
-		Name:              user.Name,
-		Email:             user.Email,
-		HashedPassword:    user.HashedPassword,
-		PasswordChangedAt: user.PasswordChangedAt,
-		CreatedAt:         user.CreatedAt,
+	return toUserDomain(user), nil
+}
+
+type GetUsersServiceParams struct {
+	PageID   int32
+	PageSize int32
+}
+
+func (s *UserService) GetUsers(ctx context.Context, params GetUsersServiceParams) ([]User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUsers")
+	defer span.End()
+
+	users, err := s.store.ListUsers(ctx, db.ListUsersParams{
+		Limit:  params.PageSize,
+		Offset: (params.PageID - 1) * params.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := make([]User, len(users))
+	for i, user := range users {
+		rsp[i] = toUserDomain(user)
 	}
 
-	return rsp, err
+	return rsp, nil
+}
+
+func (s *UserService) CountUsers(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CountUsers")
+	defer span.End()
+
+	return s.store.CountUsers(ctx)
 }
 
 type CreateUserServiceParams struct {
@@ -63,11 +120,17 @@ type CreateUserServiceParams struct {
 }
 
 func (s *UserService) CreateUser(ctx context.Context, params CreateUserServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.CreateUser")
+	defer span.End()
+
 	_, err := s.store.CreateUser(ctx, db.CreateUserParams{
 		Name:           params.Name,
 		Email:          params.Email,
 		HashedPassword: params.HashedPassword,
 	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrUserAlreadyExists
+	}
 
 	return err
 }
@@ -79,6 +142,9 @@ type UpdateUserServiceParams struct {
 }
 
 func (s *UserService) UpdateUser(ctx context.Context, params UpdateUserServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUser")
+	defer span.End()
+
 	user, err := s.GetUser(ctx, params.ID)
 	if err != nil {
 		return err
@@ -90,17 +156,24 @@ func (s *UserService) UpdateUser(ctx context.Context, params UpdateUserServicePa
 		Email:          params.Email,
 		HashedPassword: user.HashedPassword,
 	})
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+		return ErrUserAlreadyExists
+	}
 
 	return err
 }
 
 type UpdateUserPasswordServiceParams struct {
-	ID          uuid.UUID
-	OldPassword string
-	NewPassword string
+	ID               uuid.UUID
+	OldPassword      string
+	NewPassword      string
+	CurrentSessionID uuid.UUID
 }
 
 func (s *UserService) UpdateUserPassword(ctx context.Context, params UpdateUserPasswordServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUserPassword")
+	defer span.End()
+
 	user, err := s.GetUser(ctx, params.ID)
 	if err != nil {
 		return err
@@ -108,6 +181,9 @@ func (s *UserService) UpdateUserPassword(ctx context.Context, params UpdateUserP
 
 	err = util.CheckPassword(params.OldPassword, user.HashedPassword)
 	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return ErrIncorrectPassword
+		}
 		return err
 	}
 
@@ -122,32 +198,84 @@ func (s *UserService) UpdateUserPassword(ctx context.Context, params UpdateUserP
 		Email:          user.Email,
 		HashedPassword: hashedNewPassword,
 	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Sessions opened with the old password must not outlive it
+	return s.store.DeleteOtherSessionsByUserID(ctx, db.DeleteOtherSessionsByUserIDParams{
+		UserID:           params.ID,
+		CurrentSessionID: params.CurrentSessionID,
+	})
+}
+
+type UpdateUserRoleServiceParams struct {
+	ID          uuid.UUID
+	Role        db.UserRole
+	RequesterID uuid.UUID
+}
+
+func (s *UserService) UpdateUserRole(ctx context.Context, params UpdateUserRoleServiceParams) (User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateUserRole")
+	defer span.End()
+
+	if params.ID == params.RequesterID {
+		return User{}, ErrChangeOwnRole
+	}
+
+	user, err := s.store.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+		ID:   params.ID,
+		Role: params.Role,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return User{}, ErrUserNotFound
+		}
+		return User{}, err
+	}
+
+	logging.FromContext(ctx).Info("user role changed",
+		"user_id", user.ID,
+		"role", user.Role,
+		"requester_id", params.RequesterID,
+	)
+
+	return toUserDomain(user), nil
 }
 
 type CreateSessionServiceParams struct {
 	UserID               uuid.UUID
 	SessionTokenDuration time.Duration
 	RefreshTokenDuration time.Duration
+	UserAgent            string
+	ClientIP             string
 }
 
-func (s *UserService) CreateSession(ctx context.Context, params CreateSessionServiceParams) (*token.Token, error) {
+func (s *UserService) CreateSession(ctx context.Context, params CreateSessionServiceParams) (SessionTokens, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateSession")
+	defer span.End()
+
 	sessionToken := token.NewToken(params.SessionTokenDuration)
 	refreshToken := token.NewToken(params.RefreshTokenDuration)
 
-	_, err := s.store.CreateSession(ctx, db.CreateSessionParams{
+	session, err := s.store.CreateSession(ctx, db.CreateSessionParams{
 		UserID:                params.UserID,
-		SessionToken:          sessionToken.ID,
+		SessionTokenHash:      sessionToken.Hash(),
 		SessionTokenExpiredAt: sessionToken.ExpiredAt,
-		RefreshToken:          refreshToken.ID,
+		RefreshTokenHash:      refreshToken.Hash(),
 		RefreshTokenExpiredAt: refreshToken.ExpiredAt,
+		UserAgent:             params.UserAgent,
+		ClientIp:              params.ClientIP,
 	})
 	if err != nil {
-		return nil, err
+		return SessionTokens{}, err
 	}
 
-	return sessionToken, nil
+	return SessionTokens{
+		SessionID:    session.ID,
+		SessionToken: sessionToken,
+		RefreshToken: refreshToken,
+	}, nil
 }
 
 type RegisterServiceParams struct {
@@ -157,6 +285,9 @@ type RegisterServiceParams struct {
 }
 
 func (s *UserService) Register(ctx context.Context, params RegisterServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer span.End()
+
 	hashedPassword, err := util.HashPassword(params.Password)
 	if err != nil {
 		return err
@@ -174,33 +305,311 @@ type LoginServiceParams struct {
 	Password             string
 	SessionTokenDuration time.Duration
 	RefreshTokenDuration time.Duration
+	UserAgent            string
+	ClientIP             string
+	Lockout              util.LoginLockoutConfig
 }
 
-func (s *UserService) Login(ctx context.Context, params LoginServiceParams) (*token.Token, error) {
-	user, err := s.GetUserByEmail(ctx, params.Email)
+// Login checks the credentials and creates a new session.
+// Failed attempts are counted per email and per client IP. Once either exceeds its limit,
+// a *LoginLockedError is returned without checking the credentials until the lockout has passed.
+func (s *UserService) Login(ctx context.Context, params LoginServiceParams) (User, SessionTokens, error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer span.End()
+
+	keys := loginFailureKeys(params)
+
+	err := s.checkLoginLockout(ctx, keys)
 	if err != nil {
-		return nil, err
+		return User{}, SessionTokens{}, err
 	}
 
-	err = util.CheckPassword(params.Password, user.HashedPassword)
+	user, err := s.GetUserByEmail(ctx, params.Email)
+	if err == nil {
+		err = util.CheckPassword(params.Password, user.HashedPassword)
+	}
 	if err != nil {
-		return nil, err
+		if err == ErrUserNotFound || err == bcrypt.ErrMismatchedHashAndPassword {
+			if lockErr := s.recordLoginFailure(ctx, keys, params.Lockout); lockErr != nil {
+				return User{}, SessionTokens{}, lockErr
+			}
+			return User{}, SessionTokens{}, ErrInvalidCredentials
+		}
+		return User{}, SessionTokens{}, err
+	}
+
+	// Failures from the client IP are kept, so that logging in to an own account does not lift its limit
+	if params.Lockout.MaxAttemptsPerEmail > 0 {
+		err = s.store.DeleteLoginFailure(ctx, db.DeleteLoginFailureParams{
+			Scope: LoginFailureScopeEmail,
+			Key:   normalizeEmail(params.Email),
+		})
+		if err != nil {
+			return User{}, SessionTokens{}, err
+		}
 	}
 
 	arg := CreateSessionServiceParams{
 		UserID:               user.ID,
 		SessionTokenDuration: params.SessionTokenDuration,
 		RefreshTokenDuration: params.RefreshTokenDuration,
+		UserAgent:            params.UserAgent,
+		ClientIP:             params.ClientIP,
+	}
+
+	tokens, err := s.CreateSession(ctx, arg)
+	if err != nil {
+		return User{}, SessionTokens{}, err
+	}
+
+	return user, tokens, nil
+}
+
+type loginFailureKey struct {
+	scope       string
+	key         string
+	maxAttempts int
+}
+
+// loginFailureKeys returns the keys failed logins are counted by, leaving out the disabled ones
+func loginFailureKeys(params LoginServiceParams) []loginFailureKey {
+	keys := make([]loginFailureKey, 0, 2)
+
+	if params.Lockout.MaxAttemptsPerEmail > 0 {
+		keys = append(keys, loginFailureKey{
+			scope:       LoginFailureScopeEmail,
+			key:         normalizeEmail(params.Email),
+			maxAttempts: params.Lockout.MaxAttemptsPerEmail,
+		})
+	}
+	if params.Lockout.MaxAttemptsPerIP > 0 {
+		keys = append(keys, loginFailureKey{
+			scope:       LoginFailureScopeIP,
+			key:         params.ClientIP,
+			maxAttempts: params.Lockout.MaxAttemptsPerIP,
+		})
+	}
+
+	return keys
+}
+
+func (s *UserService) checkLoginLockout(ctx context.Context, keys []loginFailureKey) error {
+	var lockedUntil time.Time
+
+	for _, key := range keys {
+		failure, err := s.store.GetLoginFailure(ctx, db.GetLoginFailureParams{
+			Scope: key.scope,
+			Key:   key.key,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return err
+		}
+
+		if failure.LockedUntil.Valid && failure.LockedUntil.Time.After(lockedUntil) {
+			lockedUntil = failure.LockedUntil.Time
+		}
+	}
+
+	if retryAfter := time.Until(lockedUntil); retryAfter > 0 {
+		return &LoginLockedError{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+// recordLoginFailure counts the failed login for every key and locks the keys that exceed their limit.
+// A *LoginLockedError is returned if any key has been locked.
+func (s *UserService) recordLoginFailure(ctx context.Context, keys []loginFailureKey, config util.LoginLockoutConfig) error {
+	now := time.Now()
+
+	var lockout time.Duration
+
+	for _, key := range keys {
+		failure, err := s.store.RecordLoginFailure(ctx, db.RecordLoginFailureParams{
+			Scope:       key.scope,
+			Key:         key.key,
+			ResetBefore: now.Add(-config.FailureWindow),
+		})
+		if err != nil {
+			return err
+		}
+
+		if int(failure.FailureCount) < key.maxAttempts {
+			continue
+		}
+
+		duration := loginLockoutDuration(config, int(failure.FailureCount)-key.maxAttempts)
+
+		_, err = s.store.LockLoginTx(ctx, db.LockLoginTxParams{
+			Scope:        key.scope,
+			Key:          key.key,
+			FailureCount: failure.FailureCount,
+			LockedUntil:  now.Add(duration),
+		})
+		if err != nil {
+			return err
+		}
+
+		// The key is an email or an IP address, which is not logged
+		logging.FromContext(ctx).Warn("login locked",
+			"scope", key.scope,
+			"failure_count", failure.FailureCount,
+			"lockout", duration,
+		)
+
+		if duration > lockout {
+			lockout = duration
+		}
+	}
+
+	if lockout > 0 {
+		return &LoginLockedError{RetryAfter: lockout}
+	}
+
+	return nil
+}
+
+// loginLockoutDuration doubles the lockout duration for every failure beyond the limit
+// and caps it at the maximum lockout duration.
+func loginLockoutDuration(config util.LoginLockoutConfig, excessFailures int) time.Duration {
+	duration := config.LockoutDuration
+
+	for i := 0; i < excessFailures && duration < config.MaxLockoutDuration; i++ {
+		duration *= 2
+	}
+
+	if config.MaxLockoutDuration > 0 && duration > config.MaxLockoutDuration {
+		duration = config.MaxLockoutDuration
 	}
 
-	sessionToken, err := s.CreateSession(ctx, arg)
+	return duration
+}
+
+func (s *UserService) Logout(ctx context.Context, sessionID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer span.End()
+
+	return s.store.DeleteSessionByID(ctx, sessionID)
+}
+
+func (s *UserService) GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetSessionsByUserID")
+	defer span.End()
+
+	dbSessions, err := s.store.ListSessionsByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	return sessionToken, nil
+	sessions := make([]Session, len(dbSessions))
+	for i, dbSession := range dbSessions {
+		sessions[i] = toSessionDomain(dbSession)
+	}
+
+	return sessions, nil
 }
 
-func (s *UserService) Logout(ctx context.Context, sessionTokenID uuid.UUID) error {
-	return s.store.DeleteSession(ctx, sessionTokenID)
+type RevokeSessionServiceParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (s *UserService) RevokeSession(ctx context.Context, params RevokeSessionServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.RevokeSession")
+	defer span.End()
+
+	session, err := s.store.GetSessionByID(ctx, params.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	// Sessions of other users are reported as missing so that their IDs are not revealed
+	if session.UserID != params.UserID {
+		return ErrSessionNotFound
+	}
+
+	return s.store.DeleteSessionByID(ctx, params.ID)
+}
+
+func (s *UserService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	ctx, span := tracing.Start(ctx, "UserService.RevokeAllSessions")
+	defer span.End()
+
+	return s.store.DeleteSessionsByUserID(ctx, userID)
+}
+
+type CreateAPIKeyServiceParams struct {
+	UserID uuid.UUID
+	Name   string
+	Scopes []string
+}
+
+// CreateAPIKey creates a new API key and returns it along with the key itself.
+// Only the hash of the key is stored, so it cannot be shown again.
+func (s *UserService) CreateAPIKey(ctx context.Context, params CreateAPIKeyServiceParams) (APIKey, string, error) {
+	ctx, span := tracing.Start(ctx, "UserService.CreateAPIKey")
+	defer span.End()
+
+	key, keyPrefix := token.NewAPIKey()
+
+	apiKey, err := s.store.CreateApiKey(ctx, db.CreateApiKeyParams{
+		UserID:    params.UserID,
+		Name:      params.Name,
+		KeyPrefix: keyPrefix,
+		KeyHash:   token.HashToken(key),
+		Scopes:    params.Scopes,
+	})
+	if err != nil {
+		return APIKey{}, "", err
+	}
+
+	return toAPIKeyDomain(apiKey), key, nil
+}
+
+func (s *UserService) GetAPIKeysByUserID(ctx context.Context, userID uuid.UUID) ([]APIKey, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetAPIKeysByUserID")
+	defer span.End()
+
+	dbAPIKeys, err := s.store.ListApiKeysByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]APIKey, len(dbAPIKeys))
+	for i, dbAPIKey := range dbAPIKeys {
+		apiKeys[i] = toAPIKeyDomain(dbAPIKey)
+	}
+
+	return apiKeys, nil
+}
+
+type DeleteAPIKeyServiceParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (s *UserService) DeleteAPIKey(ctx context.Context, params DeleteAPIKeyServiceParams) error {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteAPIKey")
+	defer span.End()
+
+	apiKey, err := s.store.GetApiKey(ctx, params.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	// API keys of other users are reported as missing so that their IDs are not revealed
+	if apiKey.UserID != params.UserID {
+		return ErrAPIKeyNotFound
+	}
+
+	return s.store.DeleteApiKey(ctx, params.ID)
 }
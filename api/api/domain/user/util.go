@@ -0,0 +1,50 @@
+package user_domain
+
+import (
+	"strings"
+
+	db "github.com/ot07/next-bazaar/db/sqlc"
+)
+
+// normalizeEmail returns the form of the email failed logins are counted by,
+// so that variants of the same address share one counter.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func toUserDomain(user db.User) User {
+	return User{
+		ID:                user.ID,
+		Name:              user.Name,
+		Email:             user.Email,
+		HashedPassword:    user.HashedPassword,
+		Role:              user.Role,
+		PasswordChangedAt: user.PasswordChangedAt,
+		CreatedAt:         user.CreatedAt,
+	}
+}
+
+func toSessionDomain(session db.Session) Session {
+	return Session{
+		ID:               session.ID,
+		SessionTokenHash: session.SessionTokenHash,
+		UserID:           session.UserID,
+		ExpiredAt:        session.RefreshTokenExpiredAt,
+		UserAgent:        session.UserAgent,
+		ClientIP:         session.ClientIp,
+		LastUsedAt:       session.LastUsedAt,
+		CreatedAt:        session.CreatedAt,
+	}
+}
+
+func toAPIKeyDomain(apiKey db.ApiKey) APIKey {
+	return APIKey{
+		ID:         apiKey.ID,
+		UserID:     apiKey.UserID,
+		Name:       apiKey.Name,
+		KeyPrefix:  apiKey.KeyPrefix,
+		Scopes:     apiKey.Scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		CreatedAt:  apiKey.CreatedAt,
+	}
+}
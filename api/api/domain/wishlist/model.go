@@ -0,0 +1,73 @@
+package wishlist_domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/shopspring/decimal"
+)
+
+type WishlistProduct struct {
+	ID            uuid.UUID
+	Name          string
+	Description   sql.NullString
+	Price         decimal.Decimal
+	StockQuantity int32
+	ImageUrl      sql.NullString
+	AddedAt       time.Time
+}
+
+type AddProductRequest struct {
+	ProductID uuid.UUID `json:"product_id" validate:"required"`
+}
+
+type DeleteProductRequest struct {
+	ProductID uuid.UUID `params:"product_id"`
+}
+
+type MoveProductToCartRequestParams struct {
+	ProductID uuid.UUID `params:"product_id"`
+}
+
+type MoveProductToCartRequestBody struct {
+	Quantity int32 `json:"quantity" validate:"required,min=1"`
+}
+
+type WishlistProductResponse struct {
+	ID            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Description   db.NullString `json:"description" swaggertype:"string"`
+	Price         db.Decimal    `json:"price" swaggertype:"string"`
+	StockQuantity int32         `json:"stock_quantity"`
+	ImageUrl      db.NullString `json:"image_url" swaggertype:"string"`
+	AddedAt       time.Time     `json:"added_at"`
+}
+
+func NewWishlistProductResponse(wishlistProduct WishlistProduct) WishlistProductResponse {
+	return WishlistProductResponse{
+		ID:            wishlistProduct.ID,
+		Name:          wishlistProduct.Name,
+		Description:   db.NullString{NullString: wishlistProduct.Description},
+		Price:         db.Decimal{Decimal: wishlistProduct.Price},
+		StockQuantity: wishlistProduct.StockQuantity,
+		ImageUrl:      db.NullString{NullString: wishlistProduct.ImageUrl},
+		AddedAt:       wishlistProduct.AddedAt,
+	}
+}
+
+type WishlistResponse struct {
+	Products []WishlistProductResponse `json:"products"`
+}
+
+func NewWishlistResponse(products []WishlistProduct) WishlistResponse {
+	productsRsp := make([]WishlistProductResponse, 0, len(products))
+	for _, product := range products {
+		productsRsp = append(productsRsp, NewWishlistProductResponse(product))
+	}
+
+	return WishlistResponse{
+		Products: productsRsp,
+	}
+}
@@ -0,0 +1,132 @@
+package wishlist_domain
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/api/apperror"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/tracing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrWishlistProductNotFound  = apperror.NotFound("wishlist_product_not_found", "product is not in the wishlist")
+	ErrProductAlreadyInWishlist = apperror.Conflict("product_already_in_wishlist", "product is already in the wishlist")
+)
+
+type WishlistService struct {
+	store db.Store
+}
+
+func NewWishlistService(store db.Store) *WishlistService {
+	return &WishlistService{
+		store: store,
+	}
+}
+
+func (s *WishlistService) GetProductsByUserID(ctx context.Context, userID uuid.UUID) ([]WishlistProduct, error) {
+	ctx, span := tracing.Start(ctx, "WishlistService.GetProductsByUserID")
+	defer span.End()
+
+	wishlistProducts, err := s.store.GetWishlistProductsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rsp := make([]WishlistProduct, len(wishlistProducts))
+	for i, wishlistProduct := range wishlistProducts {
+		product, err := s.store.GetProduct(ctx, wishlistProduct.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		price, err := decimal.NewFromString(product.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		rsp[i] = WishlistProduct{
+			ID:            product.ID,
+			Name:          product.Name,
+			Description:   product.Description,
+			Price:         price,
+			StockQuantity: product.StockQuantity,
+			ImageUrl:      product.ImageUrl,
+			AddedAt:       wishlistProduct.CreatedAt,
+		}
+	}
+
+	return rsp, nil
+}
+
+type AddProductServiceParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (s *WishlistService) AddProduct(ctx context.Context, params AddProductServiceParams) error {
+	ctx, span := tracing.Start(ctx, "WishlistService.AddProduct")
+	defer span.End()
+
+	_, err := s.store.GetProduct(ctx, params.ProductID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return product_domain.ErrProductNotFound
+		}
+		return err
+	}
+
+	_, err = s.store.CreateWishlistProduct(ctx, db.CreateWishlistProductParams{
+		UserID:    params.UserID,
+		ProductID: params.ProductID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			return ErrProductAlreadyInWishlist
+		}
+		return err
+	}
+
+	return nil
+}
+
+type DeleteProductServiceParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+}
+
+func (s *WishlistService) DeleteProduct(ctx context.Context, params DeleteProductServiceParams) error {
+	ctx, span := tracing.Start(ctx, "WishlistService.DeleteProduct")
+	defer span.End()
+
+	return s.store.DeleteWishlistProduct(ctx, db.DeleteWishlistProductParams{
+		UserID:    params.UserID,
+		ProductID: params.ProductID,
+	})
+}
+
+type MoveProductToCartServiceParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int32
+}
+
+func (s *WishlistService) MoveProductToCart(ctx context.Context, params MoveProductToCartServiceParams) error {
+	ctx, span := tracing.Start(ctx, "WishlistService.MoveProductToCart")
+	defer span.End()
+
+	_, err := s.store.MoveWishlistProductToCartTx(ctx, db.MoveWishlistProductToCartTxParams{
+		UserID:    params.UserID,
+		ProductID: params.ProductID,
+		Quantity:  params.Quantity,
+	})
+	if err == sql.ErrNoRows {
+		return ErrWishlistProductNotFound
+	}
+
+	return err
+}
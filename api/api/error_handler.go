@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/ot07/next-bazaar/api/apperror"
+	"github.com/ot07/next-bazaar/api/validation"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+)
+
+const ctxLocalRequestIDKey = "request_id"
+
+const codeInsufficientStock = "insufficient_stock"
+
+var kindStatuses = map[apperror.Kind]int{
+	apperror.KindValidation:      fiber.StatusBadRequest,
+	apperror.KindUnauthorized:    fiber.StatusUnauthorized,
+	apperror.KindForbidden:       fiber.StatusForbidden,
+	apperror.KindNotFound:        fiber.StatusNotFound,
+	apperror.KindConflict:        fiber.StatusConflict,
+	apperror.KindTooManyRequests: fiber.StatusTooManyRequests,
+}
+
+// errorHandler responds to the errors returned by handlers and middlewares.
+// Only apperror.Error and a few known errors are reported to clients. Any other error is logged along with
+// the request id, and the client only gets the request id to refer to it.
+func errorHandler(c *fiber.Ctx, err error) error {
+	requestID := getRequestID(c)
+
+	var stockErr *db.InsufficientStockError
+	if errors.As(err, &stockErr) {
+		return c.Status(fiber.StatusConflict).JSON(newInsufficientStockResponse(stockErr, requestID))
+	}
+
+	if appErr, ok := apperror.As(err); ok {
+		status, ok := kindStatuses[appErr.Kind]
+		if ok {
+			if appErr.Kind == apperror.KindValidation && len(appErr.Code) == 0 {
+				rsp := validation.NewErrorResponse(appErr.Err, c.Get(fiber.HeaderAcceptLanguage))
+				return c.Status(status).JSON(errorResponse{
+					Code:      rsp.Code,
+					Message:   rsp.Message,
+					Fields:    rsp.Fields,
+					RequestID: requestID,
+				})
+			}
+
+			return c.Status(status).JSON(newErrorResponse(appErr.Code, appErr.Message, requestID))
+		}
+	}
+
+	// Errors of fiber itself, e.g. for unknown routes or too large bodies
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && fiberErr.Code < fiber.StatusInternalServerError {
+		code := strings.ReplaceAll(strings.ToLower(utils.StatusMessage(fiberErr.Code)), " ", "_")
+		return c.Status(fiberErr.Code).JSON(newErrorResponse(code, fiberErr.Message, requestID))
+	}
+
+	logging.FromContext(c.UserContext()).Error("request failed",
+		"method", c.Method(),
+		"path", c.Path(),
+		"error", err,
+	)
+
+	return c.Status(fiber.StatusInternalServerError).
+		JSON(newErrorResponse(apperror.CodeInternal, "internal server error", requestID))
+}
+
+// getRequestID returns the id the request has been given by the requestid middleware.
+func getRequestID(c *fiber.Ctx) string {
+	requestID, _ := c.Locals(ctxLocalRequestIDKey).(string)
+	return requestID
+}
@@ -0,0 +1,199 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
+	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	"github.com/ot07/next-bazaar/api/test_util"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHandler(t *testing.T) {
+	productID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		err           error
+		path          string
+		checkResponse func(t *testing.T, response *http.Response, body map[string]any)
+	}{
+		{
+			name: "NotFound",
+			err:  product_domain.ErrProductNotFound,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+				require.Equal(t, "product_not_found", body["code"])
+				require.Equal(t, "product not found", body["message"])
+			},
+		},
+		{
+			name: "Conflict",
+			err:  product_domain.ErrCategoryInUse,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+				require.Equal(t, "category_in_use", body["code"])
+			},
+		},
+		{
+			name: "Unauthorized",
+			err:  errExpiredToken,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Equal(t, "expired_token", body["code"])
+			},
+		},
+		{
+			name: "Forbidden",
+			err:  product_domain.ErrNotProductSeller,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+				require.Equal(t, "not_product_seller", body["code"])
+			},
+		},
+		{
+			name: "Validation",
+			err:  product_domain.ErrInvalidCursor,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, "invalid_cursor", body["code"])
+				require.NotContains(t, body, "fields")
+			},
+		},
+		{
+			name: "InvalidRequest",
+			err:  apperror.InvalidRequest(errors.New("unexpected end of JSON input")),
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, "invalid_request", body["code"])
+			},
+		},
+		{
+			name: "WrappedError",
+			err:  fmt.Errorf("cannot get product: %w", product_domain.ErrProductNotFound),
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+				require.Equal(t, "product_not_found", body["code"])
+			},
+		},
+		{
+			name: "InsufficientStock",
+			err: &db.InsufficientStockError{
+				ProductID: productID,
+				Requested: 3,
+				Available: 2,
+			},
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+				require.Equal(t, codeInsufficientStock, body["code"])
+				require.Equal(t, productID.String(), body["product_id"])
+				require.Equal(t, float64(3), body["requested"])
+				require.Equal(t, float64(2), body["available"])
+			},
+		},
+		{
+			name: "InternalError",
+			err:  errors.New(`pq: relation "products" does not exist`),
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+				require.Equal(t, apperror.CodeInternal, body["code"])
+				require.Equal(t, "internal server error", body["message"])
+				require.NotContains(t, body["message"], "pq")
+			},
+		},
+		{
+			name: "NoRows",
+			err:  sql.ErrNoRows,
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+				require.Equal(t, apperror.CodeInternal, body["code"])
+			},
+		},
+		{
+			name: "UnknownRoute",
+			path: "/unknown",
+			checkResponse: func(t *testing.T, response *http.Response, body map[string]any) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+				require.Equal(t, "not_found", body["code"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			server := newTestServer(t, store)
+			server.app.Get("/error", func(c *fiber.Ctx) error {
+				return tc.err
+			})
+
+			path := tc.path
+			if len(path) == 0 {
+				path = "/error"
+			}
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    path,
+			})
+
+			response := test_util.SendRequest(t, server.app, request)
+
+			body := unmarshalErrorBody(t, response.Body)
+			requestID := response.Header.Get(fiber.HeaderXRequestID)
+			require.NotEmpty(t, requestID)
+			require.Equal(t, requestID, body["request_id"])
+
+			tc.checkResponse(t, response, body)
+		})
+	}
+}
+
+func TestErrorHandlerRequestID(t *testing.T) {
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	server := newTestServer(t, store)
+	server.app.Get("/error", func(c *fiber.Ctx) error {
+		return errors.New("unexpected error")
+	})
+
+	request := test_util.NewRequest(t, test_util.RequestParams{
+		Method: http.MethodGet,
+		URL:    "/error",
+	})
+	request.Header.Set(fiber.HeaderXRequestID, "client-request-id")
+
+	response := test_util.SendRequest(t, server.app, request)
+	require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	require.Equal(t, "client-request-id", response.Header.Get(fiber.HeaderXRequestID))
+
+	body := unmarshalErrorBody(t, response.Body)
+	require.Equal(t, "client-request-id", body["request_id"])
+}
+
+func unmarshalErrorBody(t *testing.T, body io.ReadCloser) map[string]any {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed map[string]any
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
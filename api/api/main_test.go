@@ -14,8 +14,15 @@ import (
 )
 
 func newTestServer(t *testing.T, store db.Store) *Server {
+	return newTestServerWithAuthMode(t, store, util.AuthModeSession)
+}
+
+func newTestServerWithAuthMode(t *testing.T, store db.Store, authMode string) *Server {
 	config := util.Config{
-		SessionTokenDuration: time.Minute,
+		AuthMode:                authMode,
+		AccessTokenSymmetricKey: util.RandomString(32),
+		AccessTokenDuration:     time.Minute,
+		SessionTokenDuration:    time.Minute,
 	}
 
 	server, err := NewServer(config, store)
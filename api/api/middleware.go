@@ -1,55 +1,544 @@
 package api
 
 import (
+	"crypto/subtle"
 	"database/sql"
 	"fmt"
+	"math"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/api/apperror"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+	"github.com/ot07/next-bazaar/ratelimit"
 	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/tracing"
+	"github.com/ot07/next-bazaar/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 )
 
 const (
-	cookieSessionTokenKey = "session_token"
-	ctxLocalSessionKey    = "session"
+	cookieSessionTokenKey   = "session_token"
+	cookieRefreshTokenKey   = "refresh_token"
+	ctxLocalSessionKey      = "session"
+	ctxLocalRoleKey         = "role"
+	ctxLocalAPIKeyKey       = "api_key"
+	authorizationTypeBearer = "bearer"
 )
 
+// sessionTouchInterval is how stale the last use of a session or an API key may get
+// before it is written back to the database.
+const sessionTouchInterval = time.Minute
+
+// maxRequestIDLength is the length up to which incoming request ids are accepted.
+const maxRequestIDLength = 128
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+var (
+	errInvalidAuthorizationHeader = apperror.Unauthorized("invalid_authorization_header", "invalid authorization header format")
+	errSessionTokenNotFound       = apperror.Unauthorized("session_token_not_found", "session token not found")
+	errInvalidToken               = apperror.Unauthorized("invalid_token", token.ErrInvalidToken.Error())
+	errExpiredToken               = apperror.Unauthorized("expired_token", token.ErrExpiredToken.Error())
+	errRefreshTokenReused         = apperror.Unauthorized("refresh_token_reused", db.ErrRefreshTokenReused.Error())
+	errAPIKeyNotAllowed           = apperror.Unauthorized("api_key_not_allowed", "api key is not allowed to access this resource")
+	errInsufficientScope          = apperror.Forbidden("insufficient_scope", "api key does not have the scope to access this resource")
+	errRoleNotAllowed             = apperror.Forbidden("role_not_allowed", "user role is not allowed to access this resource")
+	errRateLimitExceeded          = apperror.TooManyRequests("rate_limit_exceeded", "rate limit exceeded")
+	errInvalidMetricsToken        = apperror.Unauthorized("invalid_metrics_token", "invalid metrics token")
+)
+
+// requestIDMiddleware gives the request an id, which is sent back in the X-Request-ID header.
+// The id of the incoming X-Request-ID header is kept, so that requests can be traced across services,
+// unless it is too long or contains characters other than printable ASCII.
+func requestIDMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(fiber.HeaderXRequestID)
+		if isValidRequestID(requestID) {
+			requestID = utils.CopyString(requestID)
+		} else {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(fiber.HeaderXRequestID, requestID)
+		c.Locals(ctxLocalRequestIDKey, requestID)
+		return c.Next()
+	}
+}
+
+func isValidRequestID(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] <= ' ' || requestID[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// tracingMiddleware starts the server span of the request, continuing the trace of the incoming W3C trace context
+// headers, and puts it into the user context of the request, where the spans of services and queries become its
+// children. It must be registered before accessLogMiddleware, which passes errors to the error handler,
+// so that the span has the final status.
+//
+// The span is named after the method and the route template, e.g. GET /api/v1/products/:id.
+func tracingMiddleware(server *Server) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := server.propagator.Extract(c.UserContext(), requestHeaderCarrier{c: c})
+
+		// Span attributes are kept after the request, so they must not refer to the request buffer
+		method := utils.CopyString(c.Method())
+		ctx, span := server.tracerProvider.Tracer(tracing.InstrumentationName).Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethod(method),
+				attribute.String("http.request_id", getRequestID(c)),
+			),
+		)
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPStatusCode(status))
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, utils.StatusMessage(status))
+		}
+		return err
+	}
+}
+
+// requestHeaderCarrier lets propagators read the trace context from the request headers.
+type requestHeaderCarrier struct {
+	c *fiber.Ctx
+}
+
+func (carrier requestHeaderCarrier) Get(key string) string {
+	return carrier.c.Get(key)
+}
+
+func (carrier requestHeaderCarrier) Set(key string, value string) {
+	carrier.c.Request().Header.Set(key, value)
+}
+
+func (carrier requestHeaderCarrier) Keys() []string {
+	var keys []string
+	carrier.c.Request().Header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// accessLogMiddleware puts a logger carrying the request id into the user context of the request,
+// where handlers and services get it with logging.FromContext, and writes an access log record
+// once the request has been handled. It must be registered after requestIDMiddleware.
+//
+// Errors are passed to the error handler here, so that the record has the status of the response.
+// Panics are recovered and handled as errors for the same reason.
+func accessLogMiddleware(server *Server) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		logger := server.logger.With(slog.String("request_id", getRequestID(c)))
+		if spanContext := trace.SpanContextFromContext(c.UserContext()); spanContext.IsValid() {
+			logger = logger.With(slog.String("trace_id", spanContext.TraceID().String()))
+		}
+		c.SetUserContext(logging.NewContext(c.UserContext(), logger))
+
+		if err := nextRecovered(c); err != nil {
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("ip", clientIP(c, server.config.Proxy)),
+		}
+		if userID, ok := getUserID(c); ok {
+			attrs = append(attrs, slog.String("user_id", userID.String()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logger.LogAttrs(c.UserContext(), level, "request", attrs...)
+		return nil
+	}
+}
+
+// nextRecovered calls the next handler and turns a panic into an error, logging its stack trace.
+func nextRecovered(c *fiber.Ctx) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.FromContext(c.UserContext()).Error("panic recovered", slog.String("stack", string(debug.Stack())))
+
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	return c.Next()
+}
+
+// metricsMiddleware counts the request and its latency by route. It must be registered before
+// accessLogMiddleware, which passes errors to the error handler, so that the final status is counted.
+//
+// The route is the template of the matched route, e.g. /api/v1/products/:id. Requests rejected by a middleware
+// before reaching their route are counted under the path the middleware is registered on, e.g. /api/v1
+// for failed authentication and / for unknown routes.
+func metricsMiddleware(server *Server) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		// The method is kept by the collectors, so it must not refer to the request buffer
+		method := utils.CopyString(c.Method())
+		server.metrics.ObserveRequest(method, c.Route().Path, c.Response().StatusCode(), time.Since(start))
+		return err
+	}
+}
+
+// metricsAuthMiddleware allows scraping the metrics only with the configured metrics token as bearer token.
+// The metrics are public if no token is configured.
+func metricsAuthMiddleware(server *Server) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		metricsToken := server.config.Metrics.Token
+		if len(metricsToken) == 0 {
+			return c.Next()
+		}
+
+		bearerToken, err := getBearerToken(c)
+		if err != nil {
+			return err
+		}
+		if subtle.ConstantTimeCompare([]byte(bearerToken), []byte(metricsToken)) != 1 {
+			return errInvalidMetricsToken
+		}
+
+		return c.Next()
+	}
+}
+
+// authMiddleware authenticates the request with the session token cookie.
+// When the session token is missing or has expired, the refresh token cookie is exchanged for new tokens.
+//
+// A session token or an API key may be sent as `Authorization: Bearer <token>` instead.
+// Bearer session tokens are not refreshed. API keys only grant access to routes guarded by requireScope.
 func authMiddleware(server *Server) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		bearerToken, err := getBearerToken(c)
+		if err != nil {
+			return err
+		}
+		if len(bearerToken) > 0 {
+			return authenticateBearerToken(c, server, bearerToken)
+		}
+
 		sessionToken := c.Cookies(cookieSessionTokenKey)
-		if len(sessionToken) == 0 {
-			err := fmt.Errorf("session token not found")
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		if len(sessionToken) > 0 {
+			session, err := authenticateSessionToken(c, server, sessionToken)
+			if err == nil {
+				c.Locals(ctxLocalSessionKey, session)
+				return c.Next()
+			}
+			if err == token.ErrInvalidToken {
+				return errInvalidToken
+			}
+			if err != token.ErrExpiredToken {
+				return err
+			}
+		}
+
+		refreshToken := c.Cookies(cookieRefreshTokenKey)
+		if len(refreshToken) == 0 {
+			if len(sessionToken) > 0 {
+				return errExpiredToken
+			}
+			return errSessionTokenNotFound
+		}
+
+		refreshTokenHash, err := token.ParseToken(refreshToken)
+		if err != nil {
+			return errInvalidToken
 		}
 
-		parsedSessionToken, err := uuid.Parse(sessionToken)
+		newSession, err := refreshSessionToken(c, server, refreshTokenHash)
 		if err != nil {
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+			if err == sql.ErrNoRows {
+				return errExpiredToken
+			}
+			if err == db.ErrRefreshTokenReused {
+				logging.FromContext(c.UserContext()).Warn("refresh token reused, session revoked")
+				clearSessionCookies(c)
+				return errRefreshTokenReused
+			}
+			return err
 		}
 
-		session, err := server.store.GetSession(c.Context(), parsedSessionToken)
+		c.Locals(ctxLocalSessionKey, newSession)
+		return c.Next()
+	}
+}
+
+// getBearerToken returns the token of the Authorization header, or an empty string if there is no header.
+func getBearerToken(c *fiber.Ctx) (string, error) {
+	authorizationHeader := c.Get(fiber.HeaderAuthorization)
+	if len(authorizationHeader) == 0 {
+		return "", nil
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) != 2 || strings.ToLower(fields[0]) != authorizationTypeBearer {
+		return "", errInvalidAuthorizationHeader
+	}
+
+	return fields[1], nil
+}
+
+// authenticateBearerToken authenticates the request with an API key or a session token sent as a bearer token.
+func authenticateBearerToken(c *fiber.Ctx, server *Server, bearerToken string) error {
+	if keyHash, err := token.ParseAPIKey(bearerToken); err == nil {
+		apiKey, err := server.store.GetApiKeyByHash(c.UserContext(), keyHash)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+				return errInvalidToken
 			}
-			return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+			return err
 		}
 
-		if token.IsExpired(session.SessionTokenExpiredAt) {
-			if token.IsExpired(session.RefreshTokenExpiredAt) {
-				return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(token.ErrExpiredToken))
+		if !apiKey.LastUsedAt.Valid || time.Since(apiKey.LastUsedAt.Time) >= sessionTouchInterval {
+			err = server.store.TouchApiKey(c.UserContext(), apiKey.ID)
+			if err != nil {
+				return err
 			}
+		}
+
+		c.Locals(ctxLocalAPIKeyKey, apiKey)
+		return c.Next()
+	}
+
+	session, err := authenticateSessionToken(c, server, bearerToken)
+	if err != nil {
+		switch err {
+		case token.ErrInvalidToken:
+			return errInvalidToken
+		case token.ErrExpiredToken:
+			return errExpiredToken
+		}
+		return err
+	}
+
+	c.Locals(ctxLocalSessionKey, session)
+	return c.Next()
+}
+
+// authenticateSessionToken returns the session the session token belongs to.
+// token.ErrExpiredToken is returned if the session has to be refreshed,
+// and token.ErrInvalidToken if the token is malformed or forged.
+//
+// In token auth mode the token is a signed access token, which is verified without the database.
+// Otherwise the session is looked up by the hash of the token, since only hashes are stored.
+func authenticateSessionToken(c *fiber.Ctx, server *Server, sessionToken string) (db.Session, error) {
+	if server.accessTokenMaker != nil {
+		payload, err := server.accessTokenMaker.VerifyToken(sessionToken)
+		if err != nil {
+			return db.Session{}, err
+		}
+
+		c.Locals(ctxLocalRoleKey, db.UserRole(payload.Role))
+
+		return db.Session{
+			ID:                    payload.SessionID,
+			UserID:                payload.UserID,
+			SessionTokenExpiredAt: payload.ExpiredAt,
+		}, nil
+	}
+
+	sessionTokenHash, err := token.ParseToken(sessionToken)
+	if err != nil {
+		return db.Session{}, err
+	}
+
+	session, err := server.store.GetSession(c.UserContext(), sessionTokenHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return db.Session{}, token.ErrExpiredToken
+		}
+		return db.Session{}, err
+	}
+
+	if token.IsExpired(session.SessionTokenExpiredAt) {
+		return db.Session{}, token.ErrExpiredToken
+	}
 
-			newSession, err := refreshSessionToken(c, server, session)
+	if time.Since(session.LastUsedAt) >= sessionTouchInterval {
+		err = server.store.TouchSession(c.UserContext(), session.ID)
+		if err != nil {
+			return db.Session{}, err
+		}
+	}
+
+	return session, nil
+}
+
+// requireScope allows requests authenticated with an API key only if the key has one of the given scopes,
+// and makes the owner of the key available through getSession. Other requests are passed through,
+// since session authentication is not limited by scopes. It must be registered after authMiddleware.
+func requireScope(scopes ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiKey, ok := c.Locals(ctxLocalAPIKeyKey).(db.ApiKey)
+		if !ok {
+			return c.Next()
+		}
+
+		for _, scope := range scopes {
+			for _, keyScope := range apiKey.Scopes {
+				if keyScope == scope {
+					c.Locals(ctxLocalSessionKey, db.Session{UserID: apiKey.UserID})
+					return c.Next()
+				}
+			}
+		}
+
+		return errInsufficientScope
+	}
+}
+
+// requireRole allows the request only if the session user has one of the given roles.
+// It must be registered after authMiddleware.
+func requireRole(server *Server, roles ...db.UserRole) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		session, err := getSession(c)
+		if err != nil {
+			return err
+		}
+
+		// Signed access tokens carry the role, so the user is only looked up in session auth mode
+		userRole, ok := c.Locals(ctxLocalRoleKey).(db.UserRole)
+		if !ok {
+			user, err := server.store.GetUser(c.UserContext(), session.UserID)
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+				if err == sql.ErrNoRows {
+					return errSessionTokenNotFound
+				}
+				return err
 			}
+			userRole = user.Role
+		}
 
-			c.Locals(ctxLocalSessionKey, newSession)
-		} else {
-			c.Locals(ctxLocalSessionKey, session)
+		for _, role := range roles {
+			if userRole == role {
+				return c.Next()
+			}
+		}
+
+		return errRoleNotAllowed
+	}
+}
+
+// rateLimitMiddleware counts the request against the budget with the given name and rejects it once the budget
+// is used up. Requests are counted per user if authMiddleware has already authenticated them, and per client IP
+// otherwise. The state of the budget is reported in the X-RateLimit-* headers, which are overwritten by budgets
+// applied later, so that the most specific budget is reported.
+func rateLimitMiddleware(server *Server, name string, limit ratelimit.Limit) fiber.Handler {
+	if !limit.Enabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		result, err := server.rateLimitStore.Take(c.UserContext(), rateLimitKey(c, name, server.config.Proxy), limit, time.Now())
+		if err != nil {
+			return err
+		}
+
+		c.Set(headerRateLimitLimit, strconv.Itoa(result.Limit))
+		c.Set(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+		c.Set(headerRateLimitReset, formatSeconds(result.ResetAfter))
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, formatSeconds(result.RetryAfter))
+			return errRateLimitExceeded
 		}
 
 		return c.Next()
 	}
 }
+
+// writeRateLimitMiddleware is like rateLimitMiddleware, but only counts requests that may change data.
+func writeRateLimitMiddleware(server *Server, limit ratelimit.Limit) fiber.Handler {
+	limitRequest := rateLimitMiddleware(server, "write", limit)
+
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+			return c.Next()
+		}
+		return limitRequest(c)
+	}
+}
+
+// rateLimitKey returns the key of the bucket the request is counted in.
+// Requests authenticated with a session or an API key are counted for the user, so that an API key
+// has one budget regardless of the IPs it is used from, even before requireScope has run.
+func rateLimitKey(c *fiber.Ctx, name string, proxy util.ProxyConfig) string {
+	if userID, ok := getUserID(c); ok {
+		return name + ":user:" + userID.String()
+	}
+	return name + ":ip:" + clientIP(c, proxy)
+}
+
+// clientIP returns the IP address of the client. Behind a trusted proxy, it is the last address
+// of the proxy header, which the proxy appended itself. The addresses before it are sent by the client,
+// so they are never used. Otherwise, or if the last address is not valid, it is the remote address.
+func clientIP(c *fiber.Ctx, proxy util.ProxyConfig) string {
+	if proxy.Header == "" || !c.IsProxyTrusted() {
+		return c.IP()
+	}
+
+	addresses := c.Get(proxy.Header)
+	last := strings.TrimSpace(addresses[strings.LastIndexByte(addresses, ',')+1:])
+	if net.ParseIP(last) == nil {
+		return c.IP()
+	}
+	return utils.CopyString(last)
+}
+
+// formatSeconds formats the duration as whole seconds, rounded up, as used by the Retry-After header.
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -1,19 +1,39 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 
+	user_domain "github.com/ot07/next-bazaar/api/domain/user"
 	"github.com/ot07/next-bazaar/api/test_util"
+	mockdb "github.com/ot07/next-bazaar/db/mock"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+	"github.com/ot07/next-bazaar/ratelimit"
 	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/tracing"
+	"github.com/ot07/next-bazaar/util"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
 	gomock "go.uber.org/mock/gomock"
+	"golang.org/x/exp/slog"
 )
 
 func TestAuthMiddleware(t *testing.T) {
@@ -57,19 +77,22 @@ func TestAuthMiddleware(t *testing.T) {
 		})
 	}
 
+	validAPIKey, _ := token.NewAPIKey()
+
 	testCases := []struct {
 		name           string
 		buildStore     func(t *testing.T) (store db.Store, cleanup func())
 		createSeedData func(t *testing.T, store db.Store)
-		setupAuth      func(request *http.Request)
+		authMode       string
+		setupAuth      func(t *testing.T, request *http.Request, server *Server)
 		checkResponse  func(t *testing.T, response *http.Response)
 	}{
 		{
 			name:           "OK",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: createValidSessionSeed,
-			setupAuth: func(request *http.Request) {
-				test_util.AddSessionTokenInCookie(request, validSessionToken.ID.String())
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
@@ -79,7 +102,7 @@ func TestAuthMiddleware(t *testing.T) {
 			name:           "NoAuthorization",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: createValidSessionSeed,
-			setupAuth:      func(request *http.Request) {},
+			setupAuth:      func(t *testing.T, request *http.Request, server *Server) {},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
 			},
@@ -88,19 +111,47 @@ func TestAuthMiddleware(t *testing.T) {
 			name:           "InvalidSessionTokenFormat",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: createValidSessionSeed,
-			setupAuth: func(request *http.Request) {
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
 				test_util.AddSessionTokenInCookie(request, "invalid")
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
 			},
 		},
+		{
+			name: "LegacyPlainSessionToken",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				// Plain UUID tokens issued before hashing are rejected without a lookup
+				mockStore, cleanup := test_util.NewMockStore(t)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddSessionTokenInCookie(request, util.RandomUUID().String())
+				test_util.AddRefreshTokenInCookie(request, util.RandomUUID().String())
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
 		{
 			name:           "ExpiredSessionToken",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: createExpiredSessionSeed,
-			setupAuth: func(request *http.Request) {
-				test_util.AddSessionTokenInCookie(request, expiredSessionToken.ID.String())
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, expiredSessionToken)
+				test_util.AddRefreshTokenInCookie(request, expiredRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "ExpiredSessionTokenWithoutRefreshToken",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: createShouldRefreshSessionSeed,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, expiredSessionToken)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
@@ -110,65 +161,1532 @@ func TestAuthMiddleware(t *testing.T) {
 			name:           "RefreshSessionToken",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: createShouldRefreshSessionSeed,
-			setupAuth: func(request *http.Request) {
-				test_util.AddSessionTokenInCookie(request, expiredSessionToken.ID.String())
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, expiredSessionToken)
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
+				requireRotatedSessionCookies(t, response, validRefreshToken.Value)
 			},
 		},
 		{
-			name: "InternalError",
+			name:           "RefreshWithoutSessionToken",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: createShouldRefreshSessionSeed,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+				requireRotatedSessionCookies(t, response, validRefreshToken.Value)
+			},
+		},
+		{
+			name:           "InvalidRefreshTokenFormat",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: createShouldRefreshSessionSeed,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddRefreshTokenInCookie(request, "invalid")
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "ReusedRefreshToken",
 			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
 				mockStore, cleanup := test_util.NewMockStore(t)
 
 				mockStore.EXPECT().
-					GetSession(gomock.Any(), gomock.Any()).
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Return(db.Session{}, db.ErrRefreshTokenReused)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+				cookies := response.Cookies()
+				require.Len(t, cookies, 2)
+				for _, cookie := range cookies {
+					require.Empty(t, cookie.Value)
+				}
+			},
+		},
+		{
+			name: "RefreshInternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
 					Return(db.Session{}, sql.ErrConnDone)
 
 				return mockStore, cleanup
 			},
 			createSeedData: func(t *testing.T, store db.Store) {},
-			setupAuth: func(request *http.Request) {
-				test_util.AddSessionTokenInCookie(request, validSessionToken.ID.String())
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
 			},
 		},
-	}
+		{
+			name: "TouchStaleSession",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-	for i := range testCases {
-		tc := testCases[i]
+				session := db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      validSessionToken.Hash(),
+					SessionTokenExpiredAt: validSessionToken.ExpiredAt,
+					LastUsedAt:            time.Now().Add(-sessionTouchInterval),
+				}
 
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+				mockStore.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(validSessionToken.Hash())).
+					Return(session, nil)
 
-			store, cleanupStore := tc.buildStore(t)
-			defer cleanupStore()
+				mockStore.EXPECT().
+					TouchSession(gomock.Any(), gomock.Eq(session.ID)).
+					Times(1).
+					Return(nil)
 
-			tc.createSeedData(t, store)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeSession,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "SkipTouchRecentSession",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-			authPath := "/auth"
+				mockStore.EXPECT().
+					GetSession(gomock.Any(), gomock.Eq(validSessionToken.Hash())).
+					Return(db.Session{
+						ID:                    util.RandomUUID(),
+						UserID:                util.RandomUUID(),
+						SessionTokenHash:      validSessionToken.Hash(),
+						SessionTokenExpiredAt: validSessionToken.ExpiredAt,
+						LastUsedAt:            time.Now(),
+					}, nil)
 
-			request := test_util.NewRequest(t, test_util.RequestParams{
-				Method: http.MethodGet,
-				URL:    authPath,
-			})
+				mockStore.EXPECT().
+					TouchSession(gomock.Any(), gomock.Any()).
+					Times(0)
 
-			tc.setupAuth(request)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeSession,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "TouchSessionInternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-			server := newTestServer(t, store)
-			server.app.Get(
-				authPath,
-				authMiddleware(server),
-				func(c *fiber.Ctx) error {
-					return c.SendStatus(fiber.StatusOK)
-				},
-			)
+				mockStore.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Return(db.Session{
+						ID:                    util.RandomUUID(),
+						UserID:                util.RandomUUID(),
+						SessionTokenHash:      validSessionToken.Hash(),
+						SessionTokenExpiredAt: validSessionToken.ExpiredAt,
+					}, nil)
 
-			response := test_util.SendRequest(t, server.app, request)
-			tc.checkResponse(t, response)
-		})
-	}
+				mockStore.EXPECT().
+					TouchSession(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeSession,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "AccessTokenWithoutLookup",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				// A valid access token is verified without touching the store
+				mockStore, cleanup := test_util.NewMockStore(t)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeToken,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "ForgedAccessToken",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeToken,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				otherMaker, err := token.NewJWTMaker(util.RandomString(32))
+				require.NoError(t, err)
+
+				accessToken, _, err := otherMaker.CreateToken(token.PayloadParams{
+					SessionID: util.RandomUUID(),
+					UserID:    util.RandomUUID(),
+					Role:      string(db.UserRoleAdmin),
+				}, time.Minute)
+				require.NoError(t, err)
+
+				test_util.AddSessionTokenInCookie(request, accessToken.Value)
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "RefreshIssuesAccessToken",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				session := db.Session{
+					ID:     util.RandomUUID(),
+					UserID: util.RandomUUID(),
+				}
+
+				mockStore.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Return(session, nil)
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.UserID)).
+					Return(db.User{ID: session.UserID, Role: db.UserRoleCustomer}, nil)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeToken,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, expiredSessionToken)
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				requireRotatedSessionCookies(t, response, validRefreshToken.Value)
+				for _, cookie := range response.Cookies() {
+					if cookie.Name == cookieSessionTokenKey {
+						require.Len(t, strings.Split(cookie.Value, "."), 3)
+					}
+				}
+			},
+		},
+		{
+			name: "RefreshGetUserInternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Return(db.Session{ID: util.RandomUUID(), UserID: util.RandomUUID()}, nil)
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeToken,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "RefreshedConcurrently",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Return(db.Session{
+						ID:                    util.RandomUUID(),
+						UserID:                util.RandomUUID(),
+						SessionTokenHash:      expiredSessionToken.Hash(),
+						SessionTokenExpiredAt: expiredSessionToken.ExpiredAt,
+						RefreshTokenHash:      validRefreshToken.Hash(),
+						RefreshTokenExpiredAt: validRefreshToken.ExpiredAt,
+					}, nil)
+
+				mockStore.EXPECT().
+					RotateSessionTx(gomock.Any(), gomock.Any()).
+					Return(db.Session{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeSession,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, expiredSessionToken)
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "BearerSessionToken",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: createValidSessionSeed,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addBearerSessionToken(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:           "ExpiredBearerSessionToken",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: createShouldRefreshSessionSeed,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				// Bearer tokens are not refreshed, even if a valid refresh token is sent
+				addBearerSessionToken(t, request, server, expiredSessionToken)
+				test_util.AddRefreshTokenInCookie(request, validRefreshToken.Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Empty(t, response.Cookies())
+			},
+		},
+		{
+			name: "InvalidAuthorizationHeader",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				request.Header.Set("Authorization", "Basic "+validSessionToken.Value)
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "BearerAPIKey",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				apiKey := db.ApiKey{
+					ID:     util.RandomUUID(),
+					UserID: util.RandomUUID(),
+					Scopes: []string{"catalog:read"},
+				}
+
+				mockStore.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Eq(token.HashToken(validAPIKey))).
+					Return(apiKey, nil)
+
+				mockStore.EXPECT().
+					TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddBearerTokenInHeader(request, validAPIKey)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "SkipTouchRecentAPIKey",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{
+						ID:         util.RandomUUID(),
+						UserID:     util.RandomUUID(),
+						LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+					}, nil)
+
+				mockStore.EXPECT().
+					TouchApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddBearerTokenInHeader(request, validAPIKey)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "UnknownAPIKey",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddBearerTokenInHeader(request, validAPIKey)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "APIKeyInternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetApiKeyByHash(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				test_util.AddBearerTokenInHeader(request, validAPIKey)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetSession(gomock.Any(), gomock.Any()).
+					Return(db.Session{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) {},
+			authMode:       util.AuthModeSession,
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				addSessionTokenInCookie(t, request, server, validSessionToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for _, authMode := range []string{util.AuthModeSession, util.AuthModeToken} {
+		authMode := authMode
+
+		t.Run(authMode, func(t *testing.T) {
+			for i := range testCases {
+				tc := testCases[i]
+
+				// Cases without an auth mode are shared by both modes
+				if tc.authMode != "" && tc.authMode != authMode {
+					continue
+				}
+
+				t.Run(tc.name, func(t *testing.T) {
+					t.Parallel()
+
+					store, cleanupStore := tc.buildStore(t)
+					defer cleanupStore()
+
+					tc.createSeedData(t, store)
+
+					authPath := "/auth"
+
+					server := newTestServerWithAuthMode(t, store, authMode)
+					server.app.Get(
+						authPath,
+						authMiddleware(server),
+						func(c *fiber.Ctx) error {
+							return c.SendStatus(fiber.StatusOK)
+						},
+					)
+
+					request := test_util.NewRequest(t, test_util.RequestParams{
+						Method: http.MethodGet,
+						URL:    authPath,
+					})
+
+					if tc.setupAuth != nil {
+						tc.setupAuth(t, request, server)
+					}
+
+					response := test_util.SendRequest(t, server.app, request)
+					tc.checkResponse(t, response)
+				})
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareRefreshTokenReuse(t *testing.T) {
+	sessionToken := token.NewToken(-time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	store, cleanupStore := test_util.BuildTestDBStore(t)
+	defer cleanupStore()
+
+	_ = test_util.CreateWithSessionUser(t, context.Background(), store, test_util.WithSessionUserParams{
+		Name:         "testuser",
+		Email:        "test@example.com",
+		Password:     "test-password",
+		SessionToken: sessionToken,
+		RefreshToken: refreshToken,
+	})
+
+	authPath := "/auth"
+
+	server := newTestServer(t, store)
+	server.app.Get(
+		authPath,
+		authMiddleware(server),
+		func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		},
+	)
+
+	sendWithRefreshToken := func(refreshToken string) *http.Response {
+		request := test_util.NewRequest(t, test_util.RequestParams{
+			Method: http.MethodGet,
+			URL:    authPath,
+		})
+		test_util.AddRefreshTokenInCookie(request, refreshToken)
+
+		return test_util.SendRequest(t, server.app, request)
+	}
+
+	response := sendWithRefreshToken(refreshToken.Value)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	rotatedRefreshToken := requireRotatedSessionCookies(t, response, refreshToken.Value)
+
+	// The old refresh token is presented again, e.g. by an attacker who stole it
+	response = sendWithRefreshToken(refreshToken.Value)
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	// The whole family is revoked, so the legitimate user is logged out as well
+	response = sendWithRefreshToken(rotatedRefreshToken)
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+// addSessionTokenInCookie sets the session token in the format of the server's auth mode.
+func addSessionTokenInCookie(t *testing.T, request *http.Request, server *Server, sessionToken *token.Token) {
+	test_util.AddSessionTokenInCookie(request, sessionTokenValue(t, server, sessionToken))
+}
+
+// addBearerSessionToken is like addSessionTokenInCookie, but sends the token in the Authorization header.
+func addBearerSessionToken(t *testing.T, request *http.Request, server *Server, sessionToken *token.Token) {
+	test_util.AddBearerTokenInHeader(request, sessionTokenValue(t, server, sessionToken))
+}
+
+// sessionTokenValue returns the value of the session token in the format of the server's auth mode.
+// In token auth mode a signed access token that expires with the given token is used instead.
+func sessionTokenValue(t *testing.T, server *Server, sessionToken *token.Token) string {
+	if server.accessTokenMaker == nil {
+		return sessionToken.Value
+	}
+
+	accessToken, _, err := server.accessTokenMaker.CreateToken(token.PayloadParams{
+		SessionID: util.RandomUUID(),
+		UserID:    util.RandomUUID(),
+		Role:      string(db.UserRoleCustomer),
+	}, time.Until(sessionToken.ExpiredAt))
+	require.NoError(t, err)
+
+	return accessToken.Value
+}
+
+// requireRotatedSessionCookies checks that new session and refresh tokens were issued
+// and returns the new refresh token.
+func requireRotatedSessionCookies(t *testing.T, response *http.Response, oldRefreshToken string) string {
+	var sessionCookie, refreshCookie *http.Cookie
+	for _, cookie := range response.Cookies() {
+		switch cookie.Name {
+		case cookieSessionTokenKey:
+			sessionCookie = cookie
+		case cookieRefreshTokenKey:
+			refreshCookie = cookie
+		}
+	}
+
+	require.NotNil(t, sessionCookie)
+	require.NotEmpty(t, sessionCookie.Value)
+	require.True(t, sessionCookie.HttpOnly)
+
+	require.NotNil(t, refreshCookie)
+	require.NotEmpty(t, refreshCookie.Value)
+	require.NotEqual(t, oldRefreshToken, refreshCookie.Value)
+	require.True(t, refreshCookie.HttpOnly)
+
+	return refreshCookie.Value
+}
+
+func TestRequireRole(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	newSession := func() db.Session {
+		return db.Session{
+			ID:                    util.RandomUUID(),
+			UserID:                util.RandomUUID(),
+			SessionTokenHash:      sessionToken.Hash(),
+			SessionTokenExpiredAt: sessionToken.ExpiredAt,
+			CreatedAt:             time.Now(),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		roles         []db.UserRole
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidRoleStubs(mockStore, newSession(), db.UserRoleAdmin)
+				return mockStore, cleanup
+			},
+			roles: []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "OneOfRoles",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidRoleStubs(mockStore, newSession(), db.UserRoleSeller)
+				return mockStore, cleanup
+			},
+			roles: []db.UserRole{db.UserRoleSeller, db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "Forbidden",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidRoleStubs(mockStore, newSession(), db.UserRoleCustomer)
+				return mockStore, cleanup
+			},
+			roles: []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "UserNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, newSession())
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			roles: []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, newSession())
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			roles: []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			rolePath := "/role"
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    rolePath,
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			server.app.Get(
+				rolePath,
+				authMiddleware(server),
+				requireRole(server, tc.roles...),
+				func(c *fiber.Ctx) error {
+					return c.SendStatus(fiber.StatusOK)
+				},
+			)
+
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestRequireRoleWithAccessToken(t *testing.T) {
+	testCases := []struct {
+		name          string
+		userRole      db.UserRole
+		roles         []db.UserRole
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:     "OK",
+			userRole: db.UserRoleAdmin,
+			roles:    []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:     "Forbidden",
+			userRole: db.UserRoleCustomer,
+			roles:    []db.UserRole{db.UserRoleAdmin},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The role is read from the access token, so the store must not be called
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			rolePath := "/role"
+
+			server := newTestServerWithAuthMode(t, store, util.AuthModeToken)
+			server.app.Get(
+				rolePath,
+				authMiddleware(server),
+				requireRole(server, tc.roles...),
+				func(c *fiber.Ctx) error {
+					return c.SendStatus(fiber.StatusOK)
+				},
+			)
+
+			accessToken, _, err := server.accessTokenMaker.CreateToken(token.PayloadParams{
+				SessionID: util.RandomUUID(),
+				UserID:    util.RandomUUID(),
+				Role:      string(tc.userRole),
+			}, time.Minute)
+			require.NoError(t, err)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    rolePath,
+			})
+			test_util.AddSessionTokenInCookie(request, accessToken.Value)
+
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	apiKey, _ := token.NewAPIKey()
+
+	newAPIKey := func(scopes ...string) db.ApiKey {
+		return db.ApiKey{
+			ID:         util.RandomUUID(),
+			UserID:     util.RandomUUID(),
+			Scopes:     scopes,
+			LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		setupAuth     func(request *http.Request)
+		path          string
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidAPIKeyStubs(mockStore, newAPIKey(user_domain.APIKeyScopeCatalogRead))
+				return mockStore, cleanup
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, apiKey)
+			},
+			path: "/scope",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "OneOfScopes",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidAPIKeyStubs(mockStore, newAPIKey(user_domain.APIKeyScopeProductsManage))
+				return mockStore, cleanup
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, apiKey)
+			},
+			path: "/scope",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "MissingScope",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidAPIKeyStubs(mockStore, newAPIKey(user_domain.APIKeyScopeCartManage))
+				return mockStore, cleanup
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, apiKey)
+			},
+			path: "/scope",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "SessionToken",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+				return mockStore, cleanup
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+			},
+			path: "/scope",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "RouteWithoutScope",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+				test_util.BuildValidAPIKeyStubs(mockStore, newAPIKey(
+					user_domain.APIKeyScopeCatalogRead,
+					user_domain.APIKeyScopeProductsManage,
+					user_domain.APIKeyScopeCartManage,
+				))
+				return mockStore, cleanup
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, apiKey)
+			},
+			path: "/session",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			// The handlers read the session, as the handlers of the guarded routes do
+			handler := func(c *fiber.Ctx) error {
+				if _, err := getSession(c); err != nil {
+					return err
+				}
+				return c.SendStatus(fiber.StatusOK)
+			}
+
+			server := newTestServer(t, store)
+			server.app.Get(
+				"/scope",
+				authMiddleware(server),
+				requireScope(user_domain.APIKeyScopeCatalogRead, user_domain.APIKeyScopeProductsManage),
+				handler,
+			)
+			server.app.Get("/session", authMiddleware(server), handler)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    tc.path,
+			})
+
+			tc.setupAuth(request)
+
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("rate limit store is unavailable")
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	// setUser authenticates the request as the user given in the header, like authMiddleware does
+	setUser := func(c *fiber.Ctx) error {
+		if userID := c.Get("X-Test-User"); len(userID) > 0 {
+			c.Locals(ctxLocalSessionKey, db.Session{UserID: uuid.MustParse(userID)})
+		}
+		return c.Next()
+	}
+
+	userID1 := util.RandomUUID().String()
+	userID2 := util.RandomUUID().String()
+
+	testCases := []struct {
+		name           string
+		rateLimitStore ratelimit.Store
+		limit          ratelimit.Limit
+		requests       []string
+		checkResponses func(t *testing.T, responses []*http.Response)
+	}{
+		{
+			name:           "OK",
+			rateLimitStore: ratelimit.NewMemoryStore(),
+			limit:          limit,
+			requests:       []string{""},
+			checkResponses: func(t *testing.T, responses []*http.Response) {
+				require.Equal(t, http.StatusOK, responses[0].StatusCode)
+				require.Equal(t, "2", responses[0].Header.Get(headerRateLimitLimit))
+				require.Equal(t, "1", responses[0].Header.Get(headerRateLimitRemaining))
+				require.Equal(t, "30", responses[0].Header.Get(headerRateLimitReset))
+				require.Empty(t, responses[0].Header.Get("Retry-After"))
+			},
+		},
+		{
+			name:           "LimitExceeded",
+			rateLimitStore: ratelimit.NewMemoryStore(),
+			limit:          limit,
+			requests:       []string{"", "", ""},
+			checkResponses: func(t *testing.T, responses []*http.Response) {
+				require.Equal(t, http.StatusOK, responses[0].StatusCode)
+				require.Equal(t, http.StatusOK, responses[1].StatusCode)
+				require.Equal(t, "0", responses[1].Header.Get(headerRateLimitRemaining))
+
+				require.Equal(t, http.StatusTooManyRequests, responses[2].StatusCode)
+				require.Equal(t, "2", responses[2].Header.Get(headerRateLimitLimit))
+				require.Equal(t, "0", responses[2].Header.Get(headerRateLimitRemaining))
+				require.Equal(t, "60", responses[2].Header.Get(headerRateLimitReset))
+				require.Equal(t, "30", responses[2].Header.Get("Retry-After"))
+			},
+		},
+		{
+			name:           "PerUser",
+			rateLimitStore: ratelimit.NewMemoryStore(),
+			limit:          limit,
+			requests:       []string{userID1, userID1, userID1, userID2, ""},
+			checkResponses: func(t *testing.T, responses []*http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, responses[2].StatusCode)
+
+				// Other users and unauthenticated clients from the same IP have their own budgets
+				require.Equal(t, http.StatusOK, responses[3].StatusCode)
+				require.Equal(t, "1", responses[3].Header.Get(headerRateLimitRemaining))
+				require.Equal(t, http.StatusOK, responses[4].StatusCode)
+				require.Equal(t, "1", responses[4].Header.Get(headerRateLimitRemaining))
+			},
+		},
+		{
+			name:           "Disabled",
+			rateLimitStore: failingRateLimitStore{},
+			limit:          ratelimit.Limit{},
+			requests:       []string{"", "", ""},
+			checkResponses: func(t *testing.T, responses []*http.Response) {
+				for _, response := range responses {
+					require.Equal(t, http.StatusOK, response.StatusCode)
+					require.Empty(t, response.Header.Get(headerRateLimitLimit))
+				}
+			},
+		},
+		{
+			name:           "InternalError",
+			rateLimitStore: failingRateLimitStore{},
+			limit:          limit,
+			requests:       []string{""},
+			checkResponses: func(t *testing.T, responses []*http.Response) {
+				require.Equal(t, http.StatusInternalServerError, responses[0].StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			limitPath := "/limit"
+
+			server, err := NewServerWithRateLimitStore(util.Config{}, store, tc.rateLimitStore)
+			require.NoError(t, err)
+			server.app.Get(
+				limitPath,
+				setUser,
+				rateLimitMiddleware(server, "test", tc.limit),
+				func(c *fiber.Ctx) error {
+					return c.SendStatus(fiber.StatusOK)
+				},
+			)
+
+			responses := make([]*http.Response, len(tc.requests))
+			for i, userID := range tc.requests {
+				request := test_util.NewRequest(t, test_util.RequestParams{
+					Method: http.MethodGet,
+					URL:    limitPath,
+				})
+				if len(userID) > 0 {
+					request.Header.Set("X-Test-User", userID)
+				}
+
+				responses[i] = test_util.SendRequest(t, server.app, request)
+			}
+
+			tc.checkResponses(t, responses)
+		})
+	}
+}
+
+func TestWriteRateLimitMiddleware(t *testing.T) {
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	limitPath := "/limit"
+
+	server := newTestServer(t, store)
+	handler := func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	}
+	limitWrites := writeRateLimitMiddleware(server, ratelimit.Limit{Requests: 1, Period: time.Minute})
+	server.app.Get(limitPath, limitWrites, handler)
+	server.app.Post(limitPath, limitWrites, handler)
+
+	send := func(method string) *http.Response {
+		request := test_util.NewRequest(t, test_util.RequestParams{
+			Method: method,
+			URL:    limitPath,
+		})
+		return test_util.SendRequest(t, server.app, request)
+	}
+
+	// Reads are not counted
+	for i := 0; i < 3; i++ {
+		response := send(http.MethodGet)
+		require.Equal(t, http.StatusOK, response.StatusCode)
+		require.Empty(t, response.Header.Get(headerRateLimitLimit))
+	}
+
+	response := send(http.MethodPost)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	require.Equal(t, "0", response.Header.Get(headerRateLimitRemaining))
+
+	response = send(http.MethodPost)
+	require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+}
+
+// recordingRateLimitStore allows every request and records the keys of the buckets they are counted in.
+type recordingRateLimitStore struct {
+	mu   sync.Mutex
+	keys []string
+}
+
+func (store *recordingRateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	store.keys = append(store.keys, key)
+	return ratelimit.Result{Allowed: true, Limit: limit.Requests, Remaining: limit.Requests}, nil
+}
+
+func TestWriteRateLimitMiddlewareAPIKey(t *testing.T) {
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	userID := util.RandomUUID()
+	apiKey, _ := token.NewAPIKey()
+
+	test_util.BuildValidAPIKeyStubs(store, db.ApiKey{
+		ID:         util.RandomUUID(),
+		UserID:     userID,
+		Scopes:     []string{user_domain.APIKeyScopeProductsManage},
+		LastUsedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	})
+
+	rateLimitStore := &recordingRateLimitStore{}
+
+	server := newTestServer(t, store)
+	server.rateLimitStore = rateLimitStore
+
+	// As in the router, the limit is taken before requireScope makes the key owner the session user
+	server.app.Post(
+		"/write",
+		authMiddleware(server),
+		writeRateLimitMiddleware(server, ratelimit.Limit{Requests: 10, Period: time.Minute}),
+		requireScope(user_domain.APIKeyScopeProductsManage),
+		func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		},
+	)
+
+	request := test_util.NewRequest(t, test_util.RequestParams{
+		Method: http.MethodPost,
+		URL:    "/write",
+	})
+	test_util.AddBearerTokenInHeader(request, apiKey)
+
+	response := test_util.SendRequest(t, server.app, request)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	// API key requests are counted for the key owner rather than the client IP
+	require.Contains(t, rateLimitStore.keys, "write:user:"+userID.String())
+	for _, key := range rateLimitStore.keys {
+		require.NotContains(t, key, "write:ip:")
+	}
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		requestID     string
+		checkResponse func(t *testing.T, requestID string)
+	}{
+		{
+			name:      "Incoming",
+			requestID: "client-request-id",
+			checkResponse: func(t *testing.T, requestID string) {
+				require.Equal(t, "client-request-id", requestID)
+			},
+		},
+		{
+			name:      "Missing",
+			requestID: "",
+			checkResponse: func(t *testing.T, requestID string) {
+				_, err := uuid.Parse(requestID)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "TooLong",
+			requestID: strings.Repeat("a", maxRequestIDLength+1),
+			checkResponse: func(t *testing.T, requestID string) {
+				_, err := uuid.Parse(requestID)
+				require.NoError(t, err)
+			},
+		},
+		{
+			name:      "InvalidCharacters",
+			requestID: "client request id",
+			checkResponse: func(t *testing.T, requestID string) {
+				_, err := uuid.Parse(requestID)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			server := newTestServer(t, store)
+
+			var handlerRequestID string
+			server.app.Get("/request-id", func(c *fiber.Ctx) error {
+				handlerRequestID = getRequestID(c)
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/request-id",
+			})
+			if len(tc.requestID) > 0 {
+				request.Header.Set(fiber.HeaderXRequestID, tc.requestID)
+			}
+
+			response := test_util.SendRequest(t, server.app, request)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+
+			requestID := response.Header.Get(fiber.HeaderXRequestID)
+			require.Equal(t, handlerRequestID, requestID)
+			tc.checkResponse(t, requestID)
+		})
+	}
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	userID := util.RandomUUID()
+
+	testCases := []struct {
+		name      string
+		path      string
+		setupAuth func(t *testing.T, request *http.Request, server *Server)
+		checkLogs func(t *testing.T, records []map[string]any)
+	}{
+		{
+			name: "OK",
+			path: "/logged",
+			checkLogs: func(t *testing.T, records []map[string]any) {
+				require.Len(t, records, 2)
+				require.Equal(t, "handled", records[0]["msg"])
+
+				record := records[1]
+				require.Equal(t, "INFO", record["level"])
+				require.Equal(t, "request", record["msg"])
+				require.Equal(t, http.MethodGet, record["method"])
+				require.Equal(t, "/logged", record["path"])
+				require.Equal(t, float64(http.StatusOK), record["status"])
+				require.Contains(t, record, "latency")
+				require.NotContains(t, record, "user_id")
+			},
+		},
+		{
+			name: "Authenticated",
+			path: "/logged",
+			setupAuth: func(t *testing.T, request *http.Request, server *Server) {
+				accessToken, _, err := server.accessTokenMaker.CreateToken(token.PayloadParams{
+					SessionID: util.RandomUUID(),
+					UserID:    userID,
+					Role:      string(db.UserRoleCustomer),
+				}, time.Minute)
+				require.NoError(t, err)
+
+				test_util.AddSessionTokenInCookie(request, accessToken.Value)
+			},
+			checkLogs: func(t *testing.T, records []map[string]any) {
+				require.Len(t, records, 2)
+				require.Equal(t, userID.String(), records[1]["user_id"])
+			},
+		},
+		{
+			name: "ClientError",
+			path: "/unknown",
+			checkLogs: func(t *testing.T, records []map[string]any) {
+				require.Len(t, records, 1)
+				require.Equal(t, "WARN", records[0]["level"])
+				require.Equal(t, float64(http.StatusNotFound), records[0]["status"])
+			},
+		},
+		{
+			name: "InternalError",
+			path: "/error",
+			checkLogs: func(t *testing.T, records []map[string]any) {
+				require.Len(t, records, 2)
+
+				require.Equal(t, "ERROR", records[0]["level"])
+				require.Equal(t, "request failed", records[0]["msg"])
+				require.Equal(t, "unexpected error", records[0]["error"])
+
+				require.Equal(t, "ERROR", records[1]["level"])
+				require.Equal(t, float64(http.StatusInternalServerError), records[1]["status"])
+			},
+		},
+		{
+			name: "Panic",
+			path: "/panic",
+			checkLogs: func(t *testing.T, records []map[string]any) {
+				require.Len(t, records, 3)
+
+				require.Equal(t, "panic recovered", records[0]["msg"])
+				require.Contains(t, records[0]["stack"], "panic")
+
+				require.Equal(t, "request failed", records[1]["msg"])
+				require.Equal(t, "unexpected panic", records[1]["error"])
+
+				require.Equal(t, "ERROR", records[2]["level"])
+				require.Equal(t, "request", records[2]["msg"])
+				require.Equal(t, float64(http.StatusInternalServerError), records[2]["status"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			var logs bytes.Buffer
+
+			server := newTestServerWithAuthMode(t, store, util.AuthModeToken)
+			server.logger = logging.New(&logs, slog.LevelDebug)
+
+			server.app.Get("/logged", optionalAuth(server), func(c *fiber.Ctx) error {
+				logging.FromContext(c.UserContext()).Info("handled")
+				return c.SendStatus(fiber.StatusOK)
+			})
+			server.app.Get("/error", func(c *fiber.Ctx) error {
+				return errors.New("unexpected error")
+			})
+			server.app.Get("/panic", func(c *fiber.Ctx) error {
+				panic("unexpected panic")
+			})
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    tc.path,
+			})
+			if tc.setupAuth != nil {
+				tc.setupAuth(t, request, server)
+			}
+
+			response := test_util.SendRequest(t, server.app, request)
+
+			records := unmarshalLogRecords(t, logs.Bytes())
+			for _, record := range records {
+				require.Equal(t, response.Header.Get(fiber.HeaderXRequestID), record["request_id"])
+			}
+			tc.checkLogs(t, records)
+		})
+	}
+}
+
+func TestTracingMiddleware(t *testing.T) {
+	const (
+		incomingTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+		incomingSpanID  = "00f067aa0ba902b7"
+	)
+
+	productURL := fmt.Sprintf("/api/v1/products/%s", util.RandomUUID())
+
+	testCases := []struct {
+		name        string
+		traceparent string
+		buildStubs  func(store *mockdb.MockStore)
+		checkSpans  func(t *testing.T, serverSpan sdktrace.ReadOnlySpan, spans []sdktrace.ReadOnlySpan)
+		wantStatus  int
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(db.Product{Price: "100.00"}, nil)
+				store.EXPECT().GetCategory(gomock.Any(), gomock.Any()).Return(db.Category{}, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Return(db.User{}, nil)
+				store.EXPECT().GetProductRatingsByProductIDs(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			wantStatus: http.StatusOK,
+			checkSpans: func(t *testing.T, serverSpan sdktrace.ReadOnlySpan, spans []sdktrace.ReadOnlySpan) {
+				require.False(t, serverSpan.Parent().IsValid())
+				require.Equal(t, codes.Unset, serverSpan.Status().Code)
+
+				serviceSpan := findSpan(t, spans, "ProductService.GetProduct")
+				require.Equal(t, serverSpan.SpanContext().TraceID(), serviceSpan.SpanContext().TraceID())
+				require.Equal(t, serverSpan.SpanContext().SpanID(), serviceSpan.Parent().SpanID())
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(db.Product{}, sql.ErrConnDone)
+			},
+			wantStatus: http.StatusInternalServerError,
+			checkSpans: func(t *testing.T, serverSpan sdktrace.ReadOnlySpan, spans []sdktrace.ReadOnlySpan) {
+				require.Equal(t, codes.Error, serverSpan.Status().Code)
+			},
+		},
+		{
+			name:        "IncomingTraceContext",
+			traceparent: fmt.Sprintf("00-%s-%s-01", incomingTraceID, incomingSpanID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(db.Product{}, sql.ErrNoRows)
+			},
+			wantStatus: http.StatusNotFound,
+			checkSpans: func(t *testing.T, serverSpan sdktrace.ReadOnlySpan, spans []sdktrace.ReadOnlySpan) {
+				require.Equal(t, incomingTraceID, serverSpan.SpanContext().TraceID().String())
+				require.True(t, serverSpan.Parent().IsRemote())
+				require.Equal(t, incomingSpanID, serverSpan.Parent().SpanID().String())
+				require.Equal(t, codes.Unset, serverSpan.Status().Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			tc.buildStubs(store)
+
+			var logs bytes.Buffer
+			recorder := tracetest.NewSpanRecorder()
+
+			server := newTestServer(t, store)
+			server.logger = logging.New(&logs, slog.LevelInfo)
+			server.tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			server.propagator = tracing.NewPropagator()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    productURL,
+			})
+			if len(tc.traceparent) > 0 {
+				request.Header.Set("traceparent", tc.traceparent)
+			}
+
+			response := test_util.SendRequest(t, server.app, request)
+			require.Equal(t, tc.wantStatus, response.StatusCode)
+
+			spans := recorder.Ended()
+
+			// Spans are named after the route template, not the path
+			serverSpan := findSpan(t, spans, "GET /api/v1/products/:id")
+			require.Equal(t, trace.SpanKindServer, serverSpan.SpanKind())
+			require.Contains(t, serverSpan.Attributes(), semconv.HTTPRoute("/api/v1/products/:id"))
+			require.Contains(t, serverSpan.Attributes(), semconv.HTTPStatusCode(tc.wantStatus))
+			require.Contains(t, serverSpan.Attributes(),
+				attribute.String("http.request_id", response.Header.Get(fiber.HeaderXRequestID)))
+
+			// Access logs can be joined with the trace
+			records := unmarshalLogRecords(t, logs.Bytes())
+			require.NotEmpty(t, records)
+			require.Equal(t, serverSpan.SpanContext().TraceID().String(), records[len(records)-1]["trace_id"])
+
+			tc.checkSpans(t, serverSpan, spans)
+		})
+	}
+}
+
+func findSpan(t *testing.T, spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.FailNow(t, "span not found", name)
+	return nil
+}
+
+// optionalAuth authenticates the request only if it has credentials.
+func optionalAuth(server *Server) fiber.Handler {
+	authenticate := authMiddleware(server)
+
+	return func(c *fiber.Ctx) error {
+		if len(c.Cookies(cookieSessionTokenKey)) == 0 {
+			return c.Next()
+		}
+		return authenticate(c)
+	}
+}
+
+func unmarshalLogRecords(t *testing.T, data []byte) []map[string]any {
+	var records []map[string]any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var record map[string]any
+		err := decoder.Decode(&record)
+		require.NoError(t, err)
+
+		records = append(records, record)
+	}
+
+	return records
 }
@@ -0,0 +1,127 @@
+package api
+
+import (
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
+	order_domain "github.com/ot07/next-bazaar/api/domain/order"
+	"github.com/ot07/next-bazaar/api/validation"
+)
+
+type orderHandler struct {
+	service *order_domain.OrderService
+}
+
+func newOrderHandler(s *order_domain.OrderService) *orderHandler {
+	return &orderHandler{
+		service: s,
+	}
+}
+
+// @Summary      Checkout
+// @Tags         Orders
+// @Success      200 {object} order_domain.OrderResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      409 {object} insufficientStockResponse
+// @Failure      500 {object} errorResponse
+// @Router       /checkout [post]
+func (h *orderHandler) checkout(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	order, err := h.service.Checkout(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	rsp := order_domain.NewOrderResponse(order)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Get order
+// @Tags         Orders
+// @Param        id path string true "Order ID"
+// @Success      200 {object} order_domain.OrderResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /orders/{id} [get]
+func (h *orderHandler) getOrder(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(order_domain.GetOrderRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	order, err := h.service.GetOrder(c.UserContext(), order_domain.GetOrderServiceParams{
+		ID:     req.ID,
+		UserID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := order_domain.NewOrderResponse(order)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      List orders
+// @Tags         Orders
+// @Param        query query order_domain.ListOrdersRequest true "query"
+// @Success      200 {object} order_domain.ListOrdersResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /orders [get]
+func (h *orderHandler) listOrders(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(order_domain.ListOrdersRequest)
+	if err := c.QueryParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	orders, err := h.service.GetOrders(c.UserContext(), order_domain.GetOrdersServiceParams{
+		PageID:   req.PageID,
+		PageSize: req.PageSize,
+		UserID:   session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	totalCount, err := h.service.CountOrders(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	pageCount := int64(math.Ceil(float64(totalCount) / float64(req.PageSize)))
+
+	rsp := order_domain.ListOrdersResponse{
+		Meta: order_domain.ListOrdersResponseMeta{
+			PageID:     req.PageID,
+			PageSize:   req.PageSize,
+			PageCount:  pageCount,
+			TotalCount: totalCount,
+		},
+		Data: order_domain.NewOrdersResponse(orders),
+	}
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
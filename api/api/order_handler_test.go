@@ -0,0 +1,629 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	order_domain "github.com/ot07/next-bazaar/api/domain/order"
+	"github.com/ot07/next-bazaar/api/test_util"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestCheckoutAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+
+		category, err := store.CreateCategory(ctx, "test-category")
+		require.NoError(t, err)
+
+		product, err := store.CreateProduct(ctx, db.CreateProductParams{
+			Name:          "test-product",
+			Description:   sql.NullString{String: "test-description", Valid: true},
+			Price:         "100.00",
+			StockQuantity: 10,
+			CategoryID:    category.ID,
+			SellerID:      user.ID,
+			ImageUrl:      sql.NullString{String: "test-image-url", Valid: true},
+		})
+		require.NoError(t, err)
+
+		_, err = store.CreateCartProduct(ctx, db.CreateCartProductParams{
+			UserID:    user.ID,
+			ProductID: product.ID,
+			Quantity:  5,
+		})
+		require.NoError(t, err)
+
+		return test_util.SeedData{
+			"user":    user,
+			"product": product,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalOrderResponse(t, response.Body)
+
+				require.NotEmpty(t, gotResponse.ID)
+				require.Equal(t, 1, len(gotResponse.Items))
+
+				require.Equal(t, seedData["product"].(db.Product).ID, gotResponse.Items[0].ProductID)
+				require.Equal(t, "test-product", gotResponse.Items[0].Name)
+				require.True(t, decimal.NewFromFloat(100.00).Equal(gotResponse.Items[0].Price.Decimal))
+				require.Equal(t, int32(5), gotResponse.Items[0].Quantity)
+				require.True(t, decimal.NewFromFloat(500.00).Equal(gotResponse.Items[0].Subtotal.Decimal))
+
+				require.True(t, decimal.NewFromFloat(500.00).Equal(gotResponse.Subtotal.Decimal))
+				require.True(t, decimal.NewFromFloat(5.00).Equal(gotResponse.Shipping.Decimal))
+				require.True(t, decimal.NewFromFloat(50.00).Equal(gotResponse.Tax.Decimal))
+				require.True(t, decimal.NewFromFloat(555.00).Equal(gotResponse.Total.Decimal))
+
+				cartProducts, err := store.GetCartProductsByUserID(context.Background(), seedData["user"].(db.User).ID)
+				require.NoError(t, err)
+				require.Empty(t, cartProducts)
+
+				product, err := store.GetProduct(context.Background(), seedData["product"].(db.Product).ID)
+				require.NoError(t, err)
+				require.Equal(t, int32(5), product.StockQuantity)
+			},
+		},
+		{
+			name:       "InsufficientStock",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				product := seedData["product"].(db.Product)
+				_, err := store.UpdateProduct(context.Background(), db.UpdateProductParams{
+					ID:            product.ID,
+					Name:          product.Name,
+					Description:   product.Description,
+					Price:         product.Price,
+					StockQuantity: 3,
+					CategoryID:    product.CategoryID,
+					SellerID:      product.SellerID,
+					ImageUrl:      product.ImageUrl,
+				})
+				require.NoError(t, err)
+
+				return seedData
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+
+				cartProducts, err := store.GetCartProductsByUserID(context.Background(), seedData["user"].(db.User).ID)
+				require.NoError(t, err)
+				require.Len(t, cartProducts, 1)
+
+				product, err := store.GetProduct(context.Background(), seedData["product"].(db.Product).ID)
+				require.NoError(t, err)
+				require.Equal(t, int32(3), product.StockQuantity)
+			},
+		},
+		{
+			name:       "EmptyCart",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				ctx := context.Background()
+
+				user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+					Name:         "testuser",
+					Email:        "test@example.com",
+					Password:     "test-password",
+					SessionToken: sessionToken,
+					RefreshToken: refreshToken,
+				})
+
+				return test_util.SeedData{
+					"user": user,
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "CartChanged",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				productID := util.RandomUUID()
+
+				mockStore.EXPECT().
+					GetCartProductsByUserID(gomock.Any(), gomock.Any()).
+					Return([]db.CartProduct{{ProductID: productID, Quantity: 1}}, nil)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Eq(productID)).
+					Return(db.Product{ID: productID, Name: "test-product", Price: "100.00"}, nil)
+
+				// e.g. the same cart has been checked out concurrently
+				mockStore.EXPECT().
+					CheckoutTx(gomock.Any(), gomock.Any()).
+					Return(db.CheckoutTxResult{}, db.ErrCartChanged)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetCartProductsByUserID(gomock.Any(), gomock.Any()).
+					Return([]db.CartProduct{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/checkout",
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, store, response, seedData)
+		})
+	}
+}
+
+func TestGetOrderAPI(t *testing.T) {
+	sessionTokens := test_util.NewTokens(2, time.Minute)
+	refreshTokens := test_util.NewTokens(2, time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		ctx := context.Background()
+
+		users := make([]db.User, 2)
+		for i := range users {
+			users[i] = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+				Name:         fmt.Sprintf("testuser-%d", i),
+				Email:        fmt.Sprintf("test-%d@example.com", i),
+				Password:     "test-password",
+				SessionToken: sessionTokens[i],
+				RefreshToken: refreshTokens[i],
+			})
+		}
+
+		category, err := store.CreateCategory(ctx, "test-category")
+		require.NoError(t, err)
+
+		product, err := store.CreateProduct(ctx, db.CreateProductParams{
+			Name:          "test-product",
+			Description:   sql.NullString{String: "test-description", Valid: true},
+			Price:         "100.00",
+			StockQuantity: 10,
+			CategoryID:    category.ID,
+			SellerID:      users[1].ID,
+			ImageUrl:      sql.NullString{String: "test-image-url", Valid: true},
+		})
+		require.NoError(t, err)
+
+		order, err := store.CreateOrder(ctx, db.CreateOrderParams{
+			UserID:   users[0].ID,
+			Subtotal: "200.00",
+			Shipping: "5.00",
+			Tax:      "20.00",
+			Total:    "225.00",
+		})
+		require.NoError(t, err)
+
+		_, err = store.CreateOrderItem(ctx, db.CreateOrderItemParams{
+			OrderID:     order.ID,
+			ProductID:   product.ID,
+			Name:        product.Name,
+			Description: product.Description,
+			Price:       product.Price,
+			Quantity:    2,
+			ImageUrl:    product.ImageUrl,
+		})
+		require.NoError(t, err)
+
+		return test_util.SeedData{
+			"order_id": order.ID.String(),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		setupAuth      func(request *http.Request)
+		checkResponse  func(t *testing.T, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionTokens[0].Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalOrderResponse(t, response.Body)
+
+				require.Equal(t, seedData["order_id"].(string), gotResponse.ID.String())
+				require.Equal(t, 1, len(gotResponse.Items))
+				require.Equal(t, "test-product", gotResponse.Items[0].Name)
+				require.Equal(t, int32(2), gotResponse.Items[0].Quantity)
+				require.True(t, decimal.NewFromFloat(200.00).Equal(gotResponse.Items[0].Subtotal.Decimal))
+				require.True(t, decimal.NewFromFloat(225.00).Equal(gotResponse.Total.Decimal))
+			},
+		},
+		{
+			name:           "OtherUsersOrder",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionTokens[1].Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:       "NotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				_ = defaultCreateSeedData(t, store)
+
+				return test_util.SeedData{
+					"order_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionTokens[0].Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:       "InvalidID",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				_ = defaultCreateSeedData(t, store)
+
+				return test_util.SeedData{
+					"order_id": "InvalidID",
+				}
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionTokens[0].Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      func(request *http.Request) {},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionTokens[0].Hash(),
+					SessionTokenExpiredAt: sessionTokens[0].ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetOrder(gomock.Any(), gomock.Any()).
+					Return(db.Order{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"order_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: func(request *http.Request) {
+				test_util.AddSessionTokenInCookie(request, sessionTokens[0].Value)
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    fmt.Sprintf("/api/v1/orders/%s", seedData["order_id"].(string)),
+			})
+
+			tc.setupAuth(request)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response, seedData)
+		})
+	}
+}
+
+func TestListOrdersAPI(t *testing.T) {
+	pageSize := 5
+
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+
+		for i := 0; i < 6; i++ {
+			_, err := store.CreateOrder(ctx, db.CreateOrderParams{
+				UserID:   user.ID,
+				Subtotal: "100.00",
+				Shipping: "5.00",
+				Tax:      "10.00",
+				Total:    "115.00",
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		query          test_util.Query
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListOrdersResponse(t, response.Body)
+
+				require.Equal(t, int32(1), gotResponse.Meta.PageID)
+				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
+				require.Equal(t, int64(2), gotResponse.Meta.PageCount)
+				require.Equal(t, int64(6), gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, pageSize)
+			},
+		},
+		{
+			name:           "PageIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeMoreThanUpperLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": "101",
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			setupAuth: test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					ListOrdersByUser(gomock.Any(), gomock.Any()).
+					Return([]db.Order{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/orders",
+				Query:  tc.query,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func unmarshalOrderResponse(t *testing.T, body io.ReadCloser) order_domain.OrderResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed order_domain.OrderResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalListOrdersResponse(t *testing.T, body io.ReadCloser) order_domain.ListOrdersResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed order_domain.ListOrdersResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
@@ -2,21 +2,25 @@ package api
 
 import (
 	"database/sql"
-	"math"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
 	product_domain "github.com/ot07/next-bazaar/api/domain/product"
 	"github.com/ot07/next-bazaar/api/validation"
+	"github.com/ot07/next-bazaar/metrics"
 	"github.com/shopspring/decimal"
 )
 
 type productHandler struct {
 	service *product_domain.ProductService
+	metrics *metrics.Metrics
 }
 
-func newProductHandler(s *product_domain.ProductService) *productHandler {
+func newProductHandler(s *product_domain.ProductService, m *metrics.Metrics) *productHandler {
 	return &productHandler{
 		service: s,
+		metrics: m,
 	}
 }
 
@@ -31,20 +35,17 @@ func newProductHandler(s *product_domain.ProductService) *productHandler {
 func (h *productHandler) getProduct(c *fiber.Ctx) error {
 	req := new(product_domain.GetProductRequest)
 	if err := c.ParamsParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	product, err := h.service.GetProduct(c.Context(), req.ID)
+	product, err := h.service.GetProduct(c.UserContext(), req.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(newErrorResponse(err))
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp, err := product_domain.NewProductResponse(product)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	return c.Status(fiber.StatusOK).JSON(rsp)
@@ -60,45 +61,105 @@ func (h *productHandler) getProduct(c *fiber.Ctx) error {
 func (h *productHandler) listProducts(c *fiber.Ctx) error {
 	req := new(product_domain.ListProductsRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
+	req.CursorMode = c.Context().QueryArgs().Has("cursor")
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
+	}
+
+	if req.CursorMode && !product_domain.IsCursorSortSupported(req.Sort) {
+		return product_domain.ErrCursorSortNotSupported
+	}
+
+	query := strings.TrimSpace(req.Query)
+
+	minPrice, err := parseNullDecimal(req.MinPrice)
+	if err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	maxPrice, err := parseNullDecimal(req.MaxPrice)
+	if err != nil {
+		return apperror.InvalidRequest(err)
 	}
 
 	arg := product_domain.GetProductsServiceParams{
 		PageID:     req.PageID,
 		PageSize:   req.PageSize,
 		CategoryID: req.CategoryID,
-	}
+		Query:      sql.NullString{String: query, Valid: len(query) > 0},
+		MinPrice:   minPrice,
+		MaxPrice:   maxPrice,
+		InStock:    req.InStock,
+		SellerID:   req.SellerID,
+		Sort:       req.Sort,
+	}
+
+	var products []product_domain.Product
+	var meta product_domain.ListProductsResponseMeta
+	if req.CursorMode {
+		var nextCursor string
+		products, nextCursor, err = h.service.GetProductsByCursor(c.UserContext(), product_domain.GetProductsByCursorServiceParams{
+			Cursor:     req.Cursor,
+			PageSize:   arg.PageSize,
+			CategoryID: arg.CategoryID,
+			Query:      arg.Query,
+			MinPrice:   arg.MinPrice,
+			MaxPrice:   arg.MaxPrice,
+			InStock:    arg.InStock,
+			SellerID:   arg.SellerID,
+			Newest:     arg.Sort == "newest",
+		})
+		if err != nil {
+			return err
+		}
 
-	products, err := h.service.GetProducts(c.Context(), arg)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		meta = product_domain.NewCursorListProductsResponseMeta(req.PageSize, nextCursor)
+	} else {
+		products, err = h.service.GetProducts(c.UserContext(), arg)
+		if err != nil {
+			return err
+		}
+
+		totalCount, err := h.service.CountProducts(c.UserContext(), product_domain.CountProductsServiceParams{
+			CategoryID: arg.CategoryID,
+			Query:      arg.Query,
+			MinPrice:   arg.MinPrice,
+			MaxPrice:   arg.MaxPrice,
+			InStock:    arg.InStock,
+			SellerID:   arg.SellerID,
+		})
+		if err != nil {
+			return err
+		}
+
+		meta = product_domain.NewPageListProductsResponseMeta(req.PageID, req.PageSize, totalCount)
 	}
 
-	totalCount, err := h.service.CountProducts(c.Context())
+	facets, err := h.service.GetProductFacets(c.UserContext(), product_domain.GetProductFacetsServiceParams{
+		CategoryID: arg.CategoryID,
+		Query:      arg.Query,
+		MinPrice:   arg.MinPrice,
+		MaxPrice:   arg.MaxPrice,
+		InStock:    arg.InStock,
+		SellerID:   arg.SellerID,
+	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
-	pageCount := int64(math.Ceil(float64(totalCount) / float64(req.PageSize)))
-
 	rspData, err := product_domain.NewProductsResponse(products)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := product_domain.ListProductsResponse{
-		Meta: product_domain.ListProductsResponseMeta{
-			PageID:     req.PageID,
-			PageSize:   req.PageSize,
-			PageCount:  pageCount,
-			TotalCount: totalCount,
-		},
-		Data: rspData,
+		Meta:   meta,
+		Data:   rspData,
+		Facets: product_domain.NewProductFacetsResponse(facets),
 	}
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -113,49 +174,65 @@ func (h *productHandler) listProducts(c *fiber.Ctx) error {
 func (h *productHandler) listProductsBySeller(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(product_domain.ListProductsBySellerRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
+	req.CursorMode = c.Context().QueryArgs().Has("cursor")
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	arg := product_domain.GetProductsBySellerServiceParams{
-		PageID:   req.PageID,
-		PageSize: req.PageSize,
-		SellerID: session.UserID,
+	if req.CursorMode && !product_domain.IsCursorSortSupported(req.Sort) {
+		return product_domain.ErrCursorSortNotSupported
 	}
 
-	products, err := h.service.GetProductsBySeller(c.Context(), arg)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
-	}
+	var products []product_domain.Product
+	var meta product_domain.ListProductsResponseMeta
+	if req.CursorMode {
+		var nextCursor string
+		products, nextCursor, err = h.service.GetProductsBySellerByCursor(c.UserContext(), product_domain.GetProductsBySellerByCursorServiceParams{
+			Cursor:   req.Cursor,
+			PageSize: req.PageSize,
+			SellerID: session.UserID,
+			Newest:   req.Sort == "newest",
+		})
+		if err != nil {
+			return err
+		}
 
-	totalCount, err := h.service.CountProductsBySeller(c.Context(), session.UserID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
-	}
+		meta = product_domain.NewCursorListProductsResponseMeta(req.PageSize, nextCursor)
+	} else {
+		products, err = h.service.GetProductsBySeller(c.UserContext(), product_domain.GetProductsBySellerServiceParams{
+			PageID:   req.PageID,
+			PageSize: req.PageSize,
+			SellerID: session.UserID,
+			Sort:     req.Sort,
+		})
+		if err != nil {
+			return err
+		}
+
+		totalCount, err := h.service.CountProductsBySeller(c.UserContext(), session.UserID)
+		if err != nil {
+			return err
+		}
 
-	pageCount := int64(math.Ceil(float64(totalCount) / float64(req.PageSize)))
+		meta = product_domain.NewPageListProductsResponseMeta(req.PageID, req.PageSize, totalCount)
+	}
 
 	rspData, err := product_domain.NewProductsResponse(products)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := product_domain.ListProductsResponse{
-		Meta: product_domain.ListProductsResponseMeta{
-			PageID:     req.PageID,
-			PageSize:   req.PageSize,
-			PageCount:  pageCount,
-			TotalCount: totalCount,
-		},
+		Meta: meta,
 		Data: rspData,
 	}
 	return c.Status(fiber.StatusOK).JSON(rsp)
@@ -171,12 +248,12 @@ func (h *productHandler) listProductsBySeller(c *fiber.Ctx) error {
 func (h *productHandler) listProductCategories(c *fiber.Ctx) error {
 	req := new(product_domain.ListProductCategoriesRequest)
 	if err := c.QueryParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	arg := product_domain.GetProductCategoriesServiceParams{
@@ -184,9 +261,9 @@ func (h *productHandler) listProductCategories(c *fiber.Ctx) error {
 		PageSize: req.PageSize,
 	}
 
-	categories, err := h.service.GetProductCategories(c.Context(), arg)
+	categories, err := h.service.GetProductCategories(c.UserContext(), arg)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := product_domain.ListProductCategoriesResponse{
@@ -199,6 +276,123 @@ func (h *productHandler) listProductCategories(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
 
+// @Summary      Get product category tree
+// @Tags         Products
+// @Success      200 {array} product_domain.CategoryTreeResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/categories/tree [get]
+func (h *productHandler) getProductCategoryTree(c *fiber.Ctx) error {
+	nodes, err := h.service.GetCategoryTree(c.UserContext())
+	if err != nil {
+		return err
+	}
+
+	rsp := product_domain.NewCategoryTreeResponse(nodes)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Create product category
+// @Tags         Products
+// @Param        body body product_domain.CreateCategoryRequest true "Category object"
+// @Success      200 {object} product_domain.ProductCategoryResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/categories [post]
+// @Router       /admin/categories [post]
+func (h *productHandler) createProductCategory(c *fiber.Ctx) error {
+	req := new(product_domain.CreateCategoryRequest)
+	if err := c.BodyParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	category, err := h.service.CreateCategory(c.UserContext(), product_domain.CreateCategoryServiceParams{
+		Name:     req.Name,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := product_domain.NewProductCategoryResponse(category)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Update product category
+// @Tags         Products
+// @Param        id path string true "Category ID"
+// @Param        body body product_domain.UpdateCategoryRequestBody true "Category object"
+// @Success      200 {object} product_domain.ProductCategoryResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/categories/{id} [patch]
+// @Router       /admin/categories/{id} [patch]
+func (h *productHandler) updateProductCategory(c *fiber.Ctx) error {
+	reqParams := new(product_domain.UpdateCategoryRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(product_domain.UpdateCategoryRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	category, err := h.service.UpdateCategory(c.UserContext(), product_domain.UpdateCategoryServiceParams{
+		ID:       reqParams.ID,
+		Name:     reqBody.Name,
+		ParentID: reqBody.ParentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := product_domain.NewProductCategoryResponse(category)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Delete product category
+// @Tags         Products
+// @Param        id path string true "Category ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/categories/{id} [delete]
+// @Router       /admin/categories/{id} [delete]
+func (h *productHandler) deleteProductCategory(c *fiber.Ctx) error {
+	req := new(product_domain.DeleteCategoryRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err := h.service.DeleteCategory(c.UserContext(), req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // @Summary      Add product
 // @Tags         Users
 // @Param        body body product_domain.AddProductRequest true "Product object"
@@ -210,25 +404,25 @@ func (h *productHandler) listProductCategories(c *fiber.Ctx) error {
 func (h *productHandler) addProduct(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(product_domain.AddProductRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	price, err := decimal.NewFromString(req.Price)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.AddProduct(c.Context(), product_domain.AddProductServiceParams{
+	err = h.service.AddProduct(c.UserContext(), product_domain.AddProductServiceParams{
 		Name:          req.Name,
 		Description:   sql.NullString{String: req.Description, Valid: len(req.Description) > 0},
 		Price:         price,
@@ -238,9 +432,11 @@ func (h *productHandler) addProduct(c *fiber.Ctx) error {
 		ImageUrl:      sql.NullString{String: req.ImageUrl, Valid: len(req.ImageUrl) > 0},
 	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
+	h.metrics.ProductAdded()
+
 	rsp := newMessageResponse("Product added successfully")
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -252,35 +448,37 @@ func (h *productHandler) addProduct(c *fiber.Ctx) error {
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
 // @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
 // @Failure      500 {object} errorResponse
 // @Router       /users/products/{id} [put]
 func (h *productHandler) updateProduct(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	reqParams := new(product_domain.UpdateProductRequestParams)
 	if err := c.ParamsParser(reqParams); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	reqBody := new(product_domain.UpdateProductRequestBody)
 	if err := c.BodyParser(reqBody); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(reqBody); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	price, err := decimal.NewFromString(reqBody.Price)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.UpdateProduct(c.Context(), product_domain.UpdateProductServiceParams{
+	err = h.service.UpdateProduct(c.UserContext(), product_domain.UpdateProductServiceParams{
 		ID:            reqParams.ProductID,
 		Name:          reqBody.Name,
 		Description:   sql.NullString{String: reqBody.Description, Valid: len(reqBody.Description) > 0},
@@ -291,9 +489,54 @@ func (h *productHandler) updateProduct(c *fiber.Ctx) error {
 		ImageUrl:      sql.NullString{String: reqBody.ImageUrl, Valid: len(reqBody.ImageUrl) > 0},
 	})
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := newMessageResponse("Product updated successfully")
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
+
+// @Summary      Delete product
+// @Tags         Users
+// @Param        id path string true "Product ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/products/{id} [delete]
+func (h *productHandler) deleteProduct(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(product_domain.DeleteProductRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.DeleteProduct(c.UserContext(), product_domain.DeleteProductServiceParams{
+		ID:       req.ProductID,
+		SellerID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func parseNullDecimal(s string) (decimal.NullDecimal, error) {
+	if len(s) == 0 {
+		return decimal.NullDecimal{}, nil
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.NullDecimal{}, err
+	}
+
+	return decimal.NullDecimal{Decimal: d, Valid: true}, nil
+}
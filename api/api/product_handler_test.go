@@ -10,6 +10,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	product_domain "github.com/ot07/next-bazaar/api/domain/product"
 	"github.com/ot07/next-bazaar/api/test_util"
 	db "github.com/ot07/next-bazaar/db/sqlc"
@@ -74,6 +76,60 @@ func TestGetProduct(t *testing.T) {
 				require.Equal(t, "test-category", gotProduct.Category)
 				require.Equal(t, "testuser", gotProduct.Seller)
 				require.Equal(t, "test-image-url", gotProduct.ImageUrl.String)
+				require.Zero(t, gotProduct.AverageRating)
+				require.Zero(t, gotProduct.ReviewCount)
+			},
+		},
+		{
+			name:       "WithReviews",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				ctx := context.Background()
+
+				users := make([]db.User, 3)
+				for i := range users {
+					users[i] = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+						Name:         fmt.Sprintf("testuser-%d", i),
+						Email:        fmt.Sprintf("test-%d@example.com", i),
+						Password:     "test-password",
+						SessionToken: token.NewToken(time.Minute),
+						RefreshToken: token.NewToken(time.Minute),
+					})
+				}
+
+				category, err := store.CreateCategory(ctx, "test-category")
+				require.NoError(t, err)
+
+				product, err := store.CreateProduct(ctx, db.CreateProductParams{
+					Name:          "test-product",
+					Price:         "100.00",
+					StockQuantity: 10,
+					CategoryID:    category.ID,
+					SellerID:      users[0].ID,
+				})
+				require.NoError(t, err)
+
+				for i, rating := range []int32{4, 5} {
+					_, err = store.CreateReview(ctx, db.CreateReviewParams{
+						ProductID: product.ID,
+						UserID:    users[i+1].ID,
+						Rating:    rating,
+						Title:     "test-title",
+					})
+					require.NoError(t, err)
+				}
+
+				return test_util.SeedData{
+					"product_id": product.ID.String(),
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotProduct := unmarshalProductResponse(t, response.Body)
+
+				require.Equal(t, 4.5, gotProduct.AverageRating)
+				require.Equal(t, int64(2), gotProduct.ReviewCount)
 			},
 		},
 		{
@@ -213,10 +269,10 @@ func TestListProducts(t *testing.T) {
 
 				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-				require.Equal(t, int32(1), gotResponse.Meta.PageID)
+				require.Equal(t, int32(1), *gotResponse.Meta.PageID)
 				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
-				require.Equal(t, int64(2), gotResponse.Meta.PageCount)
-				require.Equal(t, int64(6), gotResponse.Meta.TotalCount)
+				require.Equal(t, int64(2), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(6), *gotResponse.Meta.TotalCount)
 
 				require.Len(t, gotResponse.Data, pageSize)
 
@@ -255,6 +311,9 @@ func TestListProducts(t *testing.T) {
 
 				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
+				require.Equal(t, int64(1), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(2), *gotResponse.Meta.TotalCount)
+
 				require.Len(t, gotResponse.Data, 2)
 
 				for i := 0; i < 2; i++ {
@@ -263,545 +322,1878 @@ func TestListProducts(t *testing.T) {
 			},
 		},
 		{
-			name:           "PageIDNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateAndReturnSeed,
+			name:       "FilterByParentCategory",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				ctx := context.Background()
+				parent := seedData["categories"].([]db.Category)[0]
+
+				child, err := store.CreateCategoryWithParent(ctx, db.CreateCategoryWithParentParams{
+					Name:     "test-category-child",
+					ParentID: uuid.NullUUID{UUID: parent.ID, Valid: true},
+				})
+				require.NoError(t, err)
+
+				_, err = store.CreateProduct(ctx, db.CreateProductParams{
+					Name:          "test-product-child",
+					Price:         "10.00",
+					StockQuantity: 1,
+					CategoryID:    child.ID,
+					SellerID:      seedData["users"].([]db.User)[0].ID,
+				})
+				require.NoError(t, err)
+
+				return seedData
+			},
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
-					"page_size": fmt.Sprintf("%d", pageSize),
+					"page_id":     "1",
+					"page_size":   fmt.Sprintf("%d", pageSize),
+					"category_id": seedData["categories"].([]db.Category)[0].ID.String(),
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
+				require.Len(t, gotResponse.Data, 3)
+
+				gotCategories := make([]string, len(gotResponse.Data))
+				for i, product := range gotResponse.Data {
+					gotCategories[i] = product.Category
+				}
+				require.ElementsMatch(t, []string{"test-category-0", "test-category-0", "test-category-child"}, gotCategories)
 			},
 		},
 		{
-			name:           "PageIDLessThanLowerLimit",
+			name:           "Facets",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createSeedData: defaultCreateSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
-					"page_id":   "0",
+					"page_id":   "1",
 					"page_size": fmt.Sprintf("%d", pageSize),
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+				require.NotNil(t, gotResponse.Facets)
+
+				require.Len(t, gotResponse.Facets.Categories, 3)
+				for i, facet := range gotResponse.Facets.Categories {
+					require.Equal(t, seedData["categories"].([]db.Category)[i].ID, facet.CategoryID)
+					require.Equal(t, fmt.Sprintf("test-category-%d", i), facet.Category)
+					require.Equal(t, int64(2), facet.Count)
+				}
+
+				gotCounts := make([]int64, len(gotResponse.Facets.PriceBuckets))
+				for i, facet := range gotResponse.Facets.PriceBuckets {
+					gotCounts[i] = facet.Count
+				}
+				require.Equal(t, []int64{0, 4, 2, 0, 0}, gotCounts)
+
+				require.True(t, decimal.NewFromInt(10).Equal(gotResponse.Facets.PriceBuckets[1].MinPrice.Decimal))
+				require.True(t, decimal.NewFromInt(50).Equal(gotResponse.Facets.PriceBuckets[1].MaxPrice.Decimal))
+				require.Nil(t, gotResponse.Facets.PriceBuckets[4].MaxPrice)
 			},
 		},
 		{
-			name:           "PageSizeNotFound",
+			name:           "FacetsWithFilters",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createSeedData: defaultCreateSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
-					"page_id": "1",
+					"page_id":     "1",
+					"page_size":   fmt.Sprintf("%d", pageSize),
+					"category_id": seedData["categories"].([]db.Category)[0].ID.String(),
+					"max_price":   "30",
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Equal(t, int64(1), *gotResponse.Meta.TotalCount)
+				require.Len(t, gotResponse.Data, 1)
+
+				// The category facet ignores the category filter but honours the price filter
+				require.Len(t, gotResponse.Facets.Categories, 3)
+				for _, facet := range gotResponse.Facets.Categories {
+					require.Equal(t, int64(1), facet.Count)
+				}
+
+				// The price facet ignores the price filter but honours the category filter
+				gotCounts := make([]int64, len(gotResponse.Facets.PriceBuckets))
+				for i, facet := range gotResponse.Facets.PriceBuckets {
+					gotCounts[i] = facet.Count
+				}
+				require.Equal(t, []int64{0, 2, 0, 0, 0}, gotCounts)
 			},
 		},
 		{
-			name:           "PageSizeLessThanLowerLimit",
+			name:           "SortByPriceDesc",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createSeedData: defaultCreateSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
-					"page_size": "0",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"sort":      "price_desc",
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Len(t, gotResponse.Data, pageSize)
+				for i := 0; i < pageSize; i++ {
+					require.True(t, decimal.NewFromInt(int64((6-i)*10)).Equal(gotResponse.Data[i].Price.Decimal))
+				}
 			},
 		},
 		{
-			name:           "PageSizeMoreThanUpperLimit",
+			name:           "FilterByPriceRange",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createSeedData: defaultCreateSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
-					"page_size": "101",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"min_price": "20",
+					"max_price": "40.00",
+					"sort":      "price_asc",
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Equal(t, int64(1), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, 3)
+				for i := 0; i < 3; i++ {
+					require.True(t, decimal.NewFromInt(int64((i+2)*10)).Equal(gotResponse.Data[i].Price.Decimal))
+				}
 			},
 		},
 		{
-			name: "InternalServerError",
-			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
-				mockStore, cleanup := test_util.NewMockStore(t)
-
-				mockStore.EXPECT().
-					ListProducts(gomock.Any(), gomock.Any()).
-					Return([]db.Product{}, sql.ErrConnDone)
+			name:       "FilterInStock",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				product := seedData["products"].([]db.Product)[0]
+				_, err := store.UpdateProduct(context.Background(), db.UpdateProductParams{
+					ID:            product.ID,
+					Name:          product.Name,
+					Description:   product.Description,
+					Price:         product.Price,
+					StockQuantity: 0,
+					CategoryID:    product.CategoryID,
+					SellerID:      product.SellerID,
+					ImageUrl:      product.ImageUrl,
+				})
+				require.NoError(t, err)
 
-				return mockStore, cleanup
+				return seedData
 			},
-			createSeedData: test_util.NoopCreateAndReturnSeed,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
-					"page_size": "1",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"in_stock":  "true",
 				}
 			},
 			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
-				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
-			},
-		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			store, cleanupStore := tc.buildStore(t)
-			defer cleanupStore()
-
-			seedData := tc.createSeedData(t, store)
-
-			request := test_util.NewRequest(t, test_util.RequestParams{
-				Method: http.MethodGet,
-				URL:    "/api/v1/products",
-				Query:  tc.createQuery(t, seedData),
-			})
-
-			server := newTestServer(t, store)
-			response := test_util.SendRequest(t, server.app, request)
-			tc.checkResponse(t, response, seedData)
-		})
-
-	}
-}
-
-func TestListProductsBySeller(t *testing.T) {
-	pageSize := 5
-
-	sessionTokens := test_util.NewTokens(2, time.Minute)
-	refreshTokens := test_util.NewTokens(2, time.Minute)
-
-	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
-		var err error
-
-		ctx := context.Background()
-
-		users := make([]db.User, 2)
-		for i := range users {
-			users[i] = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
-				Name:         fmt.Sprintf("testuser-%d", i),
-				Email:        fmt.Sprintf("test-%d@example.com", i),
-				Password:     "test-password",
-				SessionToken: sessionTokens[i],
-				RefreshToken: refreshTokens[i],
-			})
-		}
-
-		categories := make([]db.Category, 3)
-		for i := range categories {
-			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
-			require.NoError(t, err)
-		}
+				require.Equal(t, http.StatusOK, response.StatusCode)
 
-		for i := 0; i < 6; i++ {
-			_, err = store.CreateProduct(ctx, db.CreateProductParams{
-				Name:          fmt.Sprintf("test-product-%d", i),
-				Description:   sql.NullString{String: fmt.Sprintf("test-description-%d", i), Valid: true},
-				Price:         fmt.Sprintf("%d.00", (i+1)*10),
-				StockQuantity: int32(i + 1),
-				CategoryID:    categories[i%3].ID,
-				SellerID:      users[i%2].ID,
-				ImageUrl:      sql.NullString{String: fmt.Sprintf("test-image-url-%d", i), Valid: true},
-			})
-			require.NoError(t, err)
-		}
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-		return test_util.SeedData{
-			"users":      users,
-			"categories": categories,
-		}
-	}
+				require.Equal(t, int64(5), *gotResponse.Meta.TotalCount)
 
-	testCases := []struct {
-		name           string
-		buildStore     func(t *testing.T) (store db.Store, cleanup func())
-		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
-		createQuery    func(t *testing.T, seedData test_util.SeedData) test_util.Query
-		setupAuth      func(request *http.Request, sessionToken string)
-		checkResponse  func(t *testing.T, response *http.Response)
-	}{
+				for _, product := range gotResponse.Data {
+					require.NotEqual(t, seedData["products"].([]db.Product)[0].ID, product.ID)
+					require.Greater(t, product.StockQuantity, int32(0))
+				}
+			},
+		},
 		{
-			name:           "OK",
+			name:           "FilterBySeller",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
 					"page_size": fmt.Sprintf("%d", pageSize),
+					"seller_id": seedData["users"].([]db.User)[1].ID.String(),
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
 
 				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-				require.Equal(t, int32(1), gotResponse.Meta.PageID)
-				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
-				require.Equal(t, int64(1), gotResponse.Meta.PageCount)
-				require.Equal(t, int64(3), gotResponse.Meta.TotalCount)
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
 
 				require.Len(t, gotResponse.Data, 3)
-
-				for i := 0; i < 3; i++ {
-					productIndex := i * 2
-					categoryIndex := productIndex % 3
-
-					require.NotEmpty(t, gotResponse.Data[i].ID)
-					require.Equal(t, fmt.Sprintf("test-product-%d", productIndex), gotResponse.Data[i].Name)
-					require.Equal(t, fmt.Sprintf("test-description-%d", productIndex), gotResponse.Data[i].Description.String)
-					require.True(t, decimal.NewFromInt(int64((productIndex+1)*10)).Equal(gotResponse.Data[i].Price.Decimal))
-					require.Equal(t, int32(productIndex+1), gotResponse.Data[i].StockQuantity)
-					require.Equal(t, fmt.Sprintf("test-category-%d", categoryIndex), gotResponse.Data[i].Category)
-					require.Equal(t, "testuser-0", gotResponse.Data[i].Seller)
-					require.Equal(t, fmt.Sprintf("test-image-url-%d", productIndex), gotResponse.Data[i].ImageUrl.String)
+				for _, product := range gotResponse.Data {
+					require.Equal(t, "testuser-1", product.Seller)
 				}
 			},
 		},
 		{
-			name:           "NoAuthorization",
+			name:           "InvalidSort",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
 					"page_size": fmt.Sprintf("%d", pageSize),
+					"sort":      "popularity",
 				}
 			},
-			setupAuth: test_util.NoopSetupAuth,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PageIDNotFound",
+			name:           "MinPriceInvalidFormat",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
+					"page_id":   "1",
 					"page_size": fmt.Sprintf("%d", pageSize),
+					"min_price": "cheap",
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PageIDLessThanLowerLimit",
+			name:           "MinPriceIsNegative",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
-					"page_id":   "0",
+					"page_id":   "1",
 					"page_size": fmt.Sprintf("%d", pageSize),
+					"min_price": "-1",
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PageSizeNotFound",
+			name:           "MaxPriceLessThanMinPrice",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
-					"page_id": "1",
+					"page_id":   "1",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"min_price": "50",
+					"max_price": "10",
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PageSizeLessThanLowerLimit",
+			name:           "Search",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: createSearchSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
-					"page_size": "0",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"q":         "chair",
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Equal(t, int64(1), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, 3)
+				require.Equal(t, "Wooden table", gotResponse.Data[2].Name)
 			},
 		},
 		{
-			name:           "PageSizeMoreThanUpperLimit",
+			name:           "SearchWithPagination",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
+			createSeedData: createSearchSeedData,
 			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
 				return test_util.Query{
 					"page_id":   "1",
-					"page_size": "101",
+					"page_size": "2",
+					"q":         "chairs",
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
-			},
-		},
-		{
-			name: "InternalServerError",
-			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
-				mockStore, cleanup := test_util.NewMockStore(t)
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
 
-				test_util.BuildValidSessionStubs(mockStore, db.Session{
-					ID:                    util.RandomUUID(),
-					UserID:                util.RandomUUID(),
-					SessionToken:          sessionTokens[0].ID,
-					SessionTokenExpiredAt: sessionTokens[0].ExpiredAt,
-					CreatedAt:             time.Now(),
-				})
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-				mockStore.EXPECT().
-					ListProductsBySeller(gomock.Any(), gomock.Any()).
-					Return([]db.Product{}, sql.ErrConnDone)
+				require.Equal(t, int64(2), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
 
-				return mockStore, cleanup
-			},
-			createSeedData: test_util.NoopCreateAndReturnSeed,
-			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
-				return test_util.Query{
-					"page_id":   "1",
-					"page_size": "1",
+				require.Len(t, gotResponse.Data, 2)
+				for _, product := range gotResponse.Data {
+					require.Contains(t, product.Name, "chair")
 				}
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
-			},
 		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
-
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			store, cleanupStore := tc.buildStore(t)
-			defer cleanupStore()
-
-			seedData := tc.createSeedData(t, store)
-
-			request := test_util.NewRequest(t, test_util.RequestParams{
-				Method: http.MethodGet,
-				URL:    "/api/v1/users/products",
-				Query:  tc.createQuery(t, seedData),
-			})
-
-			tc.setupAuth(request, sessionTokens[0].ID.String())
-
-			server := newTestServer(t, store)
-			response := test_util.SendRequest(t, server.app, request)
-			tc.checkResponse(t, response)
-		})
-
-	}
-}
-
-func TestListProductCategories(t *testing.T) {
-	pageSize := 5
-
-	defaultCreateSeedData := func(t *testing.T, store db.Store) {
-		var err error
-
-		ctx := context.Background()
-
-		categories := make([]db.Category, 6)
-		for i := range categories {
-			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
-			require.NoError(t, err)
-		}
-	}
-
-	testCases := []struct {
-		name           string
-		buildStore     func(t *testing.T) (store db.Store, cleanup func())
-		createSeedData func(t *testing.T, store db.Store)
-		query          test_util.Query
-		checkResponse  func(t *testing.T, response *http.Response)
-	}{
 		{
-			name:           "OK",
+			name:           "SearchNoMatch",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			query: test_util.Query{
-				"page_id":   "1",
-				"page_size": fmt.Sprintf("%d", pageSize),
+			createSeedData: createSearchSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"q":         "sofa",
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
 
-				gotResponse := unmarshalListProductCategoriesResponse(t, response.Body)
-
-				require.Equal(t, int32(1), gotResponse.Meta.PageID)
-				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
-
-				require.Len(t, gotResponse.Data, pageSize)
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-				for i := 0; i < pageSize; i++ {
-					require.NotEmpty(t, gotResponse.Data[i].ID)
-					require.Equal(t, fmt.Sprintf("test-category-%d", i), gotResponse.Data[i].Name)
-				}
+				require.Equal(t, int64(0), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(0), *gotResponse.Meta.TotalCount)
+				require.Empty(t, gotResponse.Data)
 			},
 		},
 		{
 			name:           "PageIDNotFound",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_size": fmt.Sprintf("%d", pageSize),
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
 			name:           "PageIDLessThanLowerLimit",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_id":   "0",
-				"page_size": fmt.Sprintf("%d", pageSize),
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "0",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
 			name:           "PageSizeNotFound",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_id": "1",
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id": "1",
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
 			name:           "PageSizeLessThanLowerLimit",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_id":   "1",
-				"page_size": "0",
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "0",
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
 			name:           "PageSizeMoreThanUpperLimit",
 			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_id":   "1",
-				"page_size": "101",
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "101",
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name: "InternalServerError",
+			name: "FacetsInternalServerError",
 			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
 				mockStore, cleanup := test_util.NewMockStore(t)
 
 				mockStore.EXPECT().
-					ListCategories(gomock.Any(), gomock.Any()).
-					Return([]db.Category{}, sql.ErrConnDone)
+					ListProducts(gomock.Any(), gomock.Any()).
+					Return([]db.Product{}, nil)
+
+				mockStore.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Any()).
+					Return([]db.Category{}, nil)
+
+				mockStore.EXPECT().
+					GetUsersByIDs(gomock.Any(), gomock.Any()).
+					Return([]db.User{}, nil)
+
+				mockStore.EXPECT().
+					GetProductRatingsByProductIDs(gomock.Any(), gomock.Any()).
+					Return([]db.GetProductRatingsByProductIDsRow{}, nil)
+
+				mockStore.EXPECT().
+					CountProducts(gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+
+				mockStore.EXPECT().
+					CountProductsByCategory(gomock.Any(), gomock.Any()).
+					Return([]db.CountProductsByCategoryRow{}, sql.ErrConnDone)
 
 				return mockStore, cleanup
 			},
-			createSeedData: test_util.NoopCreateSeedData,
-			query: test_util.Query{
-				"page_id":   "1",
-				"page_size": "1",
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "1",
+				}
 			},
-			checkResponse: func(t *testing.T, response *http.Response) {
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
 				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
 			},
 		},
-	}
+		{
+			name:           "CursorFirstPage",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"cursor":    "",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
 
-	for i := range testCases {
-		tc := testCases[i]
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
 
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
+				// Cursor pages do not have the page fields of offset pagination
+				require.Nil(t, gotResponse.Meta.PageID)
+				require.Nil(t, gotResponse.Meta.PageCount)
+				require.Nil(t, gotResponse.Meta.TotalCount)
+				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
+				require.NotEmpty(t, gotResponse.Meta.NextCursor)
+				require.Len(t, gotResponse.Data, pageSize)
+			},
+		},
+		{
+			name: "CursorMeta",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-			store, cleanupStore := tc.buildStore(t)
-			defer cleanupStore()
+				// Cursor pages are not counted
+				mockStore.EXPECT().
+					CountProducts(gomock.Any(), gomock.Any()).
+					Times(0)
 
-			tc.createSeedData(t, store)
+				mockStore.EXPECT().
+					ListProductsByCursor(gomock.Any(), gomock.Any()).
+					Return([]db.Product{}, nil)
 
-			request := test_util.NewRequest(t, test_util.RequestParams{
-				Method: http.MethodGet,
-				URL:    "/api/v1/products/categories",
-				Query:  tc.query,
-			})
+				mockStore.EXPECT().
+					GetCategoriesByIDs(gomock.Any(), gomock.Any()).
+					Return([]db.Category{}, nil)
 
-			server := newTestServer(t, store)
-			response := test_util.SendRequest(t, server.app, request)
-			tc.checkResponse(t, response)
-		})
+				mockStore.EXPECT().
+					GetUsersByIDs(gomock.Any(), gomock.Any()).
+					Return([]db.User{}, nil)
 
-	}
+				mockStore.EXPECT().
+					GetProductRatingsByProductIDs(gomock.Any(), gomock.Any()).
+					Return([]db.GetProductRatingsByProductIDsRow{}, nil)
+
+				mockStore.EXPECT().
+					CountProductsByCategory(gomock.Any(), gomock.Any()).
+					Return([]db.CountProductsByCategoryRow{}, nil)
+
+				mockStore.EXPECT().
+					CountProductsByPriceBucket(gomock.Any(), gomock.Any()).
+					Return([]db.CountProductsByPriceBucketRow{}, nil)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"cursor":    "",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				var body struct {
+					Meta map[string]any `json:"meta"`
+				}
+				err := json.NewDecoder(response.Body).Decode(&body)
+				require.NoError(t, err)
+
+				require.Equal(t, map[string]any{"page_size": float64(pageSize)}, body.Meta)
+			},
+		},
+		{
+			name:           "InvalidCursor",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"cursor":    "invalid-cursor",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "CursorWithUnsupportedSort",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"cursor":    "",
+					"page_size": fmt.Sprintf("%d", pageSize),
+					"sort":      "price_asc",
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "CursorInternalServerError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					ListProductsByCursor(gomock.Any(), gomock.Any()).
+					Return([]db.Product{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"cursor":    "",
+					"page_size": "1",
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					ListProducts(gomock.Any(), gomock.Any()).
+					Return([]db.Product{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "1",
+				}
+			},
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/products",
+				Query:  tc.createQuery(t, seedData),
+			})
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response, seedData)
+		})
+
+	}
+}
+
+func TestListProductsCursorPagination(t *testing.T) {
+	pageSize := 3
+	productCount := 7
+
+	createSeedData := func(t *testing.T, store db.Store) []db.Product {
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: token.NewToken(time.Minute),
+			RefreshToken: token.NewToken(time.Minute),
+		})
+
+		category, err := store.CreateCategory(ctx, "test-category")
+		require.NoError(t, err)
+
+		products := make([]db.Product, productCount)
+		for i := range products {
+			products[i], err = store.CreateProduct(ctx, db.CreateProductParams{
+				Name:          fmt.Sprintf("test-product-%d", i),
+				Price:         "10.00",
+				StockQuantity: 10,
+				CategoryID:    category.ID,
+				SellerID:      user.ID,
+			})
+			require.NoError(t, err)
+		}
+
+		return products
+	}
+
+	listAllPages := func(t *testing.T, store db.Store, sort string) []product_domain.ProductResponse {
+		server := newTestServer(t, store)
+
+		var products []product_domain.ProductResponse
+		cursor := ""
+		for page := 0; ; page++ {
+			require.Less(t, page, productCount, "cursor pagination does not terminate")
+
+			query := test_util.Query{
+				"cursor":    cursor,
+				"page_size": fmt.Sprintf("%d", pageSize),
+			}
+			if len(sort) > 0 {
+				query["sort"] = sort
+			}
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/products",
+				Query:  query,
+			})
+
+			response := test_util.SendRequest(t, server.app, request)
+			require.Equal(t, http.StatusOK, response.StatusCode)
+
+			gotResponse := unmarshalListProductsResponse(t, response.Body)
+			require.Nil(t, gotResponse.Meta.TotalCount)
+			require.LessOrEqual(t, len(gotResponse.Data), pageSize)
+
+			products = append(products, gotResponse.Data...)
+
+			if len(gotResponse.Meta.NextCursor) == 0 {
+				break
+			}
+			cursor = gotResponse.Meta.NextCursor
+		}
+
+		return products
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		store, cleanupStore := test_util.BuildTestDBStore(t)
+		defer cleanupStore()
+
+		seedProducts := createSeedData(t, store)
+
+		products := listAllPages(t, store, "")
+		require.Len(t, products, len(seedProducts))
+
+		seen := make(map[string]bool)
+		for _, product := range products {
+			require.False(t, seen[product.ID.String()], "duplicated product in cursor pagination")
+			seen[product.ID.String()] = true
+		}
+	})
+
+	t.Run("Newest", func(t *testing.T) {
+		t.Parallel()
+
+		store, cleanupStore := test_util.BuildTestDBStore(t)
+		defer cleanupStore()
+
+		createSeedData(t, store)
+
+		ascProducts := listAllPages(t, store, "")
+		descProducts := listAllPages(t, store, "newest")
+		require.Len(t, descProducts, len(ascProducts))
+
+		for i := range ascProducts {
+			require.Equal(t, ascProducts[i].ID, descProducts[len(descProducts)-1-i].ID)
+		}
+	})
+}
+
+func createSearchSeedData(t *testing.T, store db.Store) test_util.SeedData {
+	ctx := context.Background()
+
+	user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+		Name:         "testuser",
+		Email:        "test@example.com",
+		Password:     "test-password",
+		SessionToken: token.NewToken(time.Minute),
+		RefreshToken: token.NewToken(time.Minute),
+	})
+
+	category, err := store.CreateCategory(ctx, "test-category")
+	require.NoError(t, err)
+
+	products := []struct {
+		name        string
+		description string
+	}{
+		{name: "Wooden table", description: "Goes well with any chair"},
+		{name: "Red wooden chair", description: "A sturdy chair for the dining room"},
+		{name: "Blue chair cushion", description: "Soft cushion"},
+		{name: "Folding chair", description: "Easy to store"},
+		{name: "Desk lamp", description: "Bright LED lamp"},
+	}
+
+	for _, product := range products {
+		_, err := store.CreateProduct(ctx, db.CreateProductParams{
+			Name:          product.name,
+			Description:   sql.NullString{String: product.description, Valid: true},
+			Price:         "10.00",
+			StockQuantity: 10,
+			CategoryID:    category.ID,
+			SellerID:      user.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	return test_util.SeedData{}
+}
+
+func TestListProductsBySeller(t *testing.T) {
+	pageSize := 5
+
+	sessionTokens := test_util.NewTokens(2, time.Minute)
+	refreshTokens := test_util.NewTokens(2, time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		var err error
+
+		ctx := context.Background()
+
+		users := make([]db.User, 2)
+		for i := range users {
+			users[i] = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+				Name:         fmt.Sprintf("testuser-%d", i),
+				Email:        fmt.Sprintf("test-%d@example.com", i),
+				Password:     "test-password",
+				SessionToken: sessionTokens[i],
+				RefreshToken: refreshTokens[i],
+			})
+		}
+
+		categories := make([]db.Category, 3)
+		for i := range categories {
+			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
+			require.NoError(t, err)
+		}
+
+		for i := 0; i < 6; i++ {
+			_, err = store.CreateProduct(ctx, db.CreateProductParams{
+				Name:          fmt.Sprintf("test-product-%d", i),
+				Description:   sql.NullString{String: fmt.Sprintf("test-description-%d", i), Valid: true},
+				Price:         fmt.Sprintf("%d.00", (i+1)*10),
+				StockQuantity: int32(i + 1),
+				CategoryID:    categories[i%3].ID,
+				SellerID:      users[i%2].ID,
+				ImageUrl:      sql.NullString{String: fmt.Sprintf("test-image-url-%d", i), Valid: true},
+			})
+			require.NoError(t, err)
+		}
+
+		return test_util.SeedData{
+			"users":      users,
+			"categories": categories,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		createQuery    func(t *testing.T, seedData test_util.SeedData) test_util.Query
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductsResponse(t, response.Body)
+
+				require.Equal(t, int32(1), *gotResponse.Meta.PageID)
+				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
+				require.Equal(t, int64(1), *gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), *gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, 3)
+
+				for i := 0; i < 3; i++ {
+					productIndex := i * 2
+					categoryIndex := productIndex % 3
+
+					require.NotEmpty(t, gotResponse.Data[i].ID)
+					require.Equal(t, fmt.Sprintf("test-product-%d", productIndex), gotResponse.Data[i].Name)
+					require.Equal(t, fmt.Sprintf("test-description-%d", productIndex), gotResponse.Data[i].Description.String)
+					require.True(t, decimal.NewFromInt(int64((productIndex+1)*10)).Equal(gotResponse.Data[i].Price.Decimal))
+					require.Equal(t, int32(productIndex+1), gotResponse.Data[i].StockQuantity)
+					require.Equal(t, fmt.Sprintf("test-category-%d", categoryIndex), gotResponse.Data[i].Category)
+					require.Equal(t, "testuser-0", gotResponse.Data[i].Seller)
+					require.Equal(t, fmt.Sprintf("test-image-url-%d", productIndex), gotResponse.Data[i].ImageUrl.String)
+				}
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			setupAuth: test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageIDLessThanLowerLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "0",
+					"page_size": fmt.Sprintf("%d", pageSize),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id": "1",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeLessThanLowerLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "0",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeMoreThanUpperLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "101",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionTokens[0].Hash(),
+					SessionTokenExpiredAt: sessionTokens[0].ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					ListProductsBySeller(gomock.Any(), gomock.Any()).
+					Return([]db.Product{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createQuery: func(t *testing.T, seedData test_util.SeedData) test_util.Query {
+				return test_util.Query{
+					"page_id":   "1",
+					"page_size": "1",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/users/products",
+				Query:  tc.createQuery(t, seedData),
+			})
+
+			tc.setupAuth(request, sessionTokens[0].Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+
+	}
+}
+
+func TestListProductCategories(t *testing.T) {
+	pageSize := 5
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		var err error
+
+		ctx := context.Background()
+
+		categories := make([]db.Category, 6)
+		for i := range categories {
+			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
+			require.NoError(t, err)
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		query          test_util.Query
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListProductCategoriesResponse(t, response.Body)
+
+				require.Equal(t, int32(1), gotResponse.Meta.PageID)
+				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
+
+				require.Len(t, gotResponse.Data, pageSize)
+
+				for i := 0; i < pageSize; i++ {
+					require.NotEmpty(t, gotResponse.Data[i].ID)
+					require.Equal(t, fmt.Sprintf("test-category-%d", i), gotResponse.Data[i].Name)
+				}
+			},
+		},
+		{
+			name:           "PageIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageIDLessThanLowerLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "0",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id": "1",
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeLessThanLowerLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": "0",
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageSizeMoreThanUpperLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": "101",
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalServerError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					ListCategories(gomock.Any(), gomock.Any()).
+					Return([]db.Category{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": "1",
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/products/categories",
+				Query:  tc.query,
+			})
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+
+	}
+}
+
+func TestProductHandlerAddProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		ctx := context.Background()
+
+		_ = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+			Role:         db.UserRoleSeller,
+		})
+
+		category, err := store.CreateCategory(ctx, "test-category")
+		require.NoError(t, err)
+
+		return test_util.SeedData{
+			"category": category,
+		}
+	}
+
+	defaultCreateBody := func(seedData test_util.SeedData) test_util.Body {
+		return test_util.Body{
+			"name":           "test-product",
+			"description":    "test-description",
+			"price":          "10.00",
+			"stock_quantity": 10,
+			"category_id":    seedData["category"].(db.Category).ID,
+			"image_url":      "https://example.com/image.png",
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		createBody     func(seedData test_util.SeedData) test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:           "OptionalFieldsNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "NameNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"description":    "test-description",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceInvalidFormat",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"price":          "Invalid Price",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceIsZero",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"price":          "0.00",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "ImageUrlInvalidFormat",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"category_id":    seedData["category"].(db.Category).ID,
+					"image_url":      "ttp://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "StockQuantityNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":        "test-product",
+					"description": "test-description",
+					"price":       "10.00",
+					"category_id": seedData["category"].(db.Category).ID,
+					"image_url":   "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "CategoryIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "Customer",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidRoleStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}, db.UserRoleCustomer)
+
+				mockStore.EXPECT().
+					AddProduct(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"category_id":    util.RandomUUID().String(),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidRoleStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}, db.UserRoleSeller)
+
+				mockStore.EXPECT().
+					AddProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product",
+					"description":    "test-description",
+					"price":          "10.00",
+					"stock_quantity": 10,
+					"category_id":    util.RandomUUID().String(),
+					"seller_id":      util.RandomUUID().String(),
+					"image_url":      "https://example.com/image.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/users/products",
+				Body:   tc.createBody(seedData),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestProductHandlerUpdateProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		var err error
+
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+			Role:         db.UserRoleSeller,
+		})
+
+		categories := make([]db.Category, 2)
+		for i := range categories {
+			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
+			require.NoError(t, err)
+		}
+
+		product, err := store.CreateProduct(ctx, db.CreateProductParams{
+			Name:          "test-product",
+			Description:   sql.NullString{String: "test-description", Valid: true},
+			Price:         "10.00",
+			StockQuantity: 10,
+			CategoryID:    categories[0].ID,
+			SellerID:      user.ID,
+			ImageUrl:      sql.NullString{String: "https://example.com/image.png", Valid: true},
+		})
+		require.NoError(t, err)
+
+		return test_util.SeedData{
+			"categories": categories,
+			"product":    product,
+		}
+	}
+
+	defaultCreateBody := func(seedData test_util.SeedData) test_util.Body {
+		return test_util.Body{
+			"name":           "test-product-updated",
+			"description":    "test-description-updated",
+			"price":          "20.00",
+			"stock_quantity": 20,
+			"category_id":    seedData["categories"].([]db.Category)[1].ID,
+			"image_url":      "https://example.com/image-updated.png",
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		createBody     func(seedData test_util.SeedData) test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:           "OptionalFieldsNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"price":          "20.00",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:       "ProductNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+				seedData["product"] = db.Product{ID: util.RandomUUID()}
+				return seedData
+			},
+			createBody: defaultCreateBody,
+			setupAuth:  test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:       "NotProductSeller",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				otherUser, err := store.CreateUser(context.Background(), db.CreateUserParams{
+					Name:           "otheruser",
+					Email:          "other@example.com",
+					HashedPassword: "other-hashed-password",
+				})
+				require.NoError(t, err)
+
+				product, err := store.CreateProduct(context.Background(), db.CreateProductParams{
+					Name:          "other-product",
+					Price:         "10.00",
+					StockQuantity: 10,
+					CategoryID:    seedData["categories"].([]db.Category)[0].ID,
+					SellerID:      otherUser.ID,
+				})
+				require.NoError(t, err)
+
+				seedData["product"] = product
+				return seedData
+			},
+			createBody: defaultCreateBody,
+			setupAuth:  test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name:           "NameNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"description":    "test-description-updated",
+					"price":          "20.00",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+					"image_url":      "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"description":    "test-description-updated",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+					"image_url":      "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceInvalidFormat",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"description":    "test-description-updated",
+					"price":          "Invalid Price",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+					"image_url":      "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "PriceIsZero",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"description":    "test-description-updated",
+					"price":          "0.00",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+					"image_url":      "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "ImageUrlInvalidFormat",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"description":    "test-description-updated",
+					"price":          "20.00",
+					"stock_quantity": 20,
+					"category_id":    seedData["categories"].([]db.Category)[1].ID,
+					"image_url":      "ttp://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "StockQuantityNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":        "test-product-updated",
+					"description": "test-description-updated",
+					"price":       "20.00",
+					"category_id": seedData["categories"].([]db.Category)[1].ID,
+					"image_url":   "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "CategoryIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"name":           "test-product-updated",
+					"description":    "test-description-updated",
+					"price":          "20.00",
+					"stock_quantity": 20,
+					"image_url":      "https://example.com/image-updated.png",
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		// TODO: Why does the following test fail when using a mock store?
+		// {
+		// 	name: "InternalError",
+		// 	buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+		// 		mockStore, cleanup := test_util.NewMockStore(t)
+
+		// 		test_util.BuildValidSessionStubs(mockStore, db.Session{
+		// 			ID:           util.RandomUUID(),
+		// 			UserID:       util.RandomUUID(),
+		// 			SessionTokenHash: sessionToken.Hash(),
+		// 			SessionTokenExpiredAt:    sessionToken.SessionTokenExpiredAt,
+		// 			CreatedAt:    time.Now(),
+		// 		})
+
+		// 		mockStore.EXPECT().
+		// 			UpdateProduct(gomock.Any(), gomock.Any()).
+		// 			Return(db.Product{}, sql.ErrConnDone)
+
+		// 		return mockStore, cleanup
+		// 	},
+		// 	createSeedData: test_util.NoopCreateAndReturnSeed,
+		// 	createBody: func(seedData test_util.SeedData) test_util.Body {
+		// 		return test_util.Body{
+		// 			"name":           "test-product-updated",
+		// 			"description":    "test-description-updated",
+		// 			"price":          "20.00",
+		// 			"stock_quantity": 20,
+		// 			"category_id":    util.RandomUUID().String(),
+		// 			"seller_id":      util.RandomUUID().String(),
+		// 			"image_url":      "https://example.com/image-updated.png",
+		// 		}
+		// 	},
+		// 	setupAuth: test_util.AddSessionTokenInCookie,
+		// 	checkResponse: func(t *testing.T, response *http.Response) {
+		// 		require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+		// 	},
+		// },
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPut,
+				URL:    fmt.Sprintf("/api/v1/users/products/%s", seedData["product"].(db.Product).ID),
+				Body:   tc.createBody(seedData),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
 }
 
-func TestProductHandlerAddProduct(t *testing.T) {
+func TestProductHandlerDeleteProduct(t *testing.T) {
 	sessionToken := token.NewToken(time.Minute)
 	refreshToken := token.NewToken(time.Minute)
 
 	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
 		ctx := context.Background()
 
-		_ = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
 			Name:         "testuser",
 			Email:        "test@example.com",
 			Password:     "test-password",
 			SessionToken: sessionToken,
 			RefreshToken: refreshToken,
+			Role:         db.UserRoleSeller,
 		})
 
 		category, err := store.CreateCategory(ctx, "test-category")
 		require.NoError(t, err)
 
+		product, err := store.CreateProduct(ctx, db.CreateProductParams{
+			Name:          "test-product",
+			Description:   sql.NullString{String: "test-description", Valid: true},
+			Price:         "10.00",
+			StockQuantity: 10,
+			CategoryID:    category.ID,
+			SellerID:      user.ID,
+			ImageUrl:      sql.NullString{String: "https://example.com/image.png", Valid: true},
+		})
+		require.NoError(t, err)
+
+		_, err = store.CreateCartProduct(ctx, db.CreateCartProductParams{
+			UserID:    user.ID,
+			ProductID: product.ID,
+			Quantity:  1,
+		})
+		require.NoError(t, err)
+
 		return test_util.SeedData{
+			"user":     user,
 			"category": category,
-		}
-	}
-
-	defaultCreateBody := func(seedData test_util.SeedData) test_util.Body {
-		return test_util.Body{
-			"name":           "test-product",
-			"description":    "test-description",
-			"price":          "10.00",
-			"stock_quantity": 10,
-			"category_id":    seedData["category"].(db.Category).ID,
-			"image_url":      "https://example.com/image.png",
+			"product":  product,
 		}
 	}
 
@@ -809,208 +2201,329 @@ func TestProductHandlerAddProduct(t *testing.T) {
 		name           string
 		buildStore     func(t *testing.T) (store db.Store, cleanup func())
 		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
-		createBody     func(seedData test_util.SeedData) test_util.Body
 		setupAuth      func(request *http.Request, sessionToken string)
-		checkResponse  func(t *testing.T, response *http.Response)
+		checkResponse  func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData)
 	}{
 		{
 			name:           "OK",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
-			createBody:     defaultCreateBody,
 			setupAuth:      test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusOK, response.StatusCode)
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				requireNoContent(t, response)
+
+				_, err := store.GetProduct(context.Background(), seedData["product"].(db.Product).ID)
+				require.ErrorIs(t, err, sql.ErrNoRows)
+
+				cartProducts, err := store.GetCartProductsByUserID(context.Background(), seedData["user"].(db.User).ID)
+				require.NoError(t, err)
+				require.Empty(t, cartProducts)
 			},
 		},
 		{
-			name:           "OptionalFieldsNotFound",
+			name:           "NoAuthorization",
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"price":          "10.00",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-				}
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:       "ProductNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+				seedData["product"] = db.Product{ID: util.RandomUUID()}
+				return seedData
 			},
 			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusOK, response.StatusCode)
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
 			},
 		},
 		{
-			name:           "NoAuthorization",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody:     defaultCreateBody,
-			setupAuth:      test_util.NoopSetupAuth,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			name:       "AlreadyDeleted",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				err := store.SoftDeleteProduct(context.Background(), seedData["product"].(db.Product).ID)
+				require.NoError(t, err)
+
+				return seedData
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
 			},
 		},
 		{
-			name:           "NameNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"description":    "test-description",
-					"price":          "10.00",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-					"image_url":      "https://example.com/image.png",
-				}
+			name:       "NotProductSeller",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				otherUser, err := store.CreateUser(context.Background(), db.CreateUserParams{
+					Name:           "otheruser",
+					Email:          "other@example.com",
+					HashedPassword: "other-hashed-password",
+				})
+				require.NoError(t, err)
+
+				product, err := store.CreateProduct(context.Background(), db.CreateProductParams{
+					Name:          "other-product",
+					Price:         "10.00",
+					StockQuantity: 10,
+					CategoryID:    seedData["category"].(db.Category).ID,
+					SellerID:      otherUser.ID,
+				})
+				require.NoError(t, err)
+
+				seedData["product"] = product
+				return seedData
 			},
 			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+
+				_, err := store.GetProduct(context.Background(), seedData["product"].(db.Product).ID)
+				require.NoError(t, err)
 			},
 		},
 		{
-			name:           "PriceNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-					"image_url":      "https://example.com/image.png",
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				userID := util.RandomUUID()
+
+				test_util.BuildValidRoleStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                userID,
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}, db.UserRoleSeller)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{SellerID: userID}, nil)
+
+				mockStore.EXPECT().
+					SoftDeleteProduct(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product": db.Product{ID: util.RandomUUID()},
 				}
 			},
 			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
 			},
 		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/users/products/%s", seedData["product"].(db.Product).ID),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, store, response, seedData)
+		})
+	}
+}
+
+func unmarshalProductResponse(t *testing.T, body io.ReadCloser) product_domain.ProductResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed product_domain.ProductResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalListProductsResponse(t *testing.T, body io.ReadCloser) product_domain.ListProductsResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed product_domain.ListProductsResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalListProductCategoriesResponse(t *testing.T, body io.ReadCloser) product_domain.ListProductCategoriesResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed product_domain.ListProductCategoriesResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func TestCreateProductCategory(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	parentID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		body          test_util.Body
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
 		{
-			name:           "PriceInvalidFormat",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"price":          "Invalid Price",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-					"image_url":      "https://example.com/image.png",
-				}
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Eq(db.CreateCategoryWithParentParams{Name: "test-category"})).
+					Return(db.Category{ID: util.RandomUUID(), Name: "test-category"}, nil)
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category"},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+
+				var gotResponse product_domain.ProductCategoryResponse
+				require.NoError(t, json.Unmarshal(data, &gotResponse))
+				require.NotEmpty(t, gotResponse.ID)
+				require.Equal(t, "test-category", gotResponse.Name)
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+		},
+		{
+			name: "WithParent",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parentID)).
+					Return(db.Category{ID: parentID, Name: "parent"}, nil)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Eq(db.CreateCategoryWithParentParams{
+						Name:     "test-category",
+						ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+					})).
+					Return(db.Category{
+						ID:       util.RandomUUID(),
+						Name:     "test-category",
+						ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+					}, nil)
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "test-category", "parent_id": parentID.String()},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+
+				var gotResponse product_domain.ProductCategoryResponse
+				require.NoError(t, json.Unmarshal(data, &gotResponse))
+				require.Equal(t, uuid.NullUUID{UUID: parentID, Valid: true}, gotResponse.ParentID)
 			},
 		},
 		{
-			name:           "PriceIsZero",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"price":          "0.00",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-					"image_url":      "https://example.com/image.png",
-				}
+			name: "ParentNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parentID)).
+					Return(db.Category{}, sql.ErrNoRows)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "test-category", "parent_id": parentID.String()},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "ImageUrlInvalidFormat",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"price":          "10.00",
-					"stock_quantity": 10,
-					"category_id":    seedData["category"].(db.Category).ID,
-					"image_url":      "ttp://example.com/image.png",
-				}
+			name: "AlreadyExists",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					CreateCategoryWithParent(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, &pq.Error{Code: "23505"})
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "test-category"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusConflict, response.StatusCode)
 			},
 		},
 		{
-			name:           "StockQuantityNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":        "test-product",
-					"description": "test-description",
-					"price":       "10.00",
-					"category_id": seedData["category"].(db.Category).ID,
-					"image_url":   "https://example.com/image.png",
-				}
+			name: "NameNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "CategoryIDNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"price":          "10.00",
-					"stock_quantity": 10,
-					"image_url":      "https://example.com/image.png",
-				}
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "test-category"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
 			},
 		},
 		{
 			name: "InternalError",
 			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
-				mockStore, cleanup := test_util.NewMockStore(t)
-
-				test_util.BuildValidSessionStubs(mockStore, db.Session{
-					ID:                    util.RandomUUID(),
-					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
-					SessionTokenExpiredAt: sessionToken.ExpiredAt,
-					CreatedAt:             time.Now(),
-				})
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
 
 				mockStore.EXPECT().
-					AddProduct(gomock.Any(), gomock.Any()).
-					Return(db.Product{}, sql.ErrConnDone)
+					CreateCategoryWithParent(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, sql.ErrConnDone)
 
 				return mockStore, cleanup
 			},
-			createSeedData: test_util.NoopCreateAndReturnSeed,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product",
-					"description":    "test-description",
-					"price":          "10.00",
-					"stock_quantity": 10,
-					"category_id":    util.RandomUUID().String(),
-					"seller_id":      util.RandomUUID().String(),
-					"image_url":      "https://example.com/image.png",
-				}
-			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "test-category"},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
 			},
@@ -1026,15 +2539,13 @@ func TestProductHandlerAddProduct(t *testing.T) {
 			store, cleanupStore := tc.buildStore(t)
 			defer cleanupStore()
 
-			seedData := tc.createSeedData(t, store)
-
 			request := test_util.NewRequest(t, test_util.RequestParams{
 				Method: http.MethodPost,
-				URL:    "/api/v1/users/products",
-				Body:   tc.createBody(seedData),
+				URL:    "/api/v1/products/categories",
+				Body:   tc.body,
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -1043,268 +2554,312 @@ func TestProductHandlerAddProduct(t *testing.T) {
 	}
 }
 
-func TestProductHandlerUpdateProduct(t *testing.T) {
+func TestDeleteProductCategory(t *testing.T) {
 	sessionToken := token.NewToken(time.Minute)
-	refreshToken := token.NewToken(time.Minute)
+	categoryID := util.RandomUUID()
 
-	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
-		var err error
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
 
-		ctx := context.Background()
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID}, nil)
 
-		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
-			Name:         "testuser",
-			Email:        "test@example.com",
-			Password:     "test-password",
-			SessionToken: sessionToken,
-			RefreshToken: refreshToken,
-		})
+				mockStore.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(nil)
 
-		categories := make([]db.Category, 2)
-		for i := range categories {
-			categories[i], err = store.CreateCategory(ctx, fmt.Sprintf("test-category-%d", i))
-			require.NoError(t, err)
-		}
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+			},
+		},
+		{
+			name: "CategoryNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
 
-		product, err := store.CreateProduct(ctx, db.CreateProductParams{
-			Name:          "test-product",
-			Description:   sql.NullString{String: "test-description", Valid: true},
-			Price:         "10.00",
-			StockQuantity: 10,
-			CategoryID:    categories[0].ID,
-			SellerID:      user.ID,
-			ImageUrl:      sql.NullString{String: "https://example.com/image.png", Valid: true},
-		})
-		require.NoError(t, err)
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, sql.ErrNoRows)
 
-		return test_util.SeedData{
-			"categories": categories,
-			"product":    product,
-		}
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "CategoryInUse",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Any()).
+					Return(db.Category{ID: categoryID}, nil)
+
+				mockStore.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Any()).
+					Return(&pq.Error{Code: "23503"})
+
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleCustomer)
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
 	}
 
-	defaultCreateBody := func(seedData test_util.SeedData) test_util.Body {
-		return test_util.Body{
-			"name":           "test-product-updated",
-			"description":    "test-description-updated",
-			"price":          "20.00",
-			"stock_quantity": 20,
-			"category_id":    seedData["categories"].([]db.Category)[1].ID,
-			"image_url":      "https://example.com/image-updated.png",
-		}
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/products/categories/%s", categoryID),
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
 	}
+}
+
+func TestUpdateProductCategory(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	categoryID := util.RandomUUID()
+	parentID := util.RandomUUID()
 
 	testCases := []struct {
-		name           string
-		buildStore     func(t *testing.T) (store db.Store, cleanup func())
-		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
-		createBody     func(seedData test_util.SeedData) test_util.Body
-		setupAuth      func(request *http.Request, sessionToken string)
-		checkResponse  func(t *testing.T, response *http.Response)
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		body          test_util.Body
+		checkResponse func(t *testing.T, response *http.Response)
 	}{
 		{
-			name:           "OK",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody:     defaultCreateBody,
-			setupAuth:      test_util.AddSessionTokenInCookie,
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parentID)).
+					Return(db.Category{ID: parentID, Name: "parent"}, nil)
+
+				mockStore.EXPECT().
+					GetCategoryDescendantIDs(gomock.Any(), gomock.Eq(categoryID)).
+					Return([]uuid.UUID{categoryID}, nil)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(db.UpdateCategoryParams{
+						ID:       categoryID,
+						Name:     "new-name",
+						ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+					})).
+					Return(db.Category{
+						ID:       categoryID,
+						Name:     "new-name",
+						ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+					}, nil)
+
+				return mockStore, cleanup
+			},
+			body: test_util.Body{"name": "new-name", "parent_id": parentID.String()},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+
+				var gotResponse product_domain.ProductCategoryResponse
+				require.NoError(t, json.Unmarshal(data, &gotResponse))
+				require.Equal(t, categoryID, gotResponse.ID)
+				require.Equal(t, "new-name", gotResponse.Name)
+				require.Equal(t, uuid.NullUUID{UUID: parentID, Valid: true}, gotResponse.ParentID)
 			},
 		},
 		{
-			name:           "OptionalFieldsNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"price":          "20.00",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-				}
+			name: "MoveToRoot",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					GetCategoryDescendantIDs(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Eq(db.UpdateCategoryParams{
+						ID:   categoryID,
+						Name: "new-name",
+					})).
+					Return(db.Category{ID: categoryID, Name: "new-name"}, nil)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name"},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
 			},
 		},
 		{
-			name:           "NoAuthorization",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody:     defaultCreateBody,
-			setupAuth:      test_util.NoopSetupAuth,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
-			},
-		},
-		{
-			name:           "NameNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"description":    "test-description-updated",
-					"price":          "20.00",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-					"image_url":      "https://example.com/image-updated.png",
-				}
+			name: "Cycle",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parentID)).
+					Return(db.Category{ID: parentID, Name: "child", ParentID: uuid.NullUUID{UUID: categoryID, Valid: true}}, nil)
+
+				mockStore.EXPECT().
+					GetCategoryDescendantIDs(gomock.Any(), gomock.Eq(categoryID)).
+					Return([]uuid.UUID{categoryID, parentID}, nil)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name", "parent_id": parentID.String()},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PriceNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"description":    "test-description-updated",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-					"image_url":      "https://example.com/image-updated.png",
-				}
+			name: "ParentNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(parentID)).
+					Return(db.Category{}, sql.ErrNoRows)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name", "parent_id": parentID.String()},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "PriceInvalidFormat",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"description":    "test-description-updated",
-					"price":          "Invalid Price",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-					"image_url":      "https://example.com/image-updated.png",
-				}
+			name: "CategoryNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
 			},
 		},
 		{
-			name:           "PriceIsZero",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"description":    "test-description-updated",
-					"price":          "0.00",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-					"image_url":      "https://example.com/image-updated.png",
-				}
+			name: "AlreadyExists",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{ID: categoryID, Name: "old-name"}, nil)
+
+				mockStore.EXPECT().
+					UpdateCategory(gomock.Any(), gomock.Any()).
+					Return(db.Category{}, &pq.Error{Code: "23505"})
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusConflict, response.StatusCode)
 			},
 		},
 		{
-			name:           "ImageUrlInvalidFormat",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"description":    "test-description-updated",
-					"price":          "20.00",
-					"stock_quantity": 20,
-					"category_id":    seedData["categories"].([]db.Category)[1].ID,
-					"image_url":      "ttp://example.com/image-updated.png",
-				}
+			name: "NameNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusBadRequest, response.StatusCode)
 			},
 		},
 		{
-			name:           "StockQuantityNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":        "test-product-updated",
-					"description": "test-description-updated",
-					"price":       "20.00",
-					"category_id": seedData["categories"].([]db.Category)[1].ID,
-					"image_url":   "https://example.com/image-updated.png",
-				}
+			name: "NotAdmin",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleSeller)
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
 			},
 		},
 		{
-			name:           "CategoryIDNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			createBody: func(seedData test_util.SeedData) test_util.Body {
-				return test_util.Body{
-					"name":           "test-product-updated",
-					"description":    "test-description-updated",
-					"price":          "20.00",
-					"stock_quantity": 20,
-					"image_url":      "https://example.com/image-updated.png",
-				}
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, _, cleanup := buildAdminMockStore(t, sessionToken, db.UserRoleAdmin)
+
+				mockStore.EXPECT().
+					GetCategory(gomock.Any(), gomock.Eq(categoryID)).
+					Return(db.Category{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
 			},
-			setupAuth: test_util.AddSessionTokenInCookie,
+			body: test_util.Body{"name": "new-name"},
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
 			},
 		},
-		// TODO: Why does the following test fail when using a mock store?
-		// {
-		// 	name: "InternalError",
-		// 	buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
-		// 		mockStore, cleanup := test_util.NewMockStore(t)
-
-		// 		test_util.BuildValidSessionStubs(mockStore, db.Session{
-		// 			ID:           util.RandomUUID(),
-		// 			UserID:       util.RandomUUID(),
-		// 			SessionToken: sessionToken.ID,
-		// 			SessionTokenExpiredAt:    sessionToken.SessionTokenExpiredAt,
-		// 			CreatedAt:    time.Now(),
-		// 		})
-
-		// 		mockStore.EXPECT().
-		// 			UpdateProduct(gomock.Any(), gomock.Any()).
-		// 			Return(db.Product{}, sql.ErrConnDone)
-
-		// 		return mockStore, cleanup
-		// 	},
-		// 	createSeedData: test_util.NoopCreateAndReturnSeed,
-		// 	createBody: func(seedData test_util.SeedData) test_util.Body {
-		// 		return test_util.Body{
-		// 			"name":           "test-product-updated",
-		// 			"description":    "test-description-updated",
-		// 			"price":          "20.00",
-		// 			"stock_quantity": 20,
-		// 			"category_id":    util.RandomUUID().String(),
-		// 			"seller_id":      util.RandomUUID().String(),
-		// 			"image_url":      "https://example.com/image-updated.png",
-		// 		}
-		// 	},
-		// 	setupAuth: test_util.AddSessionTokenInCookie,
-		// 	checkResponse: func(t *testing.T, response *http.Response) {
-		// 		require.Equal(t, http.StatusInternalServerError, response.StatusCode)
-		// 	},
-		// },
 	}
 
 	for i := range testCases {
@@ -1316,15 +2871,13 @@ func TestProductHandlerUpdateProduct(t *testing.T) {
 			store, cleanupStore := tc.buildStore(t)
 			defer cleanupStore()
 
-			seedData := tc.createSeedData(t, store)
-
 			request := test_util.NewRequest(t, test_util.RequestParams{
-				Method: http.MethodPut,
-				URL:    fmt.Sprintf("/api/v1/users/products/%s", seedData["product"].(db.Product).ID),
-				Body:   tc.createBody(seedData),
+				Method: http.MethodPatch,
+				URL:    fmt.Sprintf("/api/v1/products/categories/%s", categoryID),
+				Body:   tc.body,
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -1333,35 +2886,83 @@ func TestProductHandlerUpdateProduct(t *testing.T) {
 	}
 }
 
-func unmarshalProductResponse(t *testing.T, body io.ReadCloser) product_domain.ProductResponse {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
+func TestGetProductCategoryTree(t *testing.T) {
+	rootID := util.RandomUUID()
+	childID := util.RandomUUID()
+	grandchildID := util.RandomUUID()
 
-	var parsed product_domain.ProductResponse
-	err = json.Unmarshal(data, &parsed)
-	require.NoError(t, err)
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-	return parsed
-}
+				mockStore.EXPECT().
+					ListAllCategories(gomock.Any()).
+					Return([]db.Category{
+						{ID: childID, Name: "Child", ParentID: uuid.NullUUID{UUID: rootID, Valid: true}},
+						{ID: grandchildID, Name: "Grandchild", ParentID: uuid.NullUUID{UUID: childID, Valid: true}},
+						{ID: rootID, Name: "Root"},
+					}, nil)
 
-func unmarshalListProductsResponse(t *testing.T, body io.ReadCloser) product_domain.ListProductsResponse {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
 
-	var parsed product_domain.ListProductsResponse
-	err = json.Unmarshal(data, &parsed)
-	require.NoError(t, err)
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
 
-	return parsed
-}
+				var gotResponse []product_domain.CategoryTreeResponse
+				require.NoError(t, json.Unmarshal(data, &gotResponse))
 
-func unmarshalListProductCategoriesResponse(t *testing.T, body io.ReadCloser) product_domain.ListProductCategoriesResponse {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
+				require.Len(t, gotResponse, 1)
+				require.Equal(t, rootID, gotResponse[0].ID)
+				require.Len(t, gotResponse[0].Children, 1)
+				require.Equal(t, childID, gotResponse[0].Children[0].ID)
+				require.Len(t, gotResponse[0].Children[0].Children, 1)
+				require.Equal(t, grandchildID, gotResponse[0].Children[0].Children[0].ID)
+				require.Empty(t, gotResponse[0].Children[0].Children[0].Children)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
 
-	var parsed product_domain.ListProductCategoriesResponse
-	err = json.Unmarshal(data, &parsed)
-	require.NoError(t, err)
+				mockStore.EXPECT().
+					ListAllCategories(gomock.Any()).
+					Return(nil, sql.ErrConnDone)
 
-	return parsed
+				return mockStore, cleanup
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/products/categories/tree",
+			})
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
 }
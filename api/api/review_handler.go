@@ -0,0 +1,199 @@
+package api
+
+import (
+	"database/sql"
+	"math"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
+	review_domain "github.com/ot07/next-bazaar/api/domain/review"
+	"github.com/ot07/next-bazaar/api/validation"
+)
+
+type reviewHandler struct {
+	service *review_domain.ReviewService
+}
+
+func newReviewHandler(s *review_domain.ReviewService) *reviewHandler {
+	return &reviewHandler{
+		service: s,
+	}
+}
+
+// @Summary      List product reviews
+// @Tags         Reviews
+// @Param        id path string true "Product ID"
+// @Param        query query review_domain.ListReviewsRequestQuery true "query"
+// @Success      200 {object} review_domain.ListReviewsResponse
+// @Failure      400 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/{id}/reviews [get]
+func (h *reviewHandler) listReviews(c *fiber.Ctx) error {
+	reqParams := new(review_domain.ListReviewsRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqQuery := new(review_domain.ListReviewsRequestQuery)
+	if err := c.QueryParser(reqQuery); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqQuery); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reviews, err := h.service.GetReviews(c.UserContext(), review_domain.GetReviewsServiceParams{
+		ProductID: reqParams.ProductID,
+		PageID:    reqQuery.PageID,
+		PageSize:  reqQuery.PageSize,
+	})
+	if err != nil {
+		return err
+	}
+
+	totalCount, err := h.service.CountReviews(c.UserContext(), reqParams.ProductID)
+	if err != nil {
+		return err
+	}
+
+	pageCount := int64(math.Ceil(float64(totalCount) / float64(reqQuery.PageSize)))
+
+	rsp := review_domain.ListReviewsResponse{
+		Meta: review_domain.ListReviewsResponseMeta{
+			PageID:     reqQuery.PageID,
+			PageSize:   reqQuery.PageSize,
+			PageCount:  pageCount,
+			TotalCount: totalCount,
+		},
+		Data: review_domain.NewReviewsResponse(reviews),
+	}
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Create product review
+// @Tags         Reviews
+// @Param        id path string true "Product ID"
+// @Param        body body review_domain.CreateReviewRequestBody true "Review object"
+// @Success      200 {object} review_domain.ReviewResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      403 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/{id}/reviews [post]
+func (h *reviewHandler) createReview(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	reqParams := new(review_domain.CreateReviewRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(review_domain.CreateReviewRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	review, err := h.service.CreateReview(c.UserContext(), review_domain.CreateReviewServiceParams{
+		ProductID: reqParams.ProductID,
+		UserID:    session.UserID,
+		Rating:    reqBody.Rating,
+		Title:     reqBody.Title,
+		Body:      sql.NullString{String: reqBody.Body, Valid: len(reqBody.Body) > 0},
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := review_domain.NewReviewResponse(review)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Update product review
+// @Tags         Reviews
+// @Param        id path string true "Product ID"
+// @Param        body body review_domain.UpdateReviewRequestBody true "Review object"
+// @Success      200 {object} review_domain.ReviewResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/{id}/reviews [patch]
+func (h *reviewHandler) updateReview(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	reqParams := new(review_domain.UpdateReviewRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(review_domain.UpdateReviewRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	review, err := h.service.UpdateReview(c.UserContext(), review_domain.UpdateReviewServiceParams{
+		ProductID: reqParams.ProductID,
+		UserID:    session.UserID,
+		Rating:    reqBody.Rating,
+		Title:     reqBody.Title,
+		Body:      sql.NullString{String: reqBody.Body, Valid: len(reqBody.Body) > 0},
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := review_domain.NewReviewResponse(review)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Delete product review
+// @Tags         Reviews
+// @Param        id path string true "Product ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /products/{id}/reviews [delete]
+func (h *reviewHandler) deleteReview(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(review_domain.DeleteReviewRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.DeleteReview(c.UserContext(), review_domain.DeleteReviewServiceParams{
+		ProductID: req.ProductID,
+		UserID:    session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,703 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	review_domain "github.com/ot07/next-bazaar/api/domain/review"
+	"github.com/ot07/next-bazaar/api/test_util"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// createReviewSeedData creates a product sold by a seller and a reviewer who owns the session.
+func createReviewSeedData(t *testing.T, store db.Store, sessionToken, refreshToken *token.Token) test_util.SeedData {
+	ctx := context.Background()
+
+	seller, err := store.CreateUser(ctx, db.CreateUserParams{
+		Name:           "testseller",
+		Email:          "seller@example.com",
+		HashedPassword: "test-hashed-password",
+	})
+	require.NoError(t, err)
+
+	reviewer := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+		Name:         "testuser",
+		Email:        "test@example.com",
+		Password:     "test-password",
+		SessionToken: sessionToken,
+		RefreshToken: refreshToken,
+	})
+
+	category, err := store.CreateCategory(ctx, "test-category")
+	require.NoError(t, err)
+
+	product, err := store.CreateProduct(ctx, db.CreateProductParams{
+		Name:          "test-product",
+		Price:         "100.00",
+		StockQuantity: 10,
+		CategoryID:    category.ID,
+		SellerID:      seller.ID,
+	})
+	require.NoError(t, err)
+
+	return test_util.SeedData{
+		"seller":     seller,
+		"reviewer":   reviewer,
+		"category":   category,
+		"product_id": product.ID.String(),
+	}
+}
+
+func TestListReviewsAPI(t *testing.T) {
+	pageSize := 2
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		ctx := context.Background()
+
+		seedData := createReviewSeedData(t, store, token.NewToken(time.Minute), token.NewToken(time.Minute))
+		productID := uuid.MustParse(seedData["product_id"].(string))
+
+		for i := 0; i < 3; i++ {
+			user, err := store.CreateUser(ctx, db.CreateUserParams{
+				Name:           fmt.Sprintf("testreviewer-%d", i),
+				Email:          fmt.Sprintf("reviewer-%d@example.com", i),
+				HashedPassword: "test-hashed-password",
+			})
+			require.NoError(t, err)
+
+			_, err = store.CreateReview(ctx, db.CreateReviewParams{
+				ProductID: productID,
+				UserID:    user.ID,
+				Rating:    int32(i + 3),
+				Title:     fmt.Sprintf("test-title-%d", i),
+				Body:      sql.NullString{String: fmt.Sprintf("test-body-%d", i), Valid: true},
+			})
+			require.NoError(t, err)
+		}
+
+		return seedData
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		query          test_util.Query
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalListReviewsResponse(t, response.Body)
+
+				require.Equal(t, int32(1), gotResponse.Meta.PageID)
+				require.Equal(t, int32(pageSize), gotResponse.Meta.PageSize)
+				require.Equal(t, int64(2), gotResponse.Meta.PageCount)
+				require.Equal(t, int64(3), gotResponse.Meta.TotalCount)
+
+				require.Len(t, gotResponse.Data, pageSize)
+				for _, review := range gotResponse.Data {
+					require.NotEmpty(t, review.Reviewer)
+					require.GreaterOrEqual(t, review.Rating, int32(3))
+				}
+			},
+		},
+		{
+			name:       "ProductNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "PageIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			query: test_util.Query{
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, nil)
+
+				mockStore.EXPECT().
+					ListReviewsByProductID(gomock.Any(), gomock.Any()).
+					Return([]db.Review{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			query: test_util.Query{
+				"page_id":   "1",
+				"page_size": fmt.Sprintf("%d", pageSize),
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    fmt.Sprintf("/api/v1/products/%s/reviews", seedData["product_id"].(string)),
+				Query:  tc.query,
+			})
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestCreateReviewAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		return createReviewSeedData(t, store, sessionToken, refreshToken)
+	}
+
+	defaultBody := test_util.Body{
+		"rating": 4,
+		"title":  "test-title",
+		"body":   "test-body",
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		body           test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalReviewResponse(t, response.Body)
+
+				require.NotEmpty(t, gotResponse.ID)
+				require.Equal(t, seedData["product_id"].(string), gotResponse.ProductID.String())
+				require.Equal(t, "testuser", gotResponse.Reviewer)
+				require.Equal(t, int32(4), gotResponse.Rating)
+				require.Equal(t, "test-title", gotResponse.Title)
+				require.Equal(t, "test-body", gotResponse.Body.String)
+			},
+		},
+		{
+			name:       "AlreadyReviewed",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				productID := uuid.MustParse(seedData["product_id"].(string))
+
+				_, err := store.CreateReview(context.Background(), db.CreateReviewParams{
+					ProductID: productID,
+					UserID:    seedData["reviewer"].(db.User).ID,
+					Rating:    5,
+					Title:     "test-title",
+				})
+				require.NoError(t, err)
+
+				return seedData
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name:       "OwnProduct",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				product, err := store.CreateProduct(context.Background(), db.CreateProductParams{
+					Name:          "own-product",
+					Price:         "100.00",
+					StockQuantity: 10,
+					CategoryID:    seedData["category"].(db.Category).ID,
+					SellerID:      seedData["reviewer"].(db.User).ID,
+				})
+				require.NoError(t, err)
+
+				seedData["product_id"] = product.ID.String()
+				return seedData
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusForbidden, response.StatusCode)
+			},
+		},
+		{
+			name:       "ProductNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+				seedData["product_id"] = util.RandomUUID().String()
+				return seedData
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "RatingMoreThanUpperLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"rating": 6,
+				"title":  "test-title",
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "RatingIsZero",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"rating": 0,
+				"title":  "test-title",
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "TitleNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"rating": 4,
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{SellerID: util.RandomUUID()}, nil)
+
+				mockStore.EXPECT().
+					CreateReview(gomock.Any(), gomock.Any()).
+					Return(db.Review{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    fmt.Sprintf("/api/v1/products/%s/reviews", seedData["product_id"].(string)),
+				Body:   tc.body,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response, seedData)
+		})
+	}
+}
+
+func TestUpdateReviewAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		seedData := createReviewSeedData(t, store, sessionToken, refreshToken)
+
+		productID := uuid.MustParse(seedData["product_id"].(string))
+
+		_, err := store.CreateReview(context.Background(), db.CreateReviewParams{
+			ProductID: productID,
+			UserID:    seedData["reviewer"].(db.User).ID,
+			Rating:    2,
+			Title:     "test-title",
+			Body:      sql.NullString{String: "test-body", Valid: true},
+		})
+		require.NoError(t, err)
+
+		return seedData
+	}
+
+	defaultBody := test_util.Body{
+		"rating": 5,
+		"title":  "test-title-updated",
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		body           test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalReviewResponse(t, response.Body)
+
+				require.Equal(t, int32(5), gotResponse.Rating)
+				require.Equal(t, "test-title-updated", gotResponse.Title)
+				require.False(t, gotResponse.Body.Valid)
+			},
+		},
+		{
+			name:       "ReviewNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createReviewSeedData(t, store, sessionToken, refreshToken)
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "RatingMoreThanUpperLimit",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"rating": 6,
+				"title":  "test-title-updated",
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					UpdateReview(gomock.Any(), gomock.Any()).
+					Return(db.Review{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			body:      defaultBody,
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPatch,
+				URL:    fmt.Sprintf("/api/v1/products/%s/reviews", seedData["product_id"].(string)),
+				Body:   tc.body,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestDeleteReviewAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		seedData := createReviewSeedData(t, store, sessionToken, refreshToken)
+
+		productID := uuid.MustParse(seedData["product_id"].(string))
+
+		_, err := store.CreateReview(context.Background(), db.CreateReviewParams{
+			ProductID: productID,
+			UserID:    seedData["reviewer"].(db.User).ID,
+			Rating:    3,
+			Title:     "test-title",
+		})
+		require.NoError(t, err)
+
+		return seedData
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+
+				productID := uuid.MustParse(seedData["product_id"].(string))
+
+				count, err := store.CountReviewsByProductID(context.Background(), productID)
+				require.NoError(t, err)
+				require.Zero(t, count)
+			},
+		},
+		{
+			name:       "ReviewNotFound",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createReviewSeedData(t, store, sessionToken, refreshToken)
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetReviewByProductIDAndUserID(gomock.Any(), gomock.Any()).
+					Return(db.Review{}, nil)
+
+				mockStore.EXPECT().
+					DeleteReview(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/products/%s/reviews", seedData["product_id"].(string)),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, store, response, seedData)
+		})
+	}
+}
+
+func unmarshalReviewResponse(t *testing.T, body io.ReadCloser) review_domain.ReviewResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed review_domain.ReviewResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalListReviewsResponse(t *testing.T, body io.ReadCloser) review_domain.ListReviewsResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed review_domain.ListReviewsResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
@@ -1,71 +1,161 @@
 package api
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/swagger"
+	"github.com/google/uuid"
 	cart_domain "github.com/ot07/next-bazaar/api/domain/cart"
+	order_domain "github.com/ot07/next-bazaar/api/domain/order"
 	product_domain "github.com/ot07/next-bazaar/api/domain/product"
+	review_domain "github.com/ot07/next-bazaar/api/domain/review"
 	user_domain "github.com/ot07/next-bazaar/api/domain/user"
+	wishlist_domain "github.com/ot07/next-bazaar/api/domain/wishlist"
+	"github.com/ot07/next-bazaar/api/validation"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+	"github.com/ot07/next-bazaar/metrics"
+	"github.com/ot07/next-bazaar/ratelimit"
+	"github.com/ot07/next-bazaar/token"
 	"github.com/ot07/next-bazaar/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
 )
 
 type handlers struct {
-	user    *userHandler
-	product *productHandler
-	cart    *cartHandler
+	user     *userHandler
+	product  *productHandler
+	cart     *cartHandler
+	order    *orderHandler
+	review   *reviewHandler
+	wishlist *wishlistHandler
+	admin    *adminHandler
 }
 
-func newHandlers(config util.Config, store db.Store) handlers {
+func newHandlers(config util.Config, store db.Store, accessTokenMaker token.Maker, m *metrics.Metrics) handlers {
 	/* User */
 	userService := user_domain.NewUserService(store)
-	userHandler := newUserHandler(userService, config)
+	userHandler := newUserHandler(userService, config, accessTokenMaker, m)
 
 	/* Product */
 	productService := product_domain.NewProductService(store)
-	productHandler := newProductHandler(productService)
+	productHandler := newProductHandler(productService, m)
 
 	/* Cart */
 	cartService := cart_domain.NewCartService(store)
-	cartHandler := newCartHandler(cartService)
+	cartHandler := newCartHandler(cartService, m)
+
+	/* Order */
+	orderService := order_domain.NewOrderService(store)
+	orderHandler := newOrderHandler(orderService)
+
+	/* Review */
+	reviewService := review_domain.NewReviewService(store)
+	reviewHandler := newReviewHandler(reviewService)
+
+	/* Wishlist */
+	wishlistService := wishlist_domain.NewWishlistService(store)
+	wishlistHandler := newWishlistHandler(wishlistService)
+
+	/* Admin */
+	adminHandler := newAdminHandler(userService, productService)
 
 	return handlers{
-		user:    userHandler,
-		product: productHandler,
-		cart:    cartHandler,
+		user:     userHandler,
+		product:  productHandler,
+		cart:     cartHandler,
+		order:    orderHandler,
+		review:   reviewHandler,
+		wishlist: wishlistHandler,
+		admin:    adminHandler,
 	}
 }
 
 // Server serves HTTP requests for this app domain.
 type Server struct {
-	config   util.Config
-	store    db.Store
-	app      *fiber.App
-	handlers handlers
+	config           util.Config
+	store            db.Store
+	accessTokenMaker token.Maker
+	rateLimitStore   ratelimit.Store
+	logger           *slog.Logger
+	metrics          *metrics.Metrics
+	tracerProvider   trace.TracerProvider
+	propagator       propagation.TextMapPropagator
+	app              *fiber.App
+	handlers         handlers
 }
 
 // NewServer creates a new HTTP server and setup routing.
+// The rate limits are kept in memory, so they apply to each server instance separately.
 func NewServer(config util.Config, store db.Store) (*Server, error) {
-	app := fiber.New()
+	return NewServerWithRateLimitStore(config, store, ratelimit.NewMemoryStore())
+}
+
+// NewServerWithRateLimitStore creates a new HTTP server that keeps the rate limits in the given store,
+// which may be shared by several server instances.
+func NewServerWithRateLimitStore(config util.Config, store db.Store, rateLimitStore ratelimit.Store) (*Server, error) {
+	var accessTokenMaker token.Maker
+	switch config.AuthMode {
+	case "", util.AuthModeSession:
+	case util.AuthModeToken:
+		var err error
+		accessTokenMaker, err = token.NewJWTMaker(config.AccessTokenSymmetricKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create access token maker: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown auth mode %q", config.AuthMode)
+	}
+
+	logLevel, err := logging.ParseLevel(config.LogLevel)
+	if err != nil {
+		return nil, err
+	}
 
+	// The proxy header is read by clientIP, since c.IP() would take its first address, which clients control
+	app := fiber.New(fiber.Config{
+		ErrorHandler:            errorHandler,
+		EnableTrustedProxyCheck: config.Proxy.EnableTrustedProxyCheck,
+		TrustedProxies:          config.Proxy.TrustedProxies,
+	})
+
+	serverMetrics := metrics.New()
+
+	server := &Server{
+		config:           config,
+		store:            store,
+		accessTokenMaker: accessTokenMaker,
+		rateLimitStore:   rateLimitStore,
+		logger:           logging.New(os.Stdout, logLevel),
+		metrics:          serverMetrics,
+		tracerProvider:   otel.GetTracerProvider(),
+		propagator:       otel.GetTextMapPropagator(),
+		app:              app,
+		handlers:         newHandlers(config, store, accessTokenMaker, serverMetrics),
+	}
+
+	// Panics of handlers are recovered by accessLogMiddleware, so that they are logged and counted.
+	// This only keeps a panic of the middlewares in front of it from taking down the server.
 	app.Use(recover.New())
-	app.Use(logger.New())
+	app.Use(requestIDMiddleware())
+	app.Use(tracingMiddleware(server))
+	app.Use(metricsMiddleware(server))
+	app.Use(accessLogMiddleware(server))
 	app.Use(helmet.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:     "http://localhost:3000,https://next-bazaar.vercel.app",
 		AllowCredentials: true,
 	}))
 
-	server := &Server{
-		config:   config,
-		store:    store,
-		app:      app,
-		handlers: newHandlers(config, store),
-	}
+	app.Use(rateLimitMiddleware(server, "global", config.RateLimit.Global))
 
 	server.setupRouter()
 	return server, nil
@@ -76,32 +166,89 @@ func (server *Server) setupRouter() {
 
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	if server.config.Metrics.Enabled {
+		app.Get("/metrics", metricsAuthMiddleware(server), adaptor.HTTPHandler(server.metrics.Handler()))
+	}
+
 	api := app.Group("/api")
 	v1 := api.Group("/v1")
 
-	v1.Post("/users/register", server.handlers.user.register)
-	v1.Post("/users/login", server.handlers.user.login)
+	limitRegister := rateLimitMiddleware(server, "register", server.config.RateLimit.Register)
+	limitLogin := rateLimitMiddleware(server, "login", server.config.RateLimit.Login)
+
+	v1.Post("/users/register", limitRegister, server.handlers.user.register)
+	v1.Post("/users/login", limitLogin, server.handlers.user.login)
 
 	v1.Get("/products", server.handlers.product.listProducts)
 	v1.Get("/products/categories", server.handlers.product.listProductCategories)
+	v1.Get("/products/categories/tree", server.handlers.product.getProductCategoryTree)
 	v1.Get("/products/:id", server.handlers.product.getProduct)
+	v1.Get("/products/:id/reviews", server.handlers.review.listReviews)
 
 	v1.Use(authMiddleware(server))
+	v1.Use(writeRateLimitMiddleware(server, server.config.RateLimit.Write))
 
 	v1.Post("/users/logout", server.handlers.user.logout)
 	v1.Get("/users/me", server.handlers.user.getCurrentUser)
 	v1.Patch("/users/me", server.handlers.user.updateCurrentUser)
 	v1.Patch("/users/me/password", server.handlers.user.updateCurrentUserPassword)
+	v1.Get("/users/me/sessions", server.handlers.user.listCurrentUserSessions)
+	v1.Delete("/users/me/sessions/:id", server.handlers.user.revokeCurrentUserSession)
+	v1.Post("/users/me/sessions/revoke-all", server.handlers.user.revokeAllCurrentUserSessions)
+	v1.Get("/users/me/api-keys", server.handlers.user.listCurrentUserAPIKeys)
+	v1.Post("/users/me/api-keys", server.handlers.user.createCurrentUserAPIKey)
+	v1.Delete("/users/me/api-keys/:id", server.handlers.user.deleteCurrentUserAPIKey)
 
-	v1.Get("/users/products", server.handlers.product.listProductsBySeller)
-	v1.Post("/users/products", server.handlers.product.addProduct)
-	v1.Put("/users/products/:id", server.handlers.product.updateProduct)
+	// Only the routes guarded by requireScope are accessible with API keys
+	readCatalog := requireScope(user_domain.APIKeyScopeCatalogRead, user_domain.APIKeyScopeProductsManage)
+	manageProducts := requireScope(user_domain.APIKeyScopeProductsManage)
+	manageCart := requireScope(user_domain.APIKeyScopeCartManage)
 
-	v1.Get("/cart", server.handlers.cart.getCart)
-	v1.Get("/cart/count", server.handlers.cart.getCartProductsCount)
-	v1.Post("/cart/add-product", server.handlers.cart.addProduct)
-	v1.Put("/cart/:product_id", server.handlers.cart.updateProductQuantity)
-	v1.Delete("/cart/:product_id", server.handlers.cart.deleteProduct)
+	requireSeller := requireRole(server, db.UserRoleSeller, db.UserRoleAdmin)
+	requireAdmin := requireRole(server, db.UserRoleAdmin)
+
+	// Only sellers put products up for sale. The scope is checked first, since requireRole needs the key owner
+	v1.Get("/users/products", readCatalog, server.handlers.product.listProductsBySeller)
+	v1.Post("/users/products", manageProducts, requireSeller, server.handlers.product.addProduct)
+	v1.Put("/users/products/:id", manageProducts, requireSeller, server.handlers.product.updateProduct)
+	v1.Delete("/users/products/:id", manageProducts, requireSeller, server.handlers.product.deleteProduct)
+
+	v1.Post("/products/categories", requireAdmin, server.handlers.product.createProductCategory)
+	v1.Patch("/products/categories/:id", requireAdmin, server.handlers.product.updateProductCategory)
+	v1.Delete("/products/categories/:id", requireAdmin, server.handlers.product.deleteProductCategory)
+
+	v1.Get("/cart", manageCart, server.handlers.cart.getCart)
+	v1.Get("/cart/count", manageCart, server.handlers.cart.getCartProductsCount)
+	v1.Post("/cart/add-product", manageCart, server.handlers.cart.addProduct)
+	v1.Put("/cart/:product_id", manageCart, server.handlers.cart.updateProductQuantity)
+	v1.Delete("/cart/:product_id", manageCart, server.handlers.cart.deleteProduct)
+
+	v1.Get("/wishlist", server.handlers.wishlist.getWishlist)
+	v1.Post("/wishlist", server.handlers.wishlist.addProduct)
+	v1.Delete("/wishlist/:product_id", server.handlers.wishlist.deleteProduct)
+	v1.Post("/wishlist/:product_id/move-to-cart", server.handlers.wishlist.moveProductToCart)
+
+	v1.Post("/checkout", server.handlers.order.checkout)
+	v1.Get("/orders", server.handlers.order.listOrders)
+	v1.Get("/orders/:id", server.handlers.order.getOrder)
+
+	v1.Post("/products/:id/reviews", server.handlers.review.createReview)
+	v1.Patch("/products/:id/reviews", server.handlers.review.updateReview)
+	v1.Delete("/products/:id/reviews", server.handlers.review.deleteReview)
+
+	admin := v1.Group("/admin", requireAdmin)
+
+	admin.Get("/users", server.handlers.admin.listUsers)
+	admin.Get("/users/:id", server.handlers.admin.getUser)
+	admin.Patch("/users/:id/role", server.handlers.admin.updateUserRole)
+
+	// Aliases of the category management routes, so that admins manage everything under /admin
+	admin.Post("/categories", server.handlers.product.createProductCategory)
+	admin.Patch("/categories/:id", server.handlers.product.updateProductCategory)
+	admin.Delete("/categories/:id", server.handlers.product.deleteProductCategory)
+
+	admin.Put("/products/:id", server.handlers.admin.updateProduct)
+	admin.Delete("/products/:id", server.handlers.admin.deleteProduct)
 }
 
 // Start runs the HTTP server on a specific address.
@@ -109,6 +256,16 @@ func (server *Server) Start(address string) error {
 	return server.app.Listen(address)
 }
 
+// Metrics returns the metrics of the server, e.g. to register further collectors.
+func (server *Server) Metrics() *metrics.Metrics {
+	return server.metrics
+}
+
+// Shutdown gracefully shuts down the HTTP server.
+func (server *Server) Shutdown() error {
+	return server.app.Shutdown()
+}
+
 type messageResponse struct {
 	Message string `json:"message"`
 }
@@ -117,10 +274,35 @@ func newMessageResponse(message string) messageResponse {
 	return messageResponse{Message: message}
 }
 
+// errorResponse is the response of every failed request.
+// Fields are only set if the request failed validation.
 type errorResponse struct {
-	Error string `json:"error"`
+	Code      string                  `json:"code"`
+	Message   string                  `json:"message"`
+	Fields    []validation.FieldError `json:"fields,omitempty"`
+	RequestID string                  `json:"request_id"`
+}
+
+func newErrorResponse(code string, message string, requestID string) errorResponse {
+	return errorResponse{
+		Code:      code,
+		Message:   message,
+		RequestID: requestID,
+	}
 }
 
-func newErrorResponse(err error) errorResponse {
-	return errorResponse{Error: err.Error()}
+type insufficientStockResponse struct {
+	errorResponse
+	ProductID uuid.UUID `json:"product_id"`
+	Requested int32     `json:"requested"`
+	Available int32     `json:"available"`
+}
+
+func newInsufficientStockResponse(err *db.InsufficientStockError, requestID string) insufficientStockResponse {
+	return insufficientStockResponse{
+		errorResponse: newErrorResponse(codeInsufficientStock, err.Error(), requestID),
+		ProductID:     err.ProductID,
+		Requested:     err.Requested,
+		Available:     err.Available,
+	}
 }
@@ -0,0 +1,411 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/test_util"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/ratelimit"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestNewServerAuthMode(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config util.Config
+		check  func(t *testing.T, server *Server, err error)
+	}{
+		{
+			name:   "DefaultSessionMode",
+			config: util.Config{},
+			check: func(t *testing.T, server *Server, err error) {
+				require.NoError(t, err)
+				require.Nil(t, server.accessTokenMaker)
+			},
+		},
+		{
+			name: "SessionMode",
+			config: util.Config{
+				AuthMode: util.AuthModeSession,
+			},
+			check: func(t *testing.T, server *Server, err error) {
+				require.NoError(t, err)
+				require.Nil(t, server.accessTokenMaker)
+			},
+		},
+		{
+			name: "TokenMode",
+			config: util.Config{
+				AuthMode:                util.AuthModeToken,
+				AccessTokenSymmetricKey: util.RandomString(32),
+			},
+			check: func(t *testing.T, server *Server, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, server.accessTokenMaker)
+			},
+		},
+		{
+			name: "TokenModeWithShortKey",
+			config: util.Config{
+				AuthMode:                util.AuthModeToken,
+				AccessTokenSymmetricKey: util.RandomString(31),
+			},
+			check: func(t *testing.T, server *Server, err error) {
+				require.Error(t, err)
+				require.Nil(t, server)
+			},
+		},
+		{
+			name: "UnknownMode",
+			config: util.Config{
+				AuthMode: "unknown",
+			},
+			check: func(t *testing.T, server *Server, err error) {
+				require.Error(t, err)
+				require.Nil(t, server)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := NewServer(tc.config, nil)
+			tc.check(t, server, err)
+		})
+	}
+}
+
+func TestNewServerRateLimit(t *testing.T) {
+	oneRequest := ratelimit.Limit{Requests: 1, Period: time.Minute}
+
+	testCases := []struct {
+		name      string
+		rateLimit util.RateLimitConfig
+		method    string
+		url       string
+		checkLast func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:      "Global",
+			rateLimit: util.RateLimitConfig{Global: oneRequest},
+			method:    http.MethodGet,
+			url:       "/unknown",
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+		{
+			name:      "Register",
+			rateLimit: util.RateLimitConfig{Register: oneRequest},
+			method:    http.MethodPost,
+			url:       "/api/v1/users/register",
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+		{
+			name:      "Login",
+			rateLimit: util.RateLimitConfig{Login: oneRequest},
+			method:    http.MethodPost,
+			url:       "/api/v1/users/login",
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+		{
+			name:      "LoginDoesNotUseRegisterBudget",
+			rateLimit: util.RateLimitConfig{Register: oneRequest},
+			method:    http.MethodPost,
+			url:       "/api/v1/users/login",
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:      "Disabled",
+			rateLimit: util.RateLimitConfig{},
+			method:    http.MethodPost,
+			url:       "/api/v1/users/register",
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+				require.Empty(t, response.Header.Get(headerRateLimitLimit))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Invalid bodies are rejected before the store is used
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			server, err := NewServer(util.Config{RateLimit: tc.rateLimit}, store)
+			require.NoError(t, err)
+
+			var response *http.Response
+			for i := 0; i < 2; i++ {
+				request := test_util.NewRequest(t, test_util.RequestParams{
+					Method: tc.method,
+					URL:    tc.url,
+					Body:   test_util.Body{},
+				})
+				response = test_util.SendRequest(t, server.app, request)
+			}
+
+			tc.checkLast(t, response)
+		})
+	}
+}
+
+func TestNewServerProxy(t *testing.T) {
+	forwardedHeader := "X-Forwarded-For"
+	trustedProxy := util.ProxyConfig{
+		Header:                  forwardedHeader,
+		EnableTrustedProxyCheck: true,
+		// Test requests come from 0.0.0.0
+		TrustedProxies: []string{"0.0.0.0"},
+	}
+
+	testCases := []struct {
+		name      string
+		proxy     util.ProxyConfig
+		headers   []string
+		checkLast func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:    "TrustedProxy",
+			proxy:   trustedProxy,
+			headers: []string{"203.0.113.1, 198.51.100.1", "203.0.113.1, 198.51.100.2"},
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			// The proxy appends the same client address, only the spoofed addresses before it differ
+			name:    "TrustedProxySpoofedPrefix",
+			proxy:   trustedProxy,
+			headers: []string{"203.0.113.1, 198.51.100.1", "203.0.113.2, 198.51.100.1"},
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+		{
+			name:    "TrustedProxyInvalidAddress",
+			proxy:   trustedProxy,
+			headers: []string{"198.51.100.1", "invalid"},
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "UntrustedProxy",
+			proxy: util.ProxyConfig{
+				Header:                  forwardedHeader,
+				EnableTrustedProxyCheck: true,
+				TrustedProxies:          []string{"10.0.0.0/8"},
+			},
+			headers: []string{"198.51.100.1", "198.51.100.2"},
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+		{
+			name:    "NoProxyHeader",
+			proxy:   util.ProxyConfig{},
+			headers: []string{"198.51.100.1", "198.51.100.2"},
+			checkLast: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			// Every client IP may send one request, so the second request is only limited
+			// if it is not told apart from the first one
+			server, err := NewServer(util.Config{
+				RateLimit: util.RateLimitConfig{Global: ratelimit.Limit{Requests: 1, Period: time.Minute}},
+				Proxy:     tc.proxy,
+			}, store)
+			require.NoError(t, err)
+
+			var response *http.Response
+			for _, header := range tc.headers {
+				request := test_util.NewRequest(t, test_util.RequestParams{
+					Method: http.MethodGet,
+					URL:    "/unknown",
+				})
+				request.Header.Set(forwardedHeader, header)
+				response = test_util.SendRequest(t, server.app, request)
+			}
+
+			tc.checkLast(t, response)
+		})
+	}
+}
+
+func TestNewServerMetrics(t *testing.T) {
+	metricsToken := util.RandomString(32)
+
+	testCases := []struct {
+		name          string
+		config        util.MetricsConfig
+		setupAuth     func(request *http.Request)
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:   "Disabled",
+			config: util.MetricsConfig{},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:   "Public",
+			config: util.MetricsConfig{Enabled: true},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:   "Token",
+			config: util.MetricsConfig{Enabled: true, Token: metricsToken},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, metricsToken)
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:   "MissingToken",
+			config: util.MetricsConfig{Enabled: true, Token: metricsToken},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:   "WrongToken",
+			config: util.MetricsConfig{Enabled: true, Token: metricsToken},
+			setupAuth: func(request *http.Request) {
+				test_util.AddBearerTokenInHeader(request, util.RandomString(32))
+			},
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, err := NewServer(util.Config{Metrics: tc.config}, nil)
+			require.NoError(t, err)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/metrics",
+			})
+			if tc.setupAuth != nil {
+				tc.setupAuth(request)
+			}
+
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	store.EXPECT().
+		CreateUser(gomock.Any(), gomock.Any()).
+		Return(db.User{}, nil)
+
+	server, err := NewServer(util.Config{Metrics: util.MetricsConfig{Enabled: true}}, store)
+	require.NoError(t, err)
+
+	server.app.Get("/items/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	server.app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("unexpected panic")
+	})
+
+	requests := []test_util.RequestParams{
+		{Method: http.MethodGet, URL: "/items/1"},
+		{Method: http.MethodGet, URL: "/items/2"},
+		{Method: http.MethodGet, URL: "/unknown"},
+		{Method: http.MethodGet, URL: "/panic"},
+		{
+			Method: http.MethodPost,
+			URL:    "/api/v1/users/register",
+			Body: test_util.Body{
+				"name":     "testuser",
+				"email":    "test@example.com",
+				"password": "test-password",
+			},
+		},
+	}
+	for _, params := range requests {
+		request := test_util.NewRequest(t, params)
+		test_util.SendRequest(t, server.app, request)
+	}
+
+	request := test_util.NewRequest(t, test_util.RequestParams{
+		Method: http.MethodGet,
+		URL:    "/metrics",
+	})
+	response := test_util.SendRequest(t, server.app, request)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	data, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	body := string(data)
+
+	// Requests are counted by route template, not by path
+	require.Contains(t, body, `http_requests_total{method="GET",route="/items/:id",status="200"} 2`)
+	require.Contains(t, body, `http_requests_total{method="GET",route="/",status="404"} 1`)
+	require.Contains(t, body, `http_requests_total{method="GET",route="/panic",status="500"} 1`)
+	require.Contains(t, body, `http_requests_total{method="POST",route="/api/v1/users/register",status="200"} 1`)
+	require.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/items/:id"} 2`)
+	require.NotContains(t, body, `route="/items/1"`)
+
+	require.Contains(t, body, "bazaar_user_registrations_total 1")
+}
+
+// requireNoContent checks that the response is a 204 without a body.
+func requireNoContent(t *testing.T, response *http.Response) {
+	require.Equal(t, http.StatusNoContent, response.StatusCode)
+	require.Empty(t, response.Header.Get(fiber.HeaderContentType))
+
+	data, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Empty(t, data)
+}
@@ -1,53 +1,115 @@
 package api
 
 import (
-	"fmt"
-
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	db "github.com/ot07/next-bazaar/db/sqlc"
 	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/util"
 )
 
+// getSession returns the session of the authenticated user.
+// Requests authenticated with an API key only have a session on routes guarded by requireScope.
 func getSession(c *fiber.Ctx) (db.Session, error) {
 	session, ok := c.Locals(ctxLocalSessionKey).(db.Session)
 	if !ok {
-		return db.Session{}, fmt.Errorf("session token not found")
+		if _, ok := c.Locals(ctxLocalAPIKeyKey).(db.ApiKey); ok {
+			return db.Session{}, errAPIKeyNotAllowed
+		}
+		return db.Session{}, errSessionTokenNotFound
 	}
 	return session, nil
 }
 
-func refreshSessionToken(c *fiber.Ctx, server *Server, expiredSession db.Session) (db.Session, error) {
-	// TODO: setup transaction
-	if token.IsExpired(expiredSession.RefreshTokenExpiredAt) {
-		return db.Session{}, token.ErrExpiredToken
+// getUserID returns the id of the authenticated user, whether the request is authenticated with a session
+// or an API key. Unlike getSession, it is not limited to routes guarded by requireScope.
+func getUserID(c *fiber.Ctx) (uuid.UUID, bool) {
+	if session, ok := c.Locals(ctxLocalSessionKey).(db.Session); ok {
+		return session.UserID, true
 	}
-
-	err := server.store.DeleteSession(c.Context(), expiredSession.ID)
-	if err != nil {
-		return db.Session{}, err
+	if apiKey, ok := c.Locals(ctxLocalAPIKeyKey).(db.ApiKey); ok {
+		return apiKey.UserID, true
 	}
+	return uuid.UUID{}, false
+}
 
+// refreshSessionToken issues new session and refresh tokens in exchange for the refresh token
+// identified by its hash.
+func refreshSessionToken(c *fiber.Ctx, server *Server, refreshTokenHash []byte) (db.Session, error) {
 	newSessionToken := token.NewToken(server.config.SessionTokenDuration)
+	newRefreshToken := token.NewToken(server.config.RefreshTokenDuration)
 
-	newSession, err := server.store.CreateSession(c.Context(), db.CreateSessionParams{
-		UserID:                expiredSession.UserID,
-		SessionToken:          newSessionToken.ID,
-		SessionTokenExpiredAt: newSessionToken.ExpiredAt,
-		RefreshToken:          expiredSession.RefreshToken,
-		RefreshTokenExpiredAt: expiredSession.RefreshTokenExpiredAt,
+	newSession, err := server.store.RotateSessionTx(c.UserContext(), db.RotateSessionTxParams{
+		CurrentRefreshTokenHash: refreshTokenHash,
+		SessionTokenHash:        newSessionToken.Hash(),
+		SessionTokenExpiredAt:   newSessionToken.ExpiredAt,
+		RefreshTokenHash:        newRefreshToken.Hash(),
+		RefreshTokenExpiredAt:   newRefreshToken.ExpiredAt,
 	})
 	if err != nil {
 		return db.Session{}, err
 	}
 
+	if server.accessTokenMaker != nil {
+		user, err := server.store.GetUser(c.UserContext(), newSession.UserID)
+		if err != nil {
+			return db.Session{}, err
+		}
+
+		newSessionToken, err = newAccessToken(server.accessTokenMaker, server.config, newSession.ID, user.ID, user.Role)
+		if err != nil {
+			return db.Session{}, err
+		}
+
+		c.Locals(ctxLocalRoleKey, user.Role)
+	}
+
+	setSessionCookies(c, server.config, newSessionToken, newRefreshToken)
+
+	return newSession, nil
+}
+
+// newAccessToken signs a short-lived access token for the session, which is sent in place of
+// the opaque session token in token auth mode. The opaque token is still stored for the session,
+// but it is never handed out. The access token stays valid until it expires, even if the session
+// is revoked, so it lives only for the access token duration.
+func newAccessToken(
+	maker token.Maker,
+	config util.Config,
+	sessionID uuid.UUID,
+	userID uuid.UUID,
+	role db.UserRole,
+) (*token.Token, error) {
+	accessToken, _, err := maker.CreateToken(token.PayloadParams{
+		SessionID: sessionID,
+		UserID:    userID,
+		Role:      string(role),
+	}, config.AccessTokenDuration)
+	return accessToken, err
+}
+
+// setSessionCookies sets the session and refresh tokens as HttpOnly cookies
+// that live as long as the tokens themselves.
+func setSessionCookies(c *fiber.Ctx, config util.Config, sessionToken *token.Token, refreshToken *token.Token) {
 	c.Cookie(&fiber.Cookie{
 		Name:     cookieSessionTokenKey,
-		Value:    newSessionToken.ID.String(),
+		Value:    sessionToken.Value,
 		HTTPOnly: true,
 		SameSite: "none",
 		Secure:   true,
-		MaxAge:   int(server.config.RefreshTokenDuration.Seconds()),
+		MaxAge:   int(config.SessionTokenDuration.Seconds()),
 	})
 
-	return newSession, nil
+	c.Cookie(&fiber.Cookie{
+		Name:     cookieRefreshTokenKey,
+		Value:    refreshToken.Value,
+		HTTPOnly: true,
+		SameSite: "none",
+		Secure:   true,
+		MaxAge:   int(config.RefreshTokenDuration.Seconds()),
+	})
+}
+
+func clearSessionCookies(c *fiber.Ctx) {
+	c.ClearCookie(cookieSessionTokenKey, cookieRefreshTokenKey)
 }
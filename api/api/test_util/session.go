@@ -12,6 +12,7 @@ import (
 
 const (
 	cookieSessionTokenKey = "session_token"
+	cookieRefreshTokenKey = "refresh_token"
 )
 
 func AddSessionTokenInCookie(
@@ -29,10 +30,37 @@ func AddSessionTokenInCookie(
 	request.AddCookie(cookie)
 }
 
+func AddRefreshTokenInCookie(
+	request *http.Request,
+	refreshToken string,
+) {
+	cookie := &http.Cookie{
+		Name:     cookieRefreshTokenKey,
+		Value:    refreshToken,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+		Secure:   true,
+	}
+
+	request.AddCookie(cookie)
+}
+
+func AddBearerTokenInHeader(
+	request *http.Request,
+	bearerToken string,
+) {
+	request.Header.Set("Authorization", "Bearer "+bearerToken)
+}
+
 func BuildValidSessionStubs(store *mockdb.MockStore, session db.Session) {
 	store.EXPECT().
 		GetSession(gomock.Any(), gomock.Any()).
 		Return(session, nil)
+
+	store.EXPECT().
+		TouchSession(gomock.Any(), gomock.Eq(session.ID)).
+		AnyTimes().
+		Return(nil)
 }
 
 func NewTokens(count int, duration time.Duration) []*token.Token {
@@ -42,3 +70,22 @@ func NewTokens(count int, duration time.Duration) []*token.Token {
 	}
 	return tokens
 }
+
+func BuildValidRoleStubs(store *mockdb.MockStore, session db.Session, role db.UserRole) {
+	BuildValidSessionStubs(store, session)
+
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(session.UserID)).
+		Return(db.User{ID: session.UserID, Role: role}, nil)
+}
+
+func BuildValidAPIKeyStubs(store *mockdb.MockStore, apiKey db.ApiKey) {
+	store.EXPECT().
+		GetApiKeyByHash(gomock.Any(), gomock.Any()).
+		Return(apiKey, nil)
+
+	store.EXPECT().
+		TouchApiKey(gomock.Any(), gomock.Eq(apiKey.ID)).
+		AnyTimes().
+		Return(nil)
+}
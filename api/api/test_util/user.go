@@ -17,6 +17,8 @@ type WithSessionUserParams struct {
 	Password     string
 	SessionToken *token.Token
 	RefreshToken *token.Token
+	// Role is the role of the user, which defaults to the role new users get
+	Role db.UserRole
 }
 
 func CreateWithSessionUser(
@@ -35,11 +37,19 @@ func CreateWithSessionUser(
 	})
 	require.NoError(t, err)
 
+	if len(params.Role) > 0 {
+		user, err = store.UpdateUserRole(ctx, db.UpdateUserRoleParams{
+			ID:   user.ID,
+			Role: params.Role,
+		})
+		require.NoError(t, err)
+	}
+
 	_, err = store.CreateSession(ctx, db.CreateSessionParams{
 		UserID:                user.ID,
-		SessionToken:          params.SessionToken.ID,
+		SessionTokenHash:      params.SessionToken.Hash(),
 		SessionTokenExpiredAt: params.SessionToken.ExpiredAt,
-		RefreshToken:          params.RefreshToken.ID,
+		RefreshTokenHash:      params.RefreshToken.Hash(),
 		RefreshTokenExpiredAt: params.RefreshToken.ExpiredAt,
 	})
 	require.NoError(t, err)
@@ -1,25 +1,35 @@
 package api
 
 import (
-	"database/sql"
+	"errors"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/api/apperror"
 	user_domain "github.com/ot07/next-bazaar/api/domain/user"
 	"github.com/ot07/next-bazaar/api/validation"
+	"github.com/ot07/next-bazaar/metrics"
+	"github.com/ot07/next-bazaar/token"
 	"github.com/ot07/next-bazaar/util"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type userHandler struct {
-	service *user_domain.UserService
-	config  util.Config
+	service          *user_domain.UserService
+	config           util.Config
+	accessTokenMaker token.Maker
+	metrics          *metrics.Metrics
 }
 
-func newUserHandler(s *user_domain.UserService, config util.Config) *userHandler {
+func newUserHandler(
+	s *user_domain.UserService,
+	config util.Config,
+	accessTokenMaker token.Maker,
+	m *metrics.Metrics,
+) *userHandler {
 	return &userHandler{
-		service: s,
-		config:  config,
+		service:          s,
+		config:           config,
+		accessTokenMaker: accessTokenMaker,
+		metrics:          m,
 	}
 }
 
@@ -28,35 +38,32 @@ func newUserHandler(s *user_domain.UserService, config util.Config) *userHandler
 // @Param        body body user_domain.RegisterRequest true "User object"
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
-// @Failure      403 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      429 {object} errorResponse
 // @Failure      500 {object} errorResponse
 // @Router       /users/register [post]
 func (h *userHandler) register(c *fiber.Ctx) error {
 	req := new(user_domain.RegisterRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err := h.service.Register(c.Context(), user_domain.RegisterServiceParams{
+	err := h.service.Register(c.UserContext(), user_domain.RegisterServiceParams{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: req.Password,
 	})
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				return c.Status(fiber.StatusForbidden).JSON(newErrorResponse(err))
-			}
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
+	h.metrics.UserRegistered()
+
 	rsp := newMessageResponse("Congratulations! You are now a member of our online bazaar. Start exploring!")
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -67,42 +74,53 @@ func (h *userHandler) register(c *fiber.Ctx) error {
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
 // @Failure      401 {object} errorResponse
+// @Failure      429 {object} errorResponse
 // @Failure      500 {object} errorResponse
 // @Router       /users/login [post]
 func (h *userHandler) login(c *fiber.Ctx) error {
 	req := new(user_domain.LoginRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	sessionToken, err := h.service.Login(c.Context(), user_domain.LoginServiceParams{
+	user, tokens, err := h.service.Login(c.UserContext(), user_domain.LoginServiceParams{
 		Email:                req.Email,
 		Password:             req.Password,
 		SessionTokenDuration: h.config.SessionTokenDuration,
 		RefreshTokenDuration: h.config.RefreshTokenDuration,
+		UserAgent:            c.Get(fiber.HeaderUserAgent),
+		ClientIP:             clientIP(c, h.config.Proxy),
+		Lockout:              h.config.LoginLockout,
 	})
 	if err != nil {
-		if err == sql.ErrNoRows || err == bcrypt.ErrMismatchedHashAndPassword {
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		var lockedErr *user_domain.LoginLockedError
+		if errors.As(err, &lockedErr) {
+			h.metrics.LoginAttempted(metrics.LoginResultLocked)
+			c.Set(fiber.HeaderRetryAfter, formatSeconds(lockedErr.RetryAfter))
+		} else if errors.Is(err, user_domain.ErrInvalidCredentials) {
+			h.metrics.LoginAttempted(metrics.LoginResultFailure)
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
+	sessionToken := tokens.SessionToken
+	if h.accessTokenMaker != nil {
+		sessionToken, err = newAccessToken(h.accessTokenMaker, h.config, tokens.SessionID, user.ID, user.Role)
+		if err != nil {
+			return err
+		}
+	}
+
+	h.metrics.LoginAttempted(metrics.LoginResultSuccess)
+
 	rsp := newMessageResponse("Welcome to our online bazaar! Get ready to discover unique treasures and amazing deals.")
 
-	c.Cookie(&fiber.Cookie{
-		Name:     cookieSessionTokenKey,
-		Value:    sessionToken.ID.String(),
-		HTTPOnly: true,
-		SameSite: "none",
-		Secure:   true,
-		MaxAge:   int(h.config.RefreshTokenDuration.Seconds()),
-	})
+	setSessionCookies(c, h.config, sessionToken, tokens.RefreshToken)
 
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -116,20 +134,17 @@ func (h *userHandler) login(c *fiber.Ctx) error {
 func (h *userHandler) logout(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
-	err = h.service.Logout(c.Context(), session.SessionToken)
+	err = h.service.Logout(c.UserContext(), session.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := newMessageResponse("Thank you for visiting us, we look forward to your next visit!")
 
-	c.ClearCookie(cookieSessionTokenKey)
+	clearSessionCookies(c)
 
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
@@ -143,15 +158,16 @@ func (h *userHandler) logout(c *fiber.Ctx) error {
 func (h *userHandler) getCurrentUser(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
-	user, err := h.service.GetUser(c.Context(), session.UserID)
+	user, err := h.service.GetUser(c.UserContext(), session.UserID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		// The session outlived its user, so the requester is no longer authenticated
+		if err == user_domain.ErrUserNotFound {
+			return errSessionTokenNotFound
 		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := user_domain.NewUserResponse(user)
@@ -164,38 +180,32 @@ func (h *userHandler) getCurrentUser(c *fiber.Ctx) error {
 // @Param        body body user_domain.UpdateRequest true "User object"
 // @Success      200 {object} messageResponse
 // @Failure      400 {object} errorResponse
-// @Failure      403 {object} errorResponse
+// @Failure      409 {object} errorResponse
 // @Failure      500 {object} errorResponse
 // @Router       /users/me [patch]
 func (h *userHandler) updateCurrentUser(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(user_domain.UpdateRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.UpdateUser(c.Context(), user_domain.UpdateUserServiceParams{
+	err = h.service.UpdateUser(c.UserContext(), user_domain.UpdateUserServiceParams{
 		ID:    session.UserID,
 		Name:  req.Name,
 		Email: req.Email,
 	})
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				return c.Status(fiber.StatusForbidden).JSON(newErrorResponse(err))
-			}
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := newMessageResponse("Your information has been updated successfully!")
@@ -212,31 +222,199 @@ func (h *userHandler) updateCurrentUser(c *fiber.Ctx) error {
 func (h *userHandler) updateCurrentUserPassword(c *fiber.Ctx) error {
 	session, err := getSession(c)
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
+		return err
 	}
 
 	req := new(user_domain.UpdatePasswordRequest)
 	if err := c.BodyParser(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
 	validate := validation.NewValidator()
 	if err := validate.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(newErrorResponse(err))
+		return apperror.InvalidRequest(err)
 	}
 
-	err = h.service.UpdateUserPassword(c.Context(), user_domain.UpdateUserPasswordServiceParams{
-		ID:          session.UserID,
-		OldPassword: req.OldPassword,
-		NewPassword: req.NewPassword,
+	err = h.service.UpdateUserPassword(c.UserContext(), user_domain.UpdateUserPasswordServiceParams{
+		ID:               session.UserID,
+		OldPassword:      req.OldPassword,
+		NewPassword:      req.NewPassword,
+		CurrentSessionID: session.ID,
 	})
 	if err != nil {
-		if err == bcrypt.ErrMismatchedHashAndPassword {
-			return c.Status(fiber.StatusUnauthorized).JSON(newErrorResponse(err))
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(newErrorResponse(err))
+		return err
 	}
 
 	rsp := newMessageResponse("Your password has been updated successfully!")
 	return c.Status(fiber.StatusOK).JSON(rsp)
 }
+
+// @Summary      List sessions of current user
+// @Tags         Users
+// @Success      200 {array} user_domain.SessionResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/sessions [get]
+func (h *userHandler) listCurrentUserSessions(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := h.service.GetSessionsByUserID(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	rsp := user_domain.NewSessionsResponse(sessions, session.ID)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Revoke session of current user
+// @Tags         Users
+// @Param        id path string true "Session ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/sessions/{id} [delete]
+func (h *userHandler) revokeCurrentUserSession(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(user_domain.RevokeSessionRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.RevokeSession(c.UserContext(), user_domain.RevokeSessionServiceParams{
+		ID:     req.ID,
+		UserID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.ID == session.ID {
+		clearSessionCookies(c)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary      Revoke all sessions of current user
+// @Tags         Users
+// @Success      200 {object} messageResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/sessions/revoke-all [post]
+func (h *userHandler) revokeAllCurrentUserSessions(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.RevokeAllSessions(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	rsp := newMessageResponse("You have been logged out from all devices.")
+
+	clearSessionCookies(c)
+
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Create API key of current user
+// @Description  The key is only returned once. Send it as `Authorization: Bearer <key>`.
+// @Tags         Users
+// @Param        body body user_domain.CreateAPIKeyRequest true "API key object"
+// @Success      200 {object} user_domain.CreatedAPIKeyResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/api-keys [post]
+func (h *userHandler) createCurrentUserAPIKey(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(user_domain.CreateAPIKeyRequest)
+	if err := c.BodyParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	apiKey, key, err := h.service.CreateAPIKey(c.UserContext(), user_domain.CreateAPIKeyServiceParams{
+		UserID: session.UserID,
+		Name:   req.Name,
+		Scopes: req.Scopes,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := user_domain.NewCreatedAPIKeyResponse(apiKey, key)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      List API keys of current user
+// @Tags         Users
+// @Success      200 {array} user_domain.APIKeyResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/api-keys [get]
+func (h *userHandler) listCurrentUserAPIKeys(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	apiKeys, err := h.service.GetAPIKeysByUserID(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	rsp := user_domain.NewAPIKeysResponse(apiKeys)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Delete API key of current user
+// @Tags         Users
+// @Param        id path string true "API key ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /users/me/api-keys/{id} [delete]
+func (h *userHandler) deleteCurrentUserAPIKey(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(user_domain.DeleteAPIKeyRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.DeleteAPIKey(c.UserContext(), user_domain.DeleteAPIKeyServiceParams{
+		ID:     req.ID,
+		UserID: session.UserID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	user_domain "github.com/ot07/next-bazaar/api/domain/user"
 	"github.com/ot07/next-bazaar/api/test_util"
+	"github.com/ot07/next-bazaar/api/validation"
+	mockdb "github.com/ot07/next-bazaar/db/mock"
 	db "github.com/ot07/next-bazaar/db/sqlc"
 	"github.com/ot07/next-bazaar/token"
 	"github.com/ot07/next-bazaar/util"
@@ -79,7 +83,7 @@ func TestRegisterAPI(t *testing.T) {
 			},
 			body: defaultBody,
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusForbidden, response.StatusCode)
+				require.Equal(t, http.StatusConflict, response.StatusCode)
 			},
 			allowParallel: false,
 		},
@@ -174,6 +178,96 @@ func TestRegisterAPI(t *testing.T) {
 	}
 }
 
+func TestRegisterAPIValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           test_util.Body
+		acceptLanguage string
+		checkResponse  func(t *testing.T, response validation.ErrorResponse)
+	}{
+		{
+			name: "MultipleFields",
+			body: test_util.Body{
+				"name":     "test user",
+				"email":    "invalid-email",
+				"password": "1234567",
+			},
+			checkResponse: func(t *testing.T, response validation.ErrorResponse) {
+				require.Equal(t, validation.CodeValidationFailed, response.Code)
+				require.Equal(t, []validation.FieldError{
+					{Field: "name", Rule: "without_space", Param: "", Message: "name must not contain spaces"},
+					{Field: "email", Rule: "email", Param: "", Message: "email must be a valid email address"},
+					{Field: "password", Rule: "min", Param: "8", Message: "password must be at least 8 characters in length"},
+				}, response.Fields)
+			},
+		},
+		{
+			name: "Japanese",
+			body: test_util.Body{
+				"name":     "test user",
+				"email":    "test@example.com",
+				"password": "test-password",
+			},
+			acceptLanguage: "ja-JP,ja;q=0.9,en;q=0.8",
+			checkResponse: func(t *testing.T, response validation.ErrorResponse) {
+				require.Equal(t, validation.CodeValidationFailed, response.Code)
+				require.Len(t, response.Fields, 1)
+				require.Equal(t, "nameにスペースを含めることはできません", response.Fields[0].Message)
+			},
+		},
+		{
+			name: "UnsupportedLanguage",
+			body: test_util.Body{
+				"name":     "test user",
+				"email":    "test@example.com",
+				"password": "test-password",
+			},
+			acceptLanguage: "fr-FR",
+			checkResponse: func(t *testing.T, response validation.ErrorResponse) {
+				require.Len(t, response.Fields, 1)
+				require.Equal(t, "name must not contain spaces", response.Fields[0].Message)
+			},
+		},
+		{
+			name: "MalformedBody",
+			body: test_util.Body{
+				"name":     123,
+				"email":    "test@example.com",
+				"password": "test-password",
+			},
+			checkResponse: func(t *testing.T, response validation.ErrorResponse) {
+				require.Equal(t, validation.CodeInvalidRequest, response.Code)
+				require.Empty(t, response.Fields)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/users/register",
+				Body:   tc.body,
+			})
+			if len(tc.acceptLanguage) > 0 {
+				request.Header.Set("Accept-Language", tc.acceptLanguage)
+			}
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			tc.checkResponse(t, unmarshalValidationErrorResponse(t, response.Body))
+		})
+	}
+}
+
 func TestLoginAPI(t *testing.T) {
 	validName := "testuser"
 	validEmail := "test@example.com"
@@ -210,6 +304,13 @@ func TestLoginAPI(t *testing.T) {
 			body:           defaultBody,
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				cookies := response.Cookies()
+				require.Len(t, cookies, 2)
+				require.Equal(t, cookieSessionTokenKey, cookies[0].Name)
+				require.NotEmpty(t, cookies[0].Value)
+				require.Equal(t, cookieRefreshTokenKey, cookies[1].Name)
+				require.NotEmpty(t, cookies[1].Value)
 			},
 		},
 		{
@@ -328,6 +429,517 @@ func TestLoginAPI(t *testing.T) {
 	}
 }
 
+func TestLoginAPIWithAccessToken(t *testing.T) {
+	validPassword := "test-password"
+
+	validHashedPassword, err := util.HashPassword(validPassword)
+	require.NoError(t, err)
+
+	user := db.User{
+		ID:             util.RandomUUID(),
+		Name:           "testuser",
+		Email:          "test@example.com",
+		HashedPassword: validHashedPassword,
+		Role:           db.UserRoleSeller,
+	}
+	session := db.Session{
+		ID:     util.RandomUUID(),
+		UserID: user.ID,
+	}
+
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+		Return(user, nil)
+
+	store.EXPECT().
+		CreateSession(gomock.Any(), gomock.Any()).
+		Return(session, nil)
+
+	request := test_util.NewRequest(t, test_util.RequestParams{
+		Method: http.MethodPost,
+		URL:    "/api/v1/users/login",
+		Body: test_util.Body{
+			"email":    user.Email,
+			"password": validPassword,
+		},
+	})
+
+	server := newTestServerWithAuthMode(t, store, util.AuthModeToken)
+	response := test_util.SendRequest(t, server.app, request)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	cookies := response.Cookies()
+	require.Len(t, cookies, 2)
+	require.Equal(t, cookieSessionTokenKey, cookies[0].Name)
+
+	payload, err := server.accessTokenMaker.VerifyToken(cookies[0].Value)
+	require.NoError(t, err)
+	require.Equal(t, session.ID, payload.SessionID)
+	require.Equal(t, user.ID, payload.UserID)
+	require.Equal(t, string(user.Role), payload.Role)
+}
+
+func TestLoginAPIWithLockout(t *testing.T) {
+	validPassword := "test-password"
+
+	validHashedPassword, err := util.HashPassword(validPassword)
+	require.NoError(t, err)
+
+	user := db.User{
+		ID:             util.RandomUUID(),
+		Name:           "testuser",
+		Email:          "test@example.com",
+		HashedPassword: validHashedPassword,
+	}
+
+	lockout := util.LoginLockoutConfig{
+		MaxAttemptsPerEmail: 5,
+		MaxAttemptsPerIP:    20,
+		FailureWindow:       15 * time.Minute,
+		LockoutDuration:     time.Minute,
+		MaxLockoutDuration:  time.Hour,
+	}
+
+	emailFailureParams := db.GetLoginFailureParams{
+		Scope: user_domain.LoginFailureScopeEmail,
+		Key:   user.Email,
+	}
+
+	buildNotLockedStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			GetLoginFailure(gomock.Any(), gomock.Any()).
+			Times(2).
+			Return(db.LoginFailure{}, sql.ErrNoRows)
+	}
+
+	buildFailureStubs := func(store *mockdb.MockStore, emailFailureCount int32) {
+		buildNotLockedStubs(store)
+
+		store.EXPECT().
+			GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+			Return(user, nil)
+
+		store.EXPECT().
+			RecordLoginFailure(gomock.Any(), gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, arg db.RecordLoginFailureParams) (db.LoginFailure, error) {
+				require.WithinDuration(t, time.Now().Add(-lockout.FailureWindow), arg.ResetBefore, time.Second)
+
+				failureCount := int32(1)
+				if arg.Scope == user_domain.LoginFailureScopeEmail {
+					failureCount = emailFailureCount
+				}
+				return db.LoginFailure{Scope: arg.Scope, Key: arg.Key, FailureCount: failureCount}, nil
+			})
+	}
+
+	buildLockStubs := func(store *mockdb.MockStore, failureCount int32, duration time.Duration) {
+		store.EXPECT().
+			LockLoginTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, arg db.LockLoginTxParams) (db.LoginLockoutEvent, error) {
+				require.Equal(t, user_domain.LoginFailureScopeEmail, arg.Scope)
+				require.Equal(t, user.Email, arg.Key)
+				require.Equal(t, failureCount, arg.FailureCount)
+				require.WithinDuration(t, time.Now().Add(duration), arg.LockedUntil, time.Second)
+
+				return db.LoginLockoutEvent{
+					ID:           util.RandomUUID(),
+					Scope:        arg.Scope,
+					Key:          arg.Key,
+					FailureCount: arg.FailureCount,
+					LockedUntil:  arg.LockedUntil,
+				}, nil
+			})
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		password      string
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildNotLockedStubs(store)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Return(user, nil)
+
+				store.EXPECT().
+					DeleteLoginFailure(gomock.Any(), gomock.Eq(db.DeleteLoginFailureParams(emailFailureParams))).
+					Return(nil)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(db.Session{ID: util.RandomUUID(), UserID: user.ID}, nil)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "LockedByEmail",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(emailFailureParams)).
+					Return(db.LoginFailure{
+						Scope:        user_domain.LoginFailureScopeEmail,
+						Key:          user.Email,
+						FailureCount: 5,
+						LockedUntil:  sql.NullTime{Time: time.Now().Add(30 * time.Second), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{}, sql.ErrNoRows)
+
+				// The credentials are not checked while locked, even if they are correct
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "30", response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "LockedByIP",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(emailFailureParams)).
+					Return(db.LoginFailure{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{
+						Scope:        user_domain.LoginFailureScopeIP,
+						FailureCount: 20,
+						LockedUntil:  sql.NullTime{Time: time.Now().Add(5 * time.Minute), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "300", response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "LockExpired",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Eq(emailFailureParams)).
+					Return(db.LoginFailure{
+						Scope:        user_domain.LoginFailureScopeEmail,
+						Key:          user.Email,
+						FailureCount: 5,
+						LockedUntil:  sql.NullTime{Time: time.Now().Add(-time.Second), Valid: true},
+					}, nil)
+
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Eq(user.Email)).
+					Return(user, nil)
+
+				store.EXPECT().
+					DeleteLoginFailure(gomock.Any(), gomock.Any()).
+					Return(nil)
+
+				store.EXPECT().
+					CreateSession(gomock.Any(), gomock.Any()).
+					Return(db.Session{ID: util.RandomUUID(), UserID: user.ID}, nil)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name: "FailureBelowLimit",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildFailureStubs(store, 4)
+
+				store.EXPECT().
+					LockLoginTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+				require.Empty(t, response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "UnknownEmail",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildNotLockedStubs(store)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Return(db.User{}, sql.ErrNoRows)
+
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), gomock.Any()).
+					Times(2).
+					Return(db.LoginFailure{FailureCount: 1}, nil)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "FailureLocks",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildFailureStubs(store, 5)
+				buildLockStubs(store, 5, time.Minute)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "60", response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "BackoffDoubles",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildFailureStubs(store, 7)
+				buildLockStubs(store, 7, 4*time.Minute)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "240", response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "BackoffCapped",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildFailureStubs(store, 100)
+				buildLockStubs(store, 100, time.Hour)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+				require.Equal(t, "3600", response.Header.Get("Retry-After"))
+			},
+		},
+		{
+			name: "GetLoginFailureInternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{}, sql.ErrConnDone)
+			},
+			password: validPassword,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "RecordLoginFailureInternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildNotLockedStubs(store)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Return(user, nil)
+
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{}, sql.ErrConnDone)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+		{
+			name: "LockLoginInternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				buildNotLockedStubs(store)
+
+				store.EXPECT().
+					GetUserByEmail(gomock.Any(), gomock.Any()).
+					Return(user, nil)
+
+				store.EXPECT().
+					RecordLoginFailure(gomock.Any(), gomock.Any()).
+					Return(db.LoginFailure{
+						Scope:        user_domain.LoginFailureScopeEmail,
+						Key:          user.Email,
+						FailureCount: 5,
+					}, nil)
+
+				store.EXPECT().
+					LockLoginTx(gomock.Any(), gomock.Any()).
+					Return(db.LoginLockoutEvent{}, sql.ErrConnDone)
+			},
+			password: "wrong-password",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := test_util.NewMockStore(t)
+			defer cleanupStore()
+
+			tc.buildStubs(store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/users/login",
+				Body: test_util.Body{
+					"email":    user.Email,
+					"password": tc.password,
+				},
+			})
+
+			server, err := NewServer(util.Config{
+				SessionTokenDuration: time.Minute,
+				LoginLockout:         lockout,
+			}, store)
+			require.NoError(t, err)
+
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestLoginAPINormalizesLockoutEmail(t *testing.T) {
+	store, cleanupStore := test_util.NewMockStore(t)
+	defer cleanupStore()
+
+	// Variants of the same address share the failure counter of the normalized address
+	emailFailureParams := db.GetLoginFailureParams{
+		Scope: user_domain.LoginFailureScopeEmail,
+		Key:   "test@example.com",
+	}
+
+	store.EXPECT().
+		GetLoginFailure(gomock.Any(), gomock.Eq(emailFailureParams)).
+		Return(db.LoginFailure{}, sql.ErrNoRows)
+
+	store.EXPECT().
+		GetUserByEmail(gomock.Any(), gomock.Any()).
+		Return(db.User{}, sql.ErrNoRows)
+
+	store.EXPECT().
+		RecordLoginFailure(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, arg db.RecordLoginFailureParams) (db.LoginFailure, error) {
+			require.Equal(t, emailFailureParams.Scope, arg.Scope)
+			require.Equal(t, emailFailureParams.Key, arg.Key)
+			return db.LoginFailure{Scope: arg.Scope, Key: arg.Key, FailureCount: 1}, nil
+		})
+
+	request := test_util.NewRequest(t, test_util.RequestParams{
+		Method: http.MethodPost,
+		URL:    "/api/v1/users/login",
+		Body: test_util.Body{
+			"email":    "Test@Example.COM",
+			"password": "test-password",
+		},
+	})
+
+	server, err := NewServer(util.Config{
+		SessionTokenDuration: time.Minute,
+		LoginLockout: util.LoginLockoutConfig{
+			MaxAttemptsPerEmail: 5,
+			FailureWindow:       15 * time.Minute,
+			LockoutDuration:     time.Minute,
+			MaxLockoutDuration:  time.Hour,
+		},
+	}, store)
+	require.NoError(t, err)
+
+	response := test_util.SendRequest(t, server.app, request)
+	require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestLoginAPILockout(t *testing.T) {
+	validEmail := "test@example.com"
+	validPassword := "test-password"
+
+	store, cleanupStore := test_util.BuildTestDBStore(t)
+	defer cleanupStore()
+
+	_ = test_util.CreateWithSessionUser(t, context.Background(), store, test_util.WithSessionUserParams{
+		Name:         "testuser",
+		Email:        validEmail,
+		Password:     validPassword,
+		SessionToken: token.NewToken(time.Minute),
+		RefreshToken: token.NewToken(time.Minute),
+	})
+
+	server, err := NewServer(util.Config{
+		SessionTokenDuration: time.Minute,
+		LoginLockout: util.LoginLockoutConfig{
+			MaxAttemptsPerEmail: 3,
+			FailureWindow:       time.Minute,
+			LockoutDuration:     time.Minute,
+			MaxLockoutDuration:  time.Hour,
+		},
+	}, store)
+	require.NoError(t, err)
+
+	sendLogin := func(email string, password string) *http.Response {
+		request := test_util.NewRequest(t, test_util.RequestParams{
+			Method: http.MethodPost,
+			URL:    "/api/v1/users/login",
+			Body: test_util.Body{
+				"email":    email,
+				"password": password,
+			},
+		})
+
+		return test_util.SendRequest(t, server.app, request)
+	}
+
+	// Failures with a differently cased email count for the same account
+	for _, email := range []string{validEmail, strings.ToUpper(validEmail)} {
+		response := sendLogin(email, "wrong-password")
+		require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	}
+
+	response := sendLogin(validEmail, "wrong-password")
+	require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+	require.Equal(t, "60", response.Header.Get("Retry-After"))
+
+	// The correct password is rejected as well until the lockout has passed
+	response = sendLogin(validEmail, validPassword)
+	require.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+
+	events, err := store.ListLoginLockoutEvents(context.Background(), db.ListLoginLockoutEventsParams{
+		Scope: user_domain.LoginFailureScopeEmail,
+		Key:   validEmail,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int32(3), events[0].FailureCount)
+}
+
 func TestLogoutAPI(t *testing.T) {
 	sessionToken := token.NewToken(time.Minute)
 	refreshToken := token.NewToken(time.Minute)
@@ -374,7 +986,7 @@ func TestLogoutAPI(t *testing.T) {
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
 			setupAuth: func(request *http.Request, sessionToken string) {
-				test_util.AddSessionTokenInCookie(request, util.RandomUUID().String())
+				test_util.AddSessionTokenInCookie(request, token.NewToken(time.Minute).Value)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
@@ -385,16 +997,17 @@ func TestLogoutAPI(t *testing.T) {
 			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
 				mockStore, cleanup := test_util.NewMockStore(t)
 
-				test_util.BuildValidSessionStubs(mockStore, db.Session{
+				session := db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
-				})
+				}
+				test_util.BuildValidSessionStubs(mockStore, session)
 
 				mockStore.EXPECT().
-					DeleteSession(gomock.Any(), gomock.Any()).
+					DeleteSessionByID(gomock.Any(), gomock.Eq(session.ID)).
 					Return(sql.ErrConnDone)
 
 				return mockStore, cleanup
@@ -421,7 +1034,7 @@ func TestLogoutAPI(t *testing.T) {
 				URL:    "/api/v1/users/logout",
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -465,6 +1078,7 @@ func TestGetCurrentUserAPI(t *testing.T) {
 
 				require.Equal(t, "testuser", gotUser.Name)
 				require.Equal(t, "test@example.com", gotUser.Email)
+				require.Equal(t, "customer", gotUser.Role)
 			},
 		},
 		{
@@ -481,7 +1095,7 @@ func TestGetCurrentUserAPI(t *testing.T) {
 			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
 			setupAuth: func(request *http.Request, sessionToken string) {
-				test_util.AddSessionTokenInCookie(request, util.RandomUUID().String())
+				test_util.AddSessionTokenInCookie(request, token.NewToken(time.Minute).Value)
 			},
 			checkResponse: func(t *testing.T, response *http.Response) {
 				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
@@ -495,7 +1109,7 @@ func TestGetCurrentUserAPI(t *testing.T) {
 				session := db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          sessionToken.ID,
+					SessionTokenHash:      sessionToken.Hash(),
 					SessionTokenExpiredAt: sessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				}
@@ -530,7 +1144,7 @@ func TestGetCurrentUserAPI(t *testing.T) {
 				URL:    "/api/v1/users/me",
 			})
 
-			tc.setupAuth(request, sessionToken.ID.String())
+			tc.setupAuth(request, sessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -608,7 +1222,7 @@ func TestUpdateCurrentUserAPI(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          validSessionToken.ID,
+					SessionTokenHash:      validSessionToken.Hash(),
 					SessionTokenExpiredAt: validSessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
@@ -674,7 +1288,7 @@ func TestUpdateCurrentUserAPI(t *testing.T) {
 			},
 			setupAuth: test_util.AddSessionTokenInCookie,
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusForbidden, response.StatusCode)
+				require.Equal(t, http.StatusConflict, response.StatusCode)
 			},
 			allowParallel: false,
 		},
@@ -688,7 +1302,7 @@ func TestUpdateCurrentUserAPI(t *testing.T) {
 			},
 			setupAuth: test_util.AddSessionTokenInCookie,
 			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusForbidden, response.StatusCode)
+				require.Equal(t, http.StatusConflict, response.StatusCode)
 			},
 			allowParallel: false,
 		},
@@ -769,7 +1383,7 @@ func TestUpdateCurrentUserAPI(t *testing.T) {
 				Body:   tc.body,
 			})
 
-			tc.setupAuth(request, validSessionToken.ID.String())
+			tc.setupAuth(request, validSessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -830,7 +1444,7 @@ func TestUpdateCurrentUserPasswordAPI(t *testing.T) {
 				test_util.BuildValidSessionStubs(mockStore, db.Session{
 					ID:                    util.RandomUUID(),
 					UserID:                util.RandomUUID(),
-					SessionToken:          validSessionToken.ID,
+					SessionTokenHash:      validSessionToken.Hash(),
 					SessionTokenExpiredAt: validSessionToken.ExpiredAt,
 					CreatedAt:             time.Now(),
 				})
@@ -861,21 +1475,102 @@ func TestUpdateCurrentUserPasswordAPI(t *testing.T) {
 			allowParallel: false,
 		},
 		{
-			name:           "OldPasswordNotFound",
-			buildStore:     test_util.BuildTestDBStore,
-			createSeedData: defaultCreateSeedData,
-			body: test_util.Body{
-				"new_password": validNewPassword,
-			},
-			setupAuth: test_util.AddSessionTokenInCookie,
-			checkResponse: func(t *testing.T, response *http.Response) {
-				require.Equal(t, http.StatusBadRequest, response.StatusCode)
-			},
-			allowParallel: false,
-		},
-		{
-			name:           "NewPasswordNotFound",
-			buildStore:     test_util.BuildTestDBStore,
+			name: "RevokeOtherSessions",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				session := db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      validSessionToken.Hash(),
+					SessionTokenExpiredAt: validSessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				hashedOldPassword, err := util.HashPassword(validOldPassword)
+				require.NoError(t, err)
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(session.UserID)).
+					Return(db.User{ID: session.UserID, HashedPassword: hashedOldPassword}, nil)
+
+				mockStore.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(db.User{}, nil)
+
+				mockStore.EXPECT().
+					DeleteOtherSessionsByUserID(gomock.Any(), gomock.Eq(db.DeleteOtherSessionsByUserIDParams{
+						UserID:           session.UserID,
+						CurrentSessionID: session.ID,
+					})).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+			allowParallel: true,
+		},
+		{
+			name: "RevokeOtherSessionsInternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				session := db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      validSessionToken.Hash(),
+					SessionTokenExpiredAt: validSessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				hashedOldPassword, err := util.HashPassword(validOldPassword)
+				require.NoError(t, err)
+
+				mockStore.EXPECT().
+					GetUser(gomock.Any(), gomock.Any()).
+					Return(db.User{ID: session.UserID, HashedPassword: hashedOldPassword}, nil)
+
+				mockStore.EXPECT().
+					UpdateUser(gomock.Any(), gomock.Any()).
+					Return(db.User{}, nil)
+
+				mockStore.EXPECT().
+					DeleteOtherSessionsByUserID(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+			allowParallel: true,
+		},
+		{
+			name:           "OldPasswordNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body: test_util.Body{
+				"new_password": validNewPassword,
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+			allowParallel: false,
+		},
+		{
+			name:           "NewPasswordNotFound",
+			buildStore:     test_util.BuildTestDBStore,
 			createSeedData: defaultCreateSeedData,
 			body: test_util.Body{
 				"old_password": validOldPassword,
@@ -949,7 +1644,7 @@ func TestUpdateCurrentUserPasswordAPI(t *testing.T) {
 				Body:   tc.body,
 			})
 
-			tc.setupAuth(request, validSessionToken.ID.String())
+			tc.setupAuth(request, validSessionToken.Value)
 
 			server := newTestServer(t, store)
 			response := test_util.SendRequest(t, server.app, request)
@@ -958,11 +1653,941 @@ func TestUpdateCurrentUserPasswordAPI(t *testing.T) {
 	}
 }
 
-func unmarshalUserResponse(t *testing.T, body io.ReadCloser) user_domain.UserResponse {
-	data, err := io.ReadAll(body)
-	require.NoError(t, err)
+func TestListCurrentUserSessionsAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
 
-	var parsed user_domain.UserResponse
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+
+		otherSessionToken := token.NewToken(time.Minute)
+		otherRefreshToken := token.NewToken(time.Minute)
+
+		_, err := store.CreateSession(ctx, db.CreateSessionParams{
+			UserID:                user.ID,
+			SessionTokenHash:      otherSessionToken.Hash(),
+			SessionTokenExpiredAt: otherSessionToken.ExpiredAt,
+			RefreshTokenHash:      otherRefreshToken.Hash(),
+			RefreshTokenExpiredAt: otherRefreshToken.ExpiredAt,
+			UserAgent:             "test-user-agent",
+			ClientIp:              "192.0.2.1",
+		})
+		require.NoError(t, err)
+
+		expiredSessionToken := token.NewToken(-time.Minute)
+		expiredRefreshToken := token.NewToken(-time.Minute)
+
+		_, err = store.CreateSession(ctx, db.CreateSessionParams{
+			UserID:                user.ID,
+			SessionTokenHash:      expiredSessionToken.Hash(),
+			SessionTokenExpiredAt: expiredSessionToken.ExpiredAt,
+			RefreshTokenHash:      expiredRefreshToken.Hash(),
+			RefreshTokenExpiredAt: expiredRefreshToken.ExpiredAt,
+		})
+		require.NoError(t, err)
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalSessionsResponse(t, response.Body)
+				require.Len(t, gotResponse, 2)
+
+				currentCount := 0
+				for _, session := range gotResponse {
+					require.NotEmpty(t, session.ID)
+					require.NotZero(t, session.CreatedAt)
+					require.NotZero(t, session.LastUsedAt)
+
+					if session.Current {
+						currentCount++
+					} else {
+						require.Equal(t, "test-user-agent", session.UserAgent)
+						require.Equal(t, "192.0.2.1", session.IPAddress)
+					}
+				}
+				require.Equal(t, 1, currentCount)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					ListSessionsByUserID(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/users/me/sessions",
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestRevokeCurrentUserSessionAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	currentSession := db.Session{
+		ID:                    util.RandomUUID(),
+		UserID:                util.RandomUUID(),
+		SessionTokenHash:      sessionToken.Hash(),
+		SessionTokenExpiredAt: sessionToken.ExpiredAt,
+		CreatedAt:             time.Now(),
+	}
+	otherSessionID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		sessionID     string
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				mockStore.EXPECT().
+					GetSessionByID(gomock.Any(), gomock.Eq(otherSessionID)).
+					Return(db.Session{ID: otherSessionID, UserID: currentSession.UserID}, nil)
+
+				mockStore.EXPECT().
+					DeleteSessionByID(gomock.Any(), gomock.Eq(otherSessionID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			sessionID: otherSessionID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+				require.Empty(t, response.Cookies())
+			},
+		},
+		{
+			name: "CurrentSession",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				mockStore.EXPECT().
+					GetSessionByID(gomock.Any(), gomock.Eq(currentSession.ID)).
+					Return(currentSession, nil)
+
+				mockStore.EXPECT().
+					DeleteSessionByID(gomock.Any(), gomock.Eq(currentSession.ID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			sessionID: currentSession.ID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+
+				cookies := response.Cookies()
+				require.Len(t, cookies, 2)
+				require.Equal(t, cookieSessionTokenKey, cookies[0].Name)
+				require.Empty(t, cookies[0].Value)
+				require.Equal(t, cookieRefreshTokenKey, cookies[1].Name)
+				require.Empty(t, cookies[1].Value)
+			},
+		},
+		{
+			name: "OtherUserSession",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				mockStore.EXPECT().
+					GetSessionByID(gomock.Any(), gomock.Eq(otherSessionID)).
+					Return(db.Session{ID: otherSessionID, UserID: util.RandomUUID()}, nil)
+
+				mockStore.EXPECT().
+					DeleteSessionByID(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
+			},
+			sessionID: otherSessionID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "SessionNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				mockStore.EXPECT().
+					GetSessionByID(gomock.Any(), gomock.Any()).
+					Return(db.Session{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			sessionID: otherSessionID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "InvalidID",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				return mockStore, cleanup
+			},
+			sessionID: "invalid",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, currentSession)
+
+				mockStore.EXPECT().
+					GetSessionByID(gomock.Any(), gomock.Any()).
+					Return(db.Session{ID: otherSessionID, UserID: currentSession.UserID}, nil)
+
+				mockStore.EXPECT().
+					DeleteSessionByID(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			sessionID: otherSessionID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/users/me/sessions/%s", tc.sessionID),
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestRevokeAllCurrentUserSessionsAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		ctx := context.Background()
+
+		_ = test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				cookies := response.Cookies()
+				require.Len(t, cookies, 2)
+				require.Equal(t, cookieSessionTokenKey, cookies[0].Name)
+				require.Empty(t, cookies[0].Value)
+				require.Equal(t, cookieRefreshTokenKey, cookies[1].Name)
+				require.Empty(t, cookies[1].Value)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				session := db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					DeleteSessionsByUserID(gomock.Any(), gomock.Eq(session.UserID)).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/users/me/sessions/revoke-all",
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestCreateCurrentUserAPIKeyAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		_ = test_util.CreateWithSessionUser(t, context.Background(), store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+	}
+
+	buildValidSessionStore := func(t *testing.T) (store db.Store, cleanup func()) {
+		mockStore, cleanup := test_util.NewMockStore(t)
+
+		test_util.BuildValidSessionStubs(mockStore, db.Session{
+			ID:                    util.RandomUUID(),
+			UserID:                util.RandomUUID(),
+			SessionTokenHash:      sessionToken.Hash(),
+			SessionTokenExpiredAt: sessionToken.ExpiredAt,
+			CreatedAt:             time.Now(),
+		})
+
+		mockStore.EXPECT().
+			CreateApiKey(gomock.Any(), gomock.Any()).
+			Times(0)
+
+		return mockStore, cleanup
+	}
+
+	defaultBody := test_util.Body{
+		"name":   "ci",
+		"scopes": []string{user_domain.APIKeyScopeCatalogRead, user_domain.APIKeyScopeCartManage},
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		body           test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalCreatedAPIKeyResponse(t, response.Body)
+				require.NotEmpty(t, gotResponse.ID)
+				require.Equal(t, "ci", gotResponse.Name)
+				require.Equal(t, []string{user_domain.APIKeyScopeCatalogRead, user_domain.APIKeyScopeCartManage}, gotResponse.Scopes)
+				require.False(t, gotResponse.LastUsedAt.Valid)
+				require.NotZero(t, gotResponse.CreatedAt)
+
+				_, err := token.ParseAPIKey(gotResponse.Key)
+				require.NoError(t, err)
+				require.True(t, strings.HasPrefix(gotResponse.Key, gotResponse.Prefix))
+				require.Less(t, len(gotResponse.Prefix), len(gotResponse.Key))
+			},
+		},
+		{
+			name: "StoresKeyHash",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				session := db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				}
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, arg db.CreateApiKeyParams) (db.ApiKey, error) {
+						require.Equal(t, session.UserID, arg.UserID)
+						require.Len(t, arg.KeyHash, 32)
+						require.True(t, strings.HasPrefix(arg.KeyPrefix, token.APIKeyPrefix))
+
+						return db.ApiKey{
+							ID:        util.RandomUUID(),
+							UserID:    arg.UserID,
+							Name:      arg.Name,
+							KeyPrefix: arg.KeyPrefix,
+							KeyHash:   arg.KeyHash,
+							Scopes:    arg.Scopes,
+							CreatedAt: time.Now(),
+						}, nil
+					})
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalCreatedAPIKeyResponse(t, response.Body)
+				require.NotEmpty(t, gotResponse.Key)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoName",
+			buildStore:     buildValidSessionStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			body: test_util.Body{
+				"scopes": []string{user_domain.APIKeyScopeCatalogRead},
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoScopes",
+			buildStore:     buildValidSessionStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			body: test_util.Body{
+				"name":   "ci",
+				"scopes": []string{},
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "InvalidScope",
+			buildStore:     buildValidSessionStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			body: test_util.Body{
+				"name":   "ci",
+				"scopes": []string{"admin"},
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "DuplicateScopes",
+			buildStore:     buildValidSessionStore,
+			createSeedData: test_util.NoopCreateSeedData,
+			body: test_util.Body{
+				"name":   "ci",
+				"scopes": []string{user_domain.APIKeyScopeCatalogRead, user_domain.APIKeyScopeCatalogRead},
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			body:           defaultBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/users/me/api-keys",
+				Body:   tc.body,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestListCurrentUserAPIKeysAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) {
+		ctx := context.Background()
+
+		user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+			Name:         "testuser",
+			Email:        "test@example.com",
+			Password:     "test-password",
+			SessionToken: sessionToken,
+			RefreshToken: refreshToken,
+		})
+
+		for _, name := range []string{"first", "second"} {
+			key, keyPrefix := token.NewAPIKey()
+
+			_, err := store.CreateApiKey(ctx, db.CreateApiKeyParams{
+				UserID:    user.ID,
+				Name:      name,
+				KeyPrefix: keyPrefix,
+				KeyHash:   token.HashToken(key),
+				Scopes:    []string{user_domain.APIKeyScopeCatalogRead},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store)
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				data, err := io.ReadAll(response.Body)
+				require.NoError(t, err)
+				require.NotContains(t, string(data), `"key"`)
+
+				var gotResponse user_domain.APIKeysResponse
+				err = json.Unmarshal(data, &gotResponse)
+				require.NoError(t, err)
+				require.Len(t, gotResponse, 2)
+
+				for _, apiKey := range gotResponse {
+					require.NotEmpty(t, apiKey.ID)
+					require.True(t, strings.HasPrefix(apiKey.Prefix, token.APIKeyPrefix))
+					require.Equal(t, []string{user_domain.APIKeyScopeCatalogRead}, apiKey.Scopes)
+				}
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					ListApiKeysByUserID(gomock.Any(), gomock.Any()).
+					Return(nil, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/users/me/api-keys",
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestDeleteCurrentUserAPIKeyAPI(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+
+	session := db.Session{
+		ID:                    util.RandomUUID(),
+		UserID:                util.RandomUUID(),
+		SessionTokenHash:      sessionToken.Hash(),
+		SessionTokenExpiredAt: sessionToken.ExpiredAt,
+		CreatedAt:             time.Now(),
+	}
+	apiKeyID := util.RandomUUID()
+
+	testCases := []struct {
+		name          string
+		buildStore    func(t *testing.T) (store db.Store, cleanup func())
+		apiKeyID      string
+		checkResponse func(t *testing.T, response *http.Response)
+	}{
+		{
+			name: "OK",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					GetApiKey(gomock.Any(), gomock.Eq(apiKeyID)).
+					Return(db.ApiKey{ID: apiKeyID, UserID: session.UserID}, nil)
+
+				mockStore.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Eq(apiKeyID)).
+					Return(nil)
+
+				return mockStore, cleanup
+			},
+			apiKeyID: apiKeyID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+			},
+		},
+		{
+			name: "OtherUserAPIKey",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					GetApiKey(gomock.Any(), gomock.Eq(apiKeyID)).
+					Return(db.ApiKey{ID: apiKeyID, UserID: util.RandomUUID()}, nil)
+
+				mockStore.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+
+				return mockStore, cleanup
+			},
+			apiKeyID: apiKeyID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "APIKeyNotFound",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					GetApiKey(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{}, sql.ErrNoRows)
+
+				return mockStore, cleanup
+			},
+			apiKeyID: apiKeyID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name: "InvalidID",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				return mockStore, cleanup
+			},
+			apiKeyID: "invalid",
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, session)
+
+				mockStore.EXPECT().
+					GetApiKey(gomock.Any(), gomock.Any()).
+					Return(db.ApiKey{ID: apiKeyID, UserID: session.UserID}, nil)
+
+				mockStore.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			apiKeyID: apiKeyID.String(),
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/users/me/api-keys/%s", tc.apiKeyID),
+			})
+
+			test_util.AddSessionTokenInCookie(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func unmarshalUserResponse(t *testing.T, body io.ReadCloser) user_domain.UserResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed user_domain.UserResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalSessionsResponse(t *testing.T, body io.ReadCloser) user_domain.SessionsResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed user_domain.SessionsResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalCreatedAPIKeyResponse(t *testing.T, body io.ReadCloser) user_domain.CreatedAPIKeyResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed user_domain.CreatedAPIKeyResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
+
+func unmarshalValidationErrorResponse(t *testing.T, body io.ReadCloser) validation.ErrorResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed validation.ErrorResponse
 	err = json.Unmarshal(data, &parsed)
 	require.NoError(t, err)
 
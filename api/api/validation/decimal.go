@@ -1,6 +1,8 @@
 package validation
 
 import (
+	"reflect"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 )
@@ -27,3 +29,65 @@ func isDecimalGt(fl validator.FieldLevel) bool {
 
 	return fieldDecimal.GreaterThan(paramDecimal)
 }
+
+func isDecimalGte(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	param := fl.Param()
+
+	fieldDecimal, err := decimal.NewFromString(field.String())
+	if err != nil {
+		return false
+	}
+
+	paramDecimal, err := decimal.NewFromString(param)
+	if err != nil {
+		return false
+	}
+
+	return fieldDecimal.GreaterThanOrEqual(paramDecimal)
+}
+
+// isDecimalGteField checks if the field value is greater than or equal to the value of the other field in the param.
+// The param is the name of the other field in the request as returned by fieldName, not its name in the struct,
+// so that the error message and param refer to the field the client sent.
+// It passes when the other field is empty or not a decimal, since that field is expected to be validated on its own.
+func isDecimalGteField(fl validator.FieldLevel) bool {
+	field := fl.Field()
+
+	otherField, ok := structFieldByName(fl.Parent(), fl.Param())
+	if !ok {
+		return false
+	}
+
+	otherDecimal, err := decimal.NewFromString(otherField.String())
+	if err != nil {
+		return true
+	}
+
+	fieldDecimal, err := decimal.NewFromString(field.String())
+	if err != nil {
+		return false
+	}
+
+	return fieldDecimal.GreaterThanOrEqual(otherDecimal)
+}
+
+// structFieldByName returns the field of the struct whose name in the request is the given name.
+func structFieldByName(parent reflect.Value, name string) (reflect.Value, bool) {
+	for parent.Kind() == reflect.Pointer {
+		if parent.IsNil() {
+			return reflect.Value{}, false
+		}
+		parent = parent.Elem()
+	}
+	if parent.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for i := 0; i < parent.NumField(); i++ {
+		if fieldName(parent.Type().Field(i)) == name {
+			return reflect.Indirect(parent.Field(i)), true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,71 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// CodeValidationFailed is the code of errors where some fields of the request are invalid
+	CodeValidationFailed = "validation_failed"
+	// CodeInvalidRequest is the code of errors where the request could not be parsed
+	CodeInvalidRequest = "invalid_request"
+)
+
+// FieldError describes a field of the request that failed a validation rule.
+type FieldError struct {
+	// Field is the JSON, query or path parameter name of the field, e.g. "scopes[0]" for an element of a slice
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the response of a request that could not be parsed or failed validation.
+type ErrorResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields"`
+}
+
+// NewErrorResponse converts the error of parsing or validating a request to an ErrorResponse.
+// The messages of the fields are translated to the first supported language of the Accept-Language header value.
+func NewErrorResponse(err error, acceptLanguage string) ErrorResponse {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Message: err.Error(),
+			Fields:  []FieldError{},
+		}
+	}
+
+	translator := findTranslator(acceptLanguage)
+
+	fields := make([]FieldError, len(validationErrors))
+	for i, fe := range validationErrors {
+		fields[i] = FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: fe.Translate(translator),
+		}
+	}
+
+	return ErrorResponse{
+		Code:    CodeValidationFailed,
+		Message: "request validation failed",
+		Fields:  fields,
+	}
+}
+
+// fieldPath returns the namespace of the field without the name of the request struct
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+	return path
+}
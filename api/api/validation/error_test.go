@@ -0,0 +1,118 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name     string   `json:"name" validate:"required,without_symbol"`
+	Price    string   `json:"price" validate:"decimal,decimal_gt=0"`
+	Tags     []string `json:"tags" validate:"dive,without_space"`
+	Page     int32    `query:"page" validate:"min=1"`
+	Internal string   `json:"-" validate:"required"`
+}
+
+func TestNewErrorResponse(t *testing.T) {
+	req := testRequest{
+		Name:  "name$",
+		Price: "-1",
+		Tags:  []string{"ok", "not ok"},
+		Page:  0,
+	}
+
+	err := NewValidator().Struct(req)
+	require.Error(t, err)
+
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       []FieldError
+	}{
+		{
+			name:           "English",
+			acceptLanguage: "",
+			expected: []FieldError{
+				{Field: "name", Rule: "without_symbol", Param: "", Message: "name must not contain symbols"},
+				{Field: "price", Rule: "decimal_gt", Param: "0", Message: "price must be greater than 0"},
+				{Field: "tags[1]", Rule: "without_space", Param: "", Message: "tags[1] must not contain spaces"},
+				{Field: "page", Rule: "min", Param: "1", Message: "page must be 1 or greater"},
+				{Field: "Internal", Rule: "required", Param: "", Message: "Internal is a required field"},
+			},
+		},
+		{
+			name:           "Japanese",
+			acceptLanguage: "ja;q=0.9, en;q=0.8",
+			expected: []FieldError{
+				{Field: "name", Rule: "without_symbol", Param: "", Message: "nameに記号を含めることはできません"},
+				{Field: "price", Rule: "decimal_gt", Param: "0", Message: "priceは0より大きくなければなりません"},
+				{Field: "tags[1]", Rule: "without_space", Param: "", Message: "tags[1]にスペースを含めることはできません"},
+				{Field: "page", Rule: "min", Param: "1", Message: "pageは1以上でなければなりません"},
+				{Field: "Internal", Rule: "required", Param: "", Message: "Internalは必須フィールドです"},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			response := NewErrorResponse(err, tc.acceptLanguage)
+			require.Equal(t, CodeValidationFailed, response.Code)
+			require.Equal(t, tc.expected, response.Fields)
+		})
+	}
+}
+
+func TestNewErrorResponseFieldParam(t *testing.T) {
+	type priceRangeRequest struct {
+		MinPrice string `query:"min_price" validate:"omitempty,decimal"`
+		MaxPrice string `query:"max_price" validate:"omitempty,decimal,decimal_gtefield=min_price"`
+	}
+
+	err := NewValidator().Struct(&priceRangeRequest{MinPrice: "50", MaxPrice: "10"})
+	require.Error(t, err)
+
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       []FieldError
+	}{
+		{
+			name:           "English",
+			acceptLanguage: "",
+			expected: []FieldError{
+				{Field: "max_price", Rule: "decimal_gtefield", Param: "min_price", Message: "max_price must be greater than or equal to min_price"},
+			},
+		},
+		{
+			name:           "Japanese",
+			acceptLanguage: "ja",
+			expected: []FieldError{
+				{Field: "max_price", Rule: "decimal_gtefield", Param: "min_price", Message: "max_priceはmin_price以上でなければなりません"},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			response := NewErrorResponse(err, tc.acceptLanguage)
+			require.Equal(t, CodeValidationFailed, response.Code)
+			require.Equal(t, tc.expected, response.Fields)
+		})
+	}
+
+	require.NoError(t, NewValidator().Struct(&priceRangeRequest{MinPrice: "10", MaxPrice: "50"}))
+	require.NoError(t, NewValidator().Struct(&priceRangeRequest{MaxPrice: "10"}))
+}
+
+func TestNewErrorResponseInvalidRequest(t *testing.T) {
+	response := NewErrorResponse(errors.New("unexpected end of JSON input"), "")
+	require.Equal(t, CodeInvalidRequest, response.Code)
+	require.Equal(t, "unexpected end of JSON input", response.Message)
+	require.Empty(t, response.Fields)
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+)
+
+// universalTranslator holds the translators of the supported locales. English is the fallback.
+var universalTranslator = ut.New(en.New(), en.New(), ja.New())
+
+// customTagMessages contains the messages of the custom validations for each supported locale.
+// {0} is replaced with the field name and {1} with the param of the tag. The params of the tags comparing fields,
+// like decimal_gtefield, are the names of the other fields in the request.
+var customTagMessages = map[string]map[string]string{
+	"en": {
+		"without_space":    "{0} must not contain spaces",
+		"without_punct":    "{0} must not contain punctuation marks",
+		"without_symbol":   "{0} must not contain symbols",
+		"decimal":          "{0} must be a decimal number",
+		"decimal_gt":       "{0} must be greater than {1}",
+		"decimal_gte":      "{0} must be {1} or greater",
+		"decimal_gtefield": "{0} must be greater than or equal to {1}",
+	},
+	"ja": {
+		"without_space":    "{0}にスペースを含めることはできません",
+		"without_punct":    "{0}に句読点を含めることはできません",
+		"without_symbol":   "{0}に記号を含めることはできません",
+		"decimal":          "{0}は10進数でなければなりません",
+		"decimal_gt":       "{0}は{1}より大きくなければなりません",
+		"decimal_gte":      "{0}は{1}以上でなければなりません",
+		"decimal_gtefield": "{0}は{1}以上でなければなりません",
+	},
+}
+
+// registerTranslations registers the messages of the built-in and custom validations
+// for every supported locale to the given validator.Validate instance.
+func registerTranslations(v *validator.Validate) {
+	enTranslator, _ := universalTranslator.GetTranslator("en")
+	if err := en_translations.RegisterDefaultTranslations(v, enTranslator); err != nil {
+		panic(err)
+	}
+
+	jaTranslator, _ := universalTranslator.GetTranslator("ja")
+	if err := ja_translations.RegisterDefaultTranslations(v, jaTranslator); err != nil {
+		panic(err)
+	}
+
+	for locale, messages := range customTagMessages {
+		translator, _ := universalTranslator.GetTranslator(locale)
+
+		for tag, message := range messages {
+			err := v.RegisterTranslation(tag, translator, registerMessage(tag, message), translateWithParam)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func registerMessage(tag string, message string) validator.RegisterTranslationsFunc {
+	return func(trans ut.Translator) error {
+		return trans.Add(tag, message, true)
+	}
+}
+
+func translateWithParam(trans ut.Translator, fe validator.FieldError) string {
+	message, err := trans.T(fe.Tag(), fe.Field(), fe.Param())
+	if err != nil {
+		return fe.Error()
+	}
+	return message
+}
+
+// findTranslator returns the translator of the first supported locale in the Accept-Language header value,
+// or the English translator if there is none.
+func findTranslator(acceptLanguage string) ut.Translator {
+	var locales []string
+
+	for _, language := range strings.Split(acceptLanguage, ",") {
+		tag := strings.TrimSpace(strings.SplitN(language, ";", 2)[0])
+		if len(tag) == 0 {
+			continue
+		}
+
+		// Locales are named like "en_US", while language tags are like "en-US"
+		locale := strings.ReplaceAll(tag, "-", "_")
+		locales = append(locales, locale)
+		if base, _, found := strings.Cut(locale, "_"); found {
+			locales = append(locales, base)
+		}
+	}
+
+	translator, _ := universalTranslator.FindTranslator(locales...)
+	return translator
+}
@@ -1,9 +1,16 @@
 package validation
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 )
 
+// validate is shared by all requests, since a validator.Validate instance caches the parsed structs
+// and is safe for concurrent use.
+var validate = newValidator()
+
 // registerValidations registers multiple custom validations to the given validator.Validate instance.
 func registerValidations(v *validator.Validate) {
 	v.RegisterValidation("without_space", withoutSpace)
@@ -12,11 +19,34 @@ func registerValidations(v *validator.Validate) {
 
 	v.RegisterValidation("decimal", isDecimal)
 	v.RegisterValidation("decimal_gt", isDecimalGt)
+	v.RegisterValidation("decimal_gte", isDecimalGte)
+	v.RegisterValidation("decimal_gtefield", isDecimalGteField)
 }
 
-// NewValidator func for create a new validator for api requests.
-func NewValidator() *validator.Validate {
+// fieldName returns the name of the field in the request, so that errors can be mapped to the fields
+// of the client. The field name of the struct is used if the field has no name tag.
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query", "params", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if len(name) > 0 {
+			return name
+		}
+	}
+	return ""
+}
+
+func newValidator() *validator.Validate {
 	v := validator.New()
+	v.RegisterTagNameFunc(fieldName)
 	registerValidations(v)
+	registerTranslations(v)
 	return v
 }
+
+// NewValidator func for create a new validator for api requests.
+func NewValidator() *validator.Validate {
+	return validate
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ot07/next-bazaar/api/apperror"
+	wishlist_domain "github.com/ot07/next-bazaar/api/domain/wishlist"
+	"github.com/ot07/next-bazaar/api/validation"
+)
+
+type wishlistHandler struct {
+	service *wishlist_domain.WishlistService
+}
+
+func newWishlistHandler(s *wishlist_domain.WishlistService) *wishlistHandler {
+	return &wishlistHandler{
+		service: s,
+	}
+}
+
+// @Summary      Get wishlist
+// @Tags         Wishlist
+// @Success      200 {object} wishlist_domain.WishlistResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /wishlist [get]
+func (h *wishlistHandler) getWishlist(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	wishlistProducts, err := h.service.GetProductsByUserID(c.UserContext(), session.UserID)
+	if err != nil {
+		return err
+	}
+
+	rsp := wishlist_domain.NewWishlistResponse(wishlistProducts)
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Add product to wishlist
+// @Tags         Wishlist
+// @Param        body body wishlist_domain.AddProductRequest true "Wishlist product object"
+// @Success      200 {object} messageResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /wishlist [post]
+func (h *wishlistHandler) addProduct(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(wishlist_domain.AddProductRequest)
+	if err := c.BodyParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.AddProduct(c.UserContext(), wishlist_domain.AddProductServiceParams{
+		UserID:    session.UserID,
+		ProductID: req.ProductID,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := newMessageResponse("Wishlist product added successfully")
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
+
+// @Summary      Delete wishlist product
+// @Tags         Wishlist
+// @Param        product_id path string true "Product ID"
+// @Success      204
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      500 {object} errorResponse
+// @Router       /wishlist/{product_id} [delete]
+func (h *wishlistHandler) deleteProduct(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(wishlist_domain.DeleteProductRequest)
+	if err := c.ParamsParser(req); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.DeleteProduct(c.UserContext(), wishlist_domain.DeleteProductServiceParams{
+		UserID:    session.UserID,
+		ProductID: req.ProductID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary      Move wishlist product to cart
+// @Tags         Wishlist
+// @Param        product_id path string true "Product ID"
+// @Param        body body wishlist_domain.MoveProductToCartRequestBody true "Cart product object"
+// @Success      200 {object} messageResponse
+// @Failure      400 {object} errorResponse
+// @Failure      401 {object} errorResponse
+// @Failure      404 {object} errorResponse
+// @Failure      409 {object} insufficientStockResponse
+// @Failure      500 {object} errorResponse
+// @Router       /wishlist/{product_id}/move-to-cart [post]
+func (h *wishlistHandler) moveProductToCart(c *fiber.Ctx) error {
+	session, err := getSession(c)
+	if err != nil {
+		return err
+	}
+
+	reqParams := new(wishlist_domain.MoveProductToCartRequestParams)
+	if err := c.ParamsParser(reqParams); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	reqBody := new(wishlist_domain.MoveProductToCartRequestBody)
+	if err := c.BodyParser(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	validate := validation.NewValidator()
+	if err := validate.Struct(reqBody); err != nil {
+		return apperror.InvalidRequest(err)
+	}
+
+	err = h.service.MoveProductToCart(c.UserContext(), wishlist_domain.MoveProductToCartServiceParams{
+		UserID:    session.UserID,
+		ProductID: reqParams.ProductID,
+		Quantity:  reqBody.Quantity,
+	})
+	if err != nil {
+		return err
+	}
+
+	rsp := newMessageResponse("Wishlist product moved to cart successfully")
+	return c.Status(fiber.StatusOK).JSON(rsp)
+}
@@ -0,0 +1,605 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	wishlist_domain "github.com/ot07/next-bazaar/api/domain/wishlist"
+	"github.com/ot07/next-bazaar/api/test_util"
+	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/token"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// createWishlistSeedData creates a user with a session and a product with the given stock.
+// The product is added to the user's wishlist if inWishlist is true.
+func createWishlistSeedData(
+	t *testing.T,
+	store db.Store,
+	sessionToken, refreshToken *token.Token,
+	stockQuantity int32,
+	inWishlist bool,
+) test_util.SeedData {
+	ctx := context.Background()
+
+	user := test_util.CreateWithSessionUser(t, ctx, store, test_util.WithSessionUserParams{
+		Name:         "testuser",
+		Email:        "test@example.com",
+		Password:     "test-password",
+		SessionToken: sessionToken,
+		RefreshToken: refreshToken,
+	})
+
+	category, err := store.CreateCategory(ctx, "test-category")
+	require.NoError(t, err)
+
+	product, err := store.CreateProduct(ctx, db.CreateProductParams{
+		Name:          "test-product",
+		Description:   sql.NullString{String: "test-description", Valid: true},
+		Price:         "100.00",
+		StockQuantity: stockQuantity,
+		CategoryID:    category.ID,
+		SellerID:      user.ID,
+		ImageUrl:      sql.NullString{String: "test-image-url", Valid: true},
+	})
+	require.NoError(t, err)
+
+	if inWishlist {
+		_, err = store.CreateWishlistProduct(ctx, db.CreateWishlistProductParams{
+			UserID:    user.ID,
+			ProductID: product.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	return test_util.SeedData{
+		"user_id":    user.ID.String(),
+		"product_id": product.ID.String(),
+	}
+}
+
+func TestGetWishlist(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, true)
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalWishlistResponse(t, response.Body)
+
+				require.Equal(t, 1, len(gotResponse.Products))
+
+				require.Equal(t, "test-product", gotResponse.Products[0].Name)
+				require.True(t, decimal.NewFromFloat(100.00).Equal(gotResponse.Products[0].Price.Decimal))
+				require.Equal(t, int32(10), gotResponse.Products[0].StockQuantity)
+				require.Equal(t, "test-image-url", gotResponse.Products[0].ImageUrl.NullString.String)
+				require.NotZero(t, gotResponse.Products[0].AddedAt)
+			},
+		},
+		{
+			name:       "Empty",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, false)
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				gotResponse := unmarshalWishlistResponse(t, response.Body)
+				require.NotNil(t, gotResponse.Products)
+				require.Empty(t, gotResponse.Products)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetWishlistProductsByUserID(gomock.Any(), gomock.Any()).
+					Return([]db.WishlistProduct{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodGet,
+				URL:    "/api/v1/wishlist",
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestAddWishlistProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, false)
+	}
+
+	defaultCreateBody := func(seedData test_util.SeedData) test_util.Body {
+		return test_util.Body{
+			"product_id": seedData["product_id"].(string),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		createBody     func(seedData test_util.SeedData) test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, response *http.Response)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+			},
+		},
+		{
+			name:       "AlreadyInWishlist",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, true)
+			},
+			createBody: defaultCreateBody,
+			setupAuth:  test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody:     defaultCreateBody,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name:           "ProductNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "ProductIDNotFound",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					GetProduct(gomock.Any(), gomock.Any()).
+					Return(db.Product{}, nil)
+
+				mockStore.EXPECT().
+					CreateWishlistProduct(gomock.Any(), gomock.Any()).
+					Return(db.WishlistProduct{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: test_util.NoopCreateAndReturnSeed,
+			createBody: func(seedData test_util.SeedData) test_util.Body {
+				return test_util.Body{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, response *http.Response) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    "/api/v1/wishlist",
+				Body:   tc.createBody(seedData),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, response)
+		})
+	}
+}
+
+func TestDeleteWishlistProduct(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, true)
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNoContent, response.StatusCode)
+
+				wishlistProducts, err := store.GetWishlistProductsByUserID(
+					context.Background(),
+					uuid.MustParse(seedData["user_id"].(string)),
+				)
+				require.NoError(t, err)
+				require.Empty(t, wishlistProducts)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					DeleteWishlistProduct(gomock.Any(), gomock.Any()).
+					Return(sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodDelete,
+				URL:    fmt.Sprintf("/api/v1/wishlist/%s", seedData["product_id"].(string)),
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, store, response, seedData)
+		})
+	}
+}
+
+func TestMoveWishlistProductToCart(t *testing.T) {
+	sessionToken := token.NewToken(time.Minute)
+	refreshToken := token.NewToken(time.Minute)
+
+	defaultCreateSeedData := func(t *testing.T, store db.Store) test_util.SeedData {
+		return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, true)
+	}
+
+	testCases := []struct {
+		name           string
+		buildStore     func(t *testing.T) (store db.Store, cleanup func())
+		createSeedData func(t *testing.T, store db.Store) test_util.SeedData
+		body           test_util.Body
+		setupAuth      func(request *http.Request, sessionToken string)
+		checkResponse  func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData)
+	}{
+		{
+			name:           "OK",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           test_util.Body{"quantity": 2},
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				userID := uuid.MustParse(seedData["user_id"].(string))
+				productID := uuid.MustParse(seedData["product_id"].(string))
+
+				cartProduct, err := store.GetCartProductByUserIDAndProductID(context.Background(), db.GetCartProductByUserIDAndProductIDParams{
+					UserID:    userID,
+					ProductID: productID,
+				})
+				require.NoError(t, err)
+				require.Equal(t, int32(2), cartProduct.Quantity)
+
+				wishlistProducts, err := store.GetWishlistProductsByUserID(context.Background(), userID)
+				require.NoError(t, err)
+				require.Empty(t, wishlistProducts)
+			},
+		},
+		{
+			name:       "MergeWithCartProduct",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				seedData := defaultCreateSeedData(t, store)
+
+				_, err := store.CreateCartProduct(context.Background(), db.CreateCartProductParams{
+					UserID:    uuid.MustParse(seedData["user_id"].(string)),
+					ProductID: uuid.MustParse(seedData["product_id"].(string)),
+					Quantity:  5,
+				})
+				require.NoError(t, err)
+
+				return seedData
+			},
+			body:      test_util.Body{"quantity": 2},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusOK, response.StatusCode)
+
+				cartProduct, err := store.GetCartProductByUserIDAndProductID(context.Background(), db.GetCartProductByUserIDAndProductIDParams{
+					UserID:    uuid.MustParse(seedData["user_id"].(string)),
+					ProductID: uuid.MustParse(seedData["product_id"].(string)),
+				})
+				require.NoError(t, err)
+				require.Equal(t, int32(7), cartProduct.Quantity)
+			},
+		},
+		{
+			name:       "InsufficientStock",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createWishlistSeedData(t, store, sessionToken, refreshToken, 1, true)
+			},
+			body:      test_util.Body{"quantity": 2},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusConflict, response.StatusCode)
+
+				wishlistProducts, err := store.GetWishlistProductsByUserID(
+					context.Background(),
+					uuid.MustParse(seedData["user_id"].(string)),
+				)
+				require.NoError(t, err)
+				require.Len(t, wishlistProducts, 1)
+			},
+		},
+		{
+			name:       "NotInWishlist",
+			buildStore: test_util.BuildTestDBStore,
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return createWishlistSeedData(t, store, sessionToken, refreshToken, 10, false)
+			},
+			body:      test_util.Body{"quantity": 2},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusNotFound, response.StatusCode)
+			},
+		},
+		{
+			name:           "QuantityIsZero",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           test_util.Body{"quantity": 0},
+			setupAuth:      test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusBadRequest, response.StatusCode)
+			},
+		},
+		{
+			name:           "NoAuthorization",
+			buildStore:     test_util.BuildTestDBStore,
+			createSeedData: defaultCreateSeedData,
+			body:           test_util.Body{"quantity": 2},
+			setupAuth:      test_util.NoopSetupAuth,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusUnauthorized, response.StatusCode)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStore: func(t *testing.T) (store db.Store, cleanup func()) {
+				mockStore, cleanup := test_util.NewMockStore(t)
+
+				test_util.BuildValidSessionStubs(mockStore, db.Session{
+					ID:                    util.RandomUUID(),
+					UserID:                util.RandomUUID(),
+					SessionTokenHash:      sessionToken.Hash(),
+					SessionTokenExpiredAt: sessionToken.ExpiredAt,
+					CreatedAt:             time.Now(),
+				})
+
+				mockStore.EXPECT().
+					MoveWishlistProductToCartTx(gomock.Any(), gomock.Any()).
+					Return(db.CartProduct{}, sql.ErrConnDone)
+
+				return mockStore, cleanup
+			},
+			createSeedData: func(t *testing.T, store db.Store) test_util.SeedData {
+				return test_util.SeedData{
+					"product_id": util.RandomUUID().String(),
+				}
+			},
+			body:      test_util.Body{"quantity": 2},
+			setupAuth: test_util.AddSessionTokenInCookie,
+			checkResponse: func(t *testing.T, store db.Store, response *http.Response, seedData test_util.SeedData) {
+				require.Equal(t, http.StatusInternalServerError, response.StatusCode)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, cleanupStore := tc.buildStore(t)
+			defer cleanupStore()
+
+			seedData := tc.createSeedData(t, store)
+
+			request := test_util.NewRequest(t, test_util.RequestParams{
+				Method: http.MethodPost,
+				URL:    fmt.Sprintf("/api/v1/wishlist/%s/move-to-cart", seedData["product_id"].(string)),
+				Body:   tc.body,
+			})
+
+			tc.setupAuth(request, sessionToken.Value)
+
+			server := newTestServer(t, store)
+			response := test_util.SendRequest(t, server.app, request)
+			tc.checkResponse(t, store, response, seedData)
+		})
+	}
+}
+
+func unmarshalWishlistResponse(t *testing.T, body io.ReadCloser) wishlist_domain.WishlistResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var parsed wishlist_domain.WishlistResponse
+	err = json.Unmarshal(data, &parsed)
+	require.NoError(t, err)
+
+	return parsed
+}
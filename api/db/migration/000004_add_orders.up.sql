@@ -0,0 +1,29 @@
+CREATE TABLE "orders" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "subtotal" decimal NOT NULL,
+  "shipping" decimal NOT NULL,
+  "tax" decimal NOT NULL,
+  "total" decimal NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE "order_items" (
+  "order_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "name" varchar NOT NULL,
+  "description" varchar,
+  "price" decimal NOT NULL,
+  "quantity" int NOT NULL,
+  "image_url" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("order_id", "product_id")
+);
+
+CREATE INDEX ON "orders" ("user_id");
+
+ALTER TABLE "orders" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "order_items" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id");
+
+ALTER TABLE "order_items" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS "products_search_vector_update" ON "products";
+
+DROP FUNCTION IF EXISTS products_search_vector_update();
+
+ALTER TABLE "products" DROP COLUMN IF EXISTS "search_vector";
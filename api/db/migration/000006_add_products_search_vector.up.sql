@@ -0,0 +1,21 @@
+ALTER TABLE "products" ADD COLUMN "search_vector" tsvector NOT NULL DEFAULT '';
+
+CREATE FUNCTION products_search_vector_update() RETURNS trigger AS $$
+BEGIN
+  NEW.search_vector :=
+    setweight(to_tsvector('english', coalesce(NEW.name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(NEW.description, '')), 'B');
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "products_search_vector_update"
+BEFORE INSERT OR UPDATE OF "name", "description" ON "products"
+FOR EACH ROW EXECUTE PROCEDURE products_search_vector_update();
+
+UPDATE "products" SET
+  "search_vector" =
+    setweight(to_tsvector('english', coalesce("name", '')), 'A') ||
+    setweight(to_tsvector('english', coalesce("description", '')), 'B');
+
+CREATE INDEX ON "products" USING GIN ("search_vector");
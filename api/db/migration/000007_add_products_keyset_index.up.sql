@@ -0,0 +1 @@
+CREATE INDEX "products_created_at_id_idx" ON "products" ("created_at", "id");
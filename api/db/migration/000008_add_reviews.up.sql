@@ -0,0 +1,17 @@
+CREATE TABLE "reviews" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "product_id" uuid NOT NULL,
+  "user_id" uuid NOT NULL,
+  "rating" int NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+  "title" varchar NOT NULL,
+  "body" varchar,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  "updated_at" timestamptz NOT NULL DEFAULT (now()),
+  UNIQUE ("product_id", "user_id")
+);
+
+CREATE INDEX ON "reviews" ("user_id");
+
+ALTER TABLE "reviews" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
+
+ALTER TABLE "reviews" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
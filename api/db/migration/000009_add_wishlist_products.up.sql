@@ -0,0 +1,12 @@
+CREATE TABLE "wishlist_products" (
+  "user_id" uuid NOT NULL,
+  "product_id" uuid NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now()),
+  PRIMARY KEY ("user_id", "product_id")
+);
+
+CREATE INDEX ON "wishlist_products" ("user_id");
+
+ALTER TABLE "wishlist_products" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
+
+ALTER TABLE "wishlist_products" ADD FOREIGN KEY ("product_id") REFERENCES "products" ("id");
@@ -0,0 +1,10 @@
+CREATE TYPE "user_role" AS ENUM (
+  'customer',
+  'seller',
+  'admin'
+);
+
+ALTER TABLE "users" ADD COLUMN "role" user_role NOT NULL DEFAULT 'customer';
+
+UPDATE "users" SET "role" = 'seller'
+WHERE "id" IN (SELECT DISTINCT "seller_id" FROM "products");
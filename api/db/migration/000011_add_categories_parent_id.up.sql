@@ -0,0 +1,7 @@
+ALTER TABLE "categories" ADD COLUMN "parent_id" uuid;
+
+ALTER TABLE "categories" ADD FOREIGN KEY ("parent_id") REFERENCES "categories" ("id");
+
+ALTER TABLE "categories" ADD CONSTRAINT "categories_parent_id_check" CHECK ("parent_id" <> "id");
+
+CREATE INDEX ON "categories" ("parent_id");
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "sessions_user_id_idx";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "last_used_at";
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "client_ip";
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "user_agent";
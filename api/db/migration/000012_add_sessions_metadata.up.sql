@@ -0,0 +1,7 @@
+ALTER TABLE "sessions" ADD COLUMN "user_agent" varchar NOT NULL DEFAULT '';
+ALTER TABLE "sessions" ADD COLUMN "client_ip" varchar NOT NULL DEFAULT '';
+ALTER TABLE "sessions" ADD COLUMN "last_used_at" timestamptz NOT NULL DEFAULT (now());
+
+UPDATE "sessions" SET "last_used_at" = "created_at";
+
+CREATE INDEX ON "sessions" ("user_id");
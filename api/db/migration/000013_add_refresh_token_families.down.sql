@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "rotated_refresh_tokens";
+
+DROP INDEX IF EXISTS "sessions_family_id_idx";
+
+DROP INDEX IF EXISTS "sessions_refresh_token_idx";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "family_id";
@@ -0,0 +1,14 @@
+ALTER TABLE "sessions" ADD COLUMN "family_id" uuid NOT NULL DEFAULT gen_random_uuid();
+
+CREATE UNIQUE INDEX ON "sessions" ("refresh_token");
+
+CREATE INDEX ON "sessions" ("family_id");
+
+CREATE TABLE "rotated_refresh_tokens" (
+  "refresh_token" uuid PRIMARY KEY,
+  "family_id" uuid NOT NULL,
+  "expired_at" timestamptz NOT NULL,
+  "rotated_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "rotated_refresh_tokens" ("family_id");
@@ -0,0 +1,17 @@
+-- Hashed tokens cannot be restored, so every session is invalidated again.
+DELETE FROM "rotated_refresh_tokens";
+DELETE FROM "sessions";
+
+ALTER TABLE "rotated_refresh_tokens" DROP COLUMN IF EXISTS "refresh_token_hash";
+ALTER TABLE "rotated_refresh_tokens" ADD COLUMN "refresh_token" uuid PRIMARY KEY;
+
+DROP INDEX IF EXISTS "sessions_refresh_token_hash_idx";
+
+DROP INDEX IF EXISTS "sessions_session_token_hash_idx";
+
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "refresh_token_hash";
+ALTER TABLE "sessions" DROP COLUMN IF EXISTS "session_token_hash";
+ALTER TABLE "sessions" ADD COLUMN "session_token" uuid NOT NULL;
+ALTER TABLE "sessions" ADD COLUMN "refresh_token" uuid NOT NULL;
+
+CREATE UNIQUE INDEX ON "sessions" ("refresh_token");
@@ -0,0 +1,18 @@
+-- Plain tokens cannot be converted to the new format, so every existing
+-- session is invalidated and users have to log in again.
+DELETE FROM "rotated_refresh_tokens";
+DELETE FROM "sessions";
+
+DROP INDEX IF EXISTS "sessions_refresh_token_idx";
+
+ALTER TABLE "sessions" DROP COLUMN "session_token";
+ALTER TABLE "sessions" DROP COLUMN "refresh_token";
+ALTER TABLE "sessions" ADD COLUMN "session_token_hash" bytea NOT NULL;
+ALTER TABLE "sessions" ADD COLUMN "refresh_token_hash" bytea NOT NULL;
+
+CREATE UNIQUE INDEX ON "sessions" ("session_token_hash");
+
+CREATE UNIQUE INDEX ON "sessions" ("refresh_token_hash");
+
+ALTER TABLE "rotated_refresh_tokens" DROP COLUMN "refresh_token";
+ALTER TABLE "rotated_refresh_tokens" ADD COLUMN "refresh_token_hash" bytea PRIMARY KEY;
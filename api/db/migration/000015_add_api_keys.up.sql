@@ -0,0 +1,14 @@
+CREATE TABLE "api_keys" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "user_id" uuid NOT NULL,
+  "name" varchar NOT NULL,
+  "key_prefix" varchar NOT NULL,
+  "key_hash" bytea UNIQUE NOT NULL,
+  "scopes" varchar[] NOT NULL CHECK ("scopes" <@ ARRAY['catalog:read', 'products:manage', 'cart:manage']::varchar[]),
+  "last_used_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "api_keys" ("user_id");
+
+ALTER TABLE "api_keys" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id");
@@ -0,0 +1,19 @@
+CREATE TABLE "login_failures" (
+  "scope" varchar NOT NULL CHECK ("scope" IN ('email', 'ip')),
+  "key" varchar NOT NULL,
+  "failure_count" integer NOT NULL,
+  "last_failed_at" timestamptz NOT NULL DEFAULT (now()),
+  "locked_until" timestamptz,
+  PRIMARY KEY ("scope", "key")
+);
+
+CREATE TABLE "login_lockout_events" (
+  "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+  "scope" varchar NOT NULL,
+  "key" varchar NOT NULL,
+  "failure_count" integer NOT NULL,
+  "locked_until" timestamptz NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+CREATE INDEX ON "login_lockout_events" ("scope", "key");
@@ -0,0 +1,3 @@
+ALTER TABLE "cart_products" ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now());
+
+UPDATE "cart_products" SET "updated_at" = "created_at";
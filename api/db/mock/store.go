@@ -11,6 +11,7 @@ package mockdb
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	db "github.com/ot07/next-bazaar/db/sqlc"
@@ -40,6 +41,21 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddCartProductTx mocks base method.
+func (m *MockStore) AddCartProductTx(arg0 context.Context, arg1 db.AddCartProductTxParams) (db.CartProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCartProductTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CartProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCartProductTx indicates an expected call of AddCartProductTx.
+func (mr *MockStoreMockRecorder) AddCartProductTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCartProductTx", reflect.TypeOf((*MockStore)(nil).AddCartProductTx), arg0, arg1)
+}
+
 // AddProduct mocks base method.
 func (m *MockStore) AddProduct(arg0 context.Context, arg1 db.AddProductParams) (db.Product, error) {
 	m.ctrl.T.Helper()
@@ -55,331 +71,1261 @@ func (mr *MockStoreMockRecorder) AddProduct(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddProduct", reflect.TypeOf((*MockStore)(nil).AddProduct), arg0, arg1)
 }
 
+// CheckoutTx mocks base method.
+func (m *MockStore) CheckoutTx(arg0 context.Context, arg1 db.CheckoutTxParams) (db.CheckoutTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CheckoutTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckoutTx indicates an expected call of CheckoutTx.
+func (mr *MockStoreMockRecorder) CheckoutTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutTx", reflect.TypeOf((*MockStore)(nil).CheckoutTx), arg0, arg1)
+}
+
+// CountOrdersByUser mocks base method.
+func (m *MockStore) CountOrdersByUser(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOrdersByUser", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOrdersByUser indicates an expected call of CountOrdersByUser.
+func (mr *MockStoreMockRecorder) CountOrdersByUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOrdersByUser", reflect.TypeOf((*MockStore)(nil).CountOrdersByUser), arg0, arg1)
+}
+
 // CountProducts mocks base method.
-func (m *MockStore) CountProducts(arg0 context.Context) (int64, error) {
+func (m *MockStore) CountProducts(arg0 context.Context, arg1 db.CountProductsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProducts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProducts indicates an expected call of CountProducts.
+func (mr *MockStoreMockRecorder) CountProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockStore)(nil).CountProducts), arg0, arg1)
+}
+
+// CountProductsByCategory mocks base method.
+func (m *MockStore) CountProductsByCategory(arg0 context.Context, arg1 db.CountProductsByCategoryParams) ([]db.CountProductsByCategoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByCategory", arg0, arg1)
+	ret0, _ := ret[0].([]db.CountProductsByCategoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByCategory indicates an expected call of CountProductsByCategory.
+func (mr *MockStoreMockRecorder) CountProductsByCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByCategory", reflect.TypeOf((*MockStore)(nil).CountProductsByCategory), arg0, arg1)
+}
+
+// CountProductsByPriceBucket mocks base method.
+func (m *MockStore) CountProductsByPriceBucket(arg0 context.Context, arg1 db.CountProductsByPriceBucketParams) ([]db.CountProductsByPriceBucketRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsByPriceBucket", arg0, arg1)
+	ret0, _ := ret[0].([]db.CountProductsByPriceBucketRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsByPriceBucket indicates an expected call of CountProductsByPriceBucket.
+func (mr *MockStoreMockRecorder) CountProductsByPriceBucket(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsByPriceBucket", reflect.TypeOf((*MockStore)(nil).CountProductsByPriceBucket), arg0, arg1)
+}
+
+// CountProductsBySeller mocks base method.
+func (m *MockStore) CountProductsBySeller(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProductsBySeller", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProductsBySeller indicates an expected call of CountProductsBySeller.
+func (mr *MockStoreMockRecorder) CountProductsBySeller(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsBySeller", reflect.TypeOf((*MockStore)(nil).CountProductsBySeller), arg0, arg1)
+}
+
+// CountReviewsByProductID mocks base method.
+func (m *MockStore) CountReviewsByProductID(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountReviewsByProductID", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountReviewsByProductID indicates an expected call of CountReviewsByProductID.
+func (mr *MockStoreMockRecorder) CountReviewsByProductID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountReviewsByProductID", reflect.TypeOf((*MockStore)(nil).CountReviewsByProductID), arg0, arg1)
+}
+
+// CountUsers mocks base method.
+func (m *MockStore) CountUsers(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockStoreMockRecorder) CountUsers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), arg0)
+}
+
+// CreateApiKey mocks base method.
+func (m *MockStore) CreateApiKey(arg0 context.Context, arg1 db.CreateApiKeyParams) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateApiKey indicates an expected call of CreateApiKey.
+func (mr *MockStoreMockRecorder) CreateApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApiKey", reflect.TypeOf((*MockStore)(nil).CreateApiKey), arg0, arg1)
+}
+
+// CreateCartProduct mocks base method.
+func (m *MockStore) CreateCartProduct(arg0 context.Context, arg1 db.CreateCartProductParams) (db.CartProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCartProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.CartProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCartProduct indicates an expected call of CreateCartProduct.
+func (mr *MockStoreMockRecorder) CreateCartProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCartProduct", reflect.TypeOf((*MockStore)(nil).CreateCartProduct), arg0, arg1)
+}
+
+// CreateCategory mocks base method.
+func (m *MockStore) CreateCategory(arg0 context.Context, arg1 string) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateCategoryWithParent mocks base method.
+func (m *MockStore) CreateCategoryWithParent(arg0 context.Context, arg1 db.CreateCategoryWithParentParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategoryWithParent", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategoryWithParent indicates an expected call of CreateCategoryWithParent.
+func (mr *MockStoreMockRecorder) CreateCategoryWithParent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategoryWithParent", reflect.TypeOf((*MockStore)(nil).CreateCategoryWithParent), arg0, arg1)
+}
+
+// CreateLoginLockoutEvent mocks base method.
+func (m *MockStore) CreateLoginLockoutEvent(arg0 context.Context, arg1 db.CreateLoginLockoutEventParams) (db.LoginLockoutEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLoginLockoutEvent", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginLockoutEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLoginLockoutEvent indicates an expected call of CreateLoginLockoutEvent.
+func (mr *MockStoreMockRecorder) CreateLoginLockoutEvent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLoginLockoutEvent", reflect.TypeOf((*MockStore)(nil).CreateLoginLockoutEvent), arg0, arg1)
+}
+
+// CreateOrder mocks base method.
+func (m *MockStore) CreateOrder(arg0 context.Context, arg1 db.CreateOrderParams) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockStoreMockRecorder) CreateOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockStore)(nil).CreateOrder), arg0, arg1)
+}
+
+// CreateOrderItem mocks base method.
+func (m *MockStore) CreateOrderItem(arg0 context.Context, arg1 db.CreateOrderItemParams) (db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderItem indicates an expected call of CreateOrderItem.
+func (mr *MockStoreMockRecorder) CreateOrderItem(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderItem", reflect.TypeOf((*MockStore)(nil).CreateOrderItem), arg0, arg1)
+}
+
+// CreateProduct mocks base method.
+func (m *MockStore) CreateProduct(arg0 context.Context, arg1 db.CreateProductParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProduct indicates an expected call of CreateProduct.
+func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+}
+
+// CreateReview mocks base method.
+func (m *MockStore) CreateReview(arg0 context.Context, arg1 db.CreateReviewParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReview", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReview indicates an expected call of CreateReview.
+func (mr *MockStoreMockRecorder) CreateReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockStore)(nil).CreateReview), arg0, arg1)
+}
+
+// CreateRotatedRefreshToken mocks base method.
+func (m *MockStore) CreateRotatedRefreshToken(arg0 context.Context, arg1 db.CreateRotatedRefreshTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRotatedRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRotatedRefreshToken indicates an expected call of CreateRotatedRefreshToken.
+func (mr *MockStoreMockRecorder) CreateRotatedRefreshToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRotatedRefreshToken", reflect.TypeOf((*MockStore)(nil).CreateRotatedRefreshToken), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// CreateWishlistProduct mocks base method.
+func (m *MockStore) CreateWishlistProduct(arg0 context.Context, arg1 db.CreateWishlistProductParams) (db.WishlistProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWishlistProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.WishlistProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWishlistProduct indicates an expected call of CreateWishlistProduct.
+func (mr *MockStoreMockRecorder) CreateWishlistProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWishlistProduct", reflect.TypeOf((*MockStore)(nil).CreateWishlistProduct), arg0, arg1)
+}
+
+// DecrementProductStock mocks base method.
+func (m *MockStore) DecrementProductStock(arg0 context.Context, arg1 db.DecrementProductStockParams) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementProductStock", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementProductStock indicates an expected call of DecrementProductStock.
+func (mr *MockStoreMockRecorder) DecrementProductStock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementProductStock", reflect.TypeOf((*MockStore)(nil).DecrementProductStock), arg0, arg1)
+}
+
+// DeleteApiKey mocks base method.
+func (m *MockStore) DeleteApiKey(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteApiKey indicates an expected call of DeleteApiKey.
+func (mr *MockStoreMockRecorder) DeleteApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteApiKey", reflect.TypeOf((*MockStore)(nil).DeleteApiKey), arg0, arg1)
+}
+
+// DeleteCartProduct mocks base method.
+func (m *MockStore) DeleteCartProduct(arg0 context.Context, arg1 db.DeleteCartProductParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartProduct indicates an expected call of DeleteCartProduct.
+func (mr *MockStoreMockRecorder) DeleteCartProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartProduct", reflect.TypeOf((*MockStore)(nil).DeleteCartProduct), arg0, arg1)
+}
+
+// DeleteCartProductsByUserID mocks base method.
+func (m *MockStore) DeleteCartProductsByUserID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCartProductsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCartProductsByUserID indicates an expected call of DeleteCartProductsByUserID.
+func (mr *MockStoreMockRecorder) DeleteCartProductsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartProductsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteCartProductsByUserID), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteExpiredRotatedRefreshTokens mocks base method.
+func (m *MockStore) DeleteExpiredRotatedRefreshTokens(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredRotatedRefreshTokens", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredRotatedRefreshTokens indicates an expected call of DeleteExpiredRotatedRefreshTokens.
+func (mr *MockStoreMockRecorder) DeleteExpiredRotatedRefreshTokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredRotatedRefreshTokens", reflect.TypeOf((*MockStore)(nil).DeleteExpiredRotatedRefreshTokens), arg0, arg1)
+}
+
+// DeleteExpiredSessions mocks base method.
+func (m *MockStore) DeleteExpiredSessions(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSessions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSessions indicates an expected call of DeleteExpiredSessions.
+func (mr *MockStoreMockRecorder) DeleteExpiredSessions(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockStore)(nil).DeleteExpiredSessions), arg0, arg1)
+}
+
+// DeleteLoginFailure mocks base method.
+func (m *MockStore) DeleteLoginFailure(arg0 context.Context, arg1 db.DeleteLoginFailureParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLoginFailure", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLoginFailure indicates an expected call of DeleteLoginFailure.
+func (mr *MockStoreMockRecorder) DeleteLoginFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLoginFailure", reflect.TypeOf((*MockStore)(nil).DeleteLoginFailure), arg0, arg1)
+}
+
+// DeleteOtherSessionsByUserID mocks base method.
+func (m *MockStore) DeleteOtherSessionsByUserID(arg0 context.Context, arg1 db.DeleteOtherSessionsByUserIDParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOtherSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOtherSessionsByUserID indicates an expected call of DeleteOtherSessionsByUserID.
+func (mr *MockStoreMockRecorder) DeleteOtherSessionsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOtherSessionsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteOtherSessionsByUserID), arg0, arg1)
+}
+
+// DeleteReview mocks base method.
+func (m *MockStore) DeleteReview(arg0 context.Context, arg1 db.DeleteReviewParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockStoreMockRecorder) DeleteReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockStore)(nil).DeleteReview), arg0, arg1)
+}
+
+// DeleteSession mocks base method.
+func (m *MockStore) DeleteSession(arg0 context.Context, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSession indicates an expected call of DeleteSession.
+func (mr *MockStoreMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0, arg1)
+}
+
+// DeleteSessionByID mocks base method.
+func (m *MockStore) DeleteSessionByID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionByID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionByID indicates an expected call of DeleteSessionByID.
+func (mr *MockStoreMockRecorder) DeleteSessionByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionByID", reflect.TypeOf((*MockStore)(nil).DeleteSessionByID), arg0, arg1)
+}
+
+// DeleteSessionsByFamilyID mocks base method.
+func (m *MockStore) DeleteSessionsByFamilyID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsByFamilyID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsByFamilyID indicates an expected call of DeleteSessionsByFamilyID.
+func (mr *MockStoreMockRecorder) DeleteSessionsByFamilyID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsByFamilyID", reflect.TypeOf((*MockStore)(nil).DeleteSessionsByFamilyID), arg0, arg1)
+}
+
+// DeleteSessionsByUserID mocks base method.
+func (m *MockStore) DeleteSessionsByUserID(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsByUserID indicates an expected call of DeleteSessionsByUserID.
+func (mr *MockStoreMockRecorder) DeleteSessionsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsByUserID", reflect.TypeOf((*MockStore)(nil).DeleteSessionsByUserID), arg0, arg1)
+}
+
+// DeleteStaleCartProducts mocks base method.
+func (m *MockStore) DeleteStaleCartProducts(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleCartProducts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleCartProducts indicates an expected call of DeleteStaleCartProducts.
+func (mr *MockStoreMockRecorder) DeleteStaleCartProducts(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleCartProducts", reflect.TypeOf((*MockStore)(nil).DeleteStaleCartProducts), arg0, arg1)
+}
+
+// DeleteStaleLoginFailures mocks base method.
+func (m *MockStore) DeleteStaleLoginFailures(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStaleLoginFailures", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStaleLoginFailures indicates an expected call of DeleteStaleLoginFailures.
+func (mr *MockStoreMockRecorder) DeleteStaleLoginFailures(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStaleLoginFailures", reflect.TypeOf((*MockStore)(nil).DeleteStaleLoginFailures), arg0, arg1)
+}
+
+// DeleteWishlistProduct mocks base method.
+func (m *MockStore) DeleteWishlistProduct(arg0 context.Context, arg1 db.DeleteWishlistProductParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWishlistProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWishlistProduct indicates an expected call of DeleteWishlistProduct.
+func (mr *MockStoreMockRecorder) DeleteWishlistProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWishlistProduct", reflect.TypeOf((*MockStore)(nil).DeleteWishlistProduct), arg0, arg1)
+}
+
+// ExecTx mocks base method.
+func (m *MockStore) ExecTx(arg0 context.Context, arg1 func(*db.Queries) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecTx indicates an expected call of ExecTx.
+func (mr *MockStoreMockRecorder) ExecTx(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockStore)(nil).ExecTx), arg0, arg1)
+}
+
+// GetApiKey mocks base method.
+func (m *MockStore) GetApiKey(arg0 context.Context, arg1 uuid.UUID) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKey", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKey indicates an expected call of GetApiKey.
+func (mr *MockStoreMockRecorder) GetApiKey(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKey", reflect.TypeOf((*MockStore)(nil).GetApiKey), arg0, arg1)
+}
+
+// GetApiKeyByHash mocks base method.
+func (m *MockStore) GetApiKeyByHash(arg0 context.Context, arg1 []byte) (db.ApiKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeyByHash", arg0, arg1)
+	ret0, _ := ret[0].(db.ApiKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeyByHash indicates an expected call of GetApiKeyByHash.
+func (mr *MockStoreMockRecorder) GetApiKeyByHash(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeyByHash", reflect.TypeOf((*MockStore)(nil).GetApiKeyByHash), arg0, arg1)
+}
+
+// GetCartProductByUserIDAndProductID mocks base method.
+func (m *MockStore) GetCartProductByUserIDAndProductID(arg0 context.Context, arg1 db.GetCartProductByUserIDAndProductIDParams) (db.CartProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartProductByUserIDAndProductID", arg0, arg1)
+	ret0, _ := ret[0].(db.CartProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartProductByUserIDAndProductID indicates an expected call of GetCartProductByUserIDAndProductID.
+func (mr *MockStoreMockRecorder) GetCartProductByUserIDAndProductID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartProductByUserIDAndProductID", reflect.TypeOf((*MockStore)(nil).GetCartProductByUserIDAndProductID), arg0, arg1)
+}
+
+// GetCartProductsByUserID mocks base method.
+func (m *MockStore) GetCartProductsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]db.CartProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCartProductsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.CartProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCartProductsByUserID indicates an expected call of GetCartProductsByUserID.
+func (mr *MockStoreMockRecorder) GetCartProductsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartProductsByUserID", reflect.TypeOf((*MockStore)(nil).GetCartProductsByUserID), arg0, arg1)
+}
+
+// GetCategoriesByIDs mocks base method.
+func (m *MockStore) GetCategoriesByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoriesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoriesByIDs indicates an expected call of GetCategoriesByIDs.
+func (mr *MockStoreMockRecorder) GetCategoriesByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByIDs", reflect.TypeOf((*MockStore)(nil).GetCategoriesByIDs), arg0, arg1)
+}
+
+// GetCategory mocks base method.
+func (m *MockStore) GetCategory(arg0 context.Context, arg1 uuid.UUID) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategory indicates an expected call of GetCategory.
+func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+}
+
+// GetCategoryDescendantIDs mocks base method.
+func (m *MockStore) GetCategoryDescendantIDs(arg0 context.Context, arg1 uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCategoryDescendantIDs", arg0, arg1)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCategoryDescendantIDs indicates an expected call of GetCategoryDescendantIDs.
+func (mr *MockStoreMockRecorder) GetCategoryDescendantIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoryDescendantIDs", reflect.TypeOf((*MockStore)(nil).GetCategoryDescendantIDs), arg0, arg1)
+}
+
+// GetLoginFailure mocks base method.
+func (m *MockStore) GetLoginFailure(arg0 context.Context, arg1 db.GetLoginFailureParams) (db.LoginFailure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginFailure", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginFailure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginFailure indicates an expected call of GetLoginFailure.
+func (mr *MockStoreMockRecorder) GetLoginFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginFailure", reflect.TypeOf((*MockStore)(nil).GetLoginFailure), arg0, arg1)
+}
+
+// GetOrder mocks base method.
+func (m *MockStore) GetOrder(arg0 context.Context, arg1 uuid.UUID) (db.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockStoreMockRecorder) GetOrder(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockStore)(nil).GetOrder), arg0, arg1)
+}
+
+// GetOrderItemsByOrderID mocks base method.
+func (m *MockStore) GetOrderItemsByOrderID(arg0 context.Context, arg1 uuid.UUID) ([]db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemsByOrderID", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemsByOrderID indicates an expected call of GetOrderItemsByOrderID.
+func (mr *MockStoreMockRecorder) GetOrderItemsByOrderID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemsByOrderID", reflect.TypeOf((*MockStore)(nil).GetOrderItemsByOrderID), arg0, arg1)
+}
+
+// GetOrderItemsByOrderIDs mocks base method.
+func (m *MockStore) GetOrderItemsByOrderIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.OrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderItemsByOrderIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.OrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderItemsByOrderIDs indicates an expected call of GetOrderItemsByOrderIDs.
+func (mr *MockStoreMockRecorder) GetOrderItemsByOrderIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderItemsByOrderIDs", reflect.TypeOf((*MockStore)(nil).GetOrderItemsByOrderIDs), arg0, arg1)
+}
+
+// GetProduct mocks base method.
+func (m *MockStore) GetProduct(arg0 context.Context, arg1 uuid.UUID) (db.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
+	ret0, _ := ret[0].(db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProduct indicates an expected call of GetProduct.
+func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+}
+
+// GetProductRatingsByProductIDs mocks base method.
+func (m *MockStore) GetProductRatingsByProductIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.GetProductRatingsByProductIDsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductRatingsByProductIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.GetProductRatingsByProductIDsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductRatingsByProductIDs indicates an expected call of GetProductRatingsByProductIDs.
+func (mr *MockStoreMockRecorder) GetProductRatingsByProductIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductRatingsByProductIDs", reflect.TypeOf((*MockStore)(nil).GetProductRatingsByProductIDs), arg0, arg1)
+}
+
+// GetReviewByProductIDAndUserID mocks base method.
+func (m *MockStore) GetReviewByProductIDAndUserID(arg0 context.Context, arg1 db.GetReviewByProductIDAndUserIDParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewByProductIDAndUserID", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReviewByProductIDAndUserID indicates an expected call of GetReviewByProductIDAndUserID.
+func (mr *MockStoreMockRecorder) GetReviewByProductIDAndUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByProductIDAndUserID", reflect.TypeOf((*MockStore)(nil).GetReviewByProductIDAndUserID), arg0, arg1)
+}
+
+// GetRotatedRefreshToken mocks base method.
+func (m *MockStore) GetRotatedRefreshToken(arg0 context.Context, arg1 []byte) (db.RotatedRefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRotatedRefreshToken", arg0, arg1)
+	ret0, _ := ret[0].(db.RotatedRefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRotatedRefreshToken indicates an expected call of GetRotatedRefreshToken.
+func (mr *MockStoreMockRecorder) GetRotatedRefreshToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRotatedRefreshToken", reflect.TypeOf((*MockStore)(nil).GetRotatedRefreshToken), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 []byte) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetSessionByFamilyID mocks base method.
+func (m *MockStore) GetSessionByFamilyID(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByFamilyID", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByFamilyID indicates an expected call of GetSessionByFamilyID.
+func (mr *MockStoreMockRecorder) GetSessionByFamilyID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByFamilyID", reflect.TypeOf((*MockStore)(nil).GetSessionByFamilyID), arg0, arg1)
+}
+
+// GetSessionByID mocks base method.
+func (m *MockStore) GetSessionByID(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByID", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByID indicates an expected call of GetSessionByID.
+func (mr *MockStoreMockRecorder) GetSessionByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockStore)(nil).GetSessionByID), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+}
+
+// GetUsersByIDs mocks base method.
+func (m *MockStore) GetUsersByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersByIDs indicates an expected call of GetUsersByIDs.
+func (mr *MockStoreMockRecorder) GetUsersByIDs(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockStore)(nil).GetUsersByIDs), arg0, arg1)
+}
+
+// GetWishlistProductByUserIDAndProductID mocks base method.
+func (m *MockStore) GetWishlistProductByUserIDAndProductID(arg0 context.Context, arg1 db.GetWishlistProductByUserIDAndProductIDParams) (db.WishlistProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistProductByUserIDAndProductID", arg0, arg1)
+	ret0, _ := ret[0].(db.WishlistProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistProductByUserIDAndProductID indicates an expected call of GetWishlistProductByUserIDAndProductID.
+func (mr *MockStoreMockRecorder) GetWishlistProductByUserIDAndProductID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistProductByUserIDAndProductID", reflect.TypeOf((*MockStore)(nil).GetWishlistProductByUserIDAndProductID), arg0, arg1)
+}
+
+// GetWishlistProductsByUserID mocks base method.
+func (m *MockStore) GetWishlistProductsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]db.WishlistProduct, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWishlistProductsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.WishlistProduct)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWishlistProductsByUserID indicates an expected call of GetWishlistProductsByUserID.
+func (mr *MockStoreMockRecorder) GetWishlistProductsByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWishlistProductsByUserID", reflect.TypeOf((*MockStore)(nil).GetWishlistProductsByUserID), arg0, arg1)
+}
+
+// ListAllCategories mocks base method.
+func (m *MockStore) ListAllCategories(arg0 context.Context) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllCategories", arg0)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllCategories indicates an expected call of ListAllCategories.
+func (mr *MockStoreMockRecorder) ListAllCategories(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllCategories", reflect.TypeOf((*MockStore)(nil).ListAllCategories), arg0)
+}
+
+// ListApiKeysByUserID mocks base method.
+func (m *MockStore) ListApiKeysByUserID(arg0 context.Context, arg1 uuid.UUID) ([]db.ApiKey, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountProducts", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListApiKeysByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.ApiKey)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountProducts indicates an expected call of CountProducts.
-func (mr *MockStoreMockRecorder) CountProducts(arg0 any) *gomock.Call {
+// ListApiKeysByUserID indicates an expected call of ListApiKeysByUserID.
+func (mr *MockStoreMockRecorder) ListApiKeysByUserID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProducts", reflect.TypeOf((*MockStore)(nil).CountProducts), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListApiKeysByUserID", reflect.TypeOf((*MockStore)(nil).ListApiKeysByUserID), arg0, arg1)
 }
 
-// CountProductsBySeller mocks base method.
-func (m *MockStore) CountProductsBySeller(arg0 context.Context, arg1 uuid.UUID) (int64, error) {
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountProductsBySeller", arg0, arg1)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountProductsBySeller indicates an expected call of CountProductsBySeller.
-func (mr *MockStoreMockRecorder) CountProductsBySeller(arg0, arg1 any) *gomock.Call {
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProductsBySeller", reflect.TypeOf((*MockStore)(nil).CountProductsBySeller), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
 }
 
-// CreateCartProduct mocks base method.
-func (m *MockStore) CreateCartProduct(arg0 context.Context, arg1 db.CreateCartProductParams) (db.CartProduct, error) {
+// ListLoginLockoutEvents mocks base method.
+func (m *MockStore) ListLoginLockoutEvents(arg0 context.Context, arg1 db.ListLoginLockoutEventsParams) ([]db.LoginLockoutEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCartProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.CartProduct)
+	ret := m.ctrl.Call(m, "ListLoginLockoutEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.LoginLockoutEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCartProduct indicates an expected call of CreateCartProduct.
-func (mr *MockStoreMockRecorder) CreateCartProduct(arg0, arg1 any) *gomock.Call {
+// ListLoginLockoutEvents indicates an expected call of ListLoginLockoutEvents.
+func (mr *MockStoreMockRecorder) ListLoginLockoutEvents(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCartProduct", reflect.TypeOf((*MockStore)(nil).CreateCartProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLoginLockoutEvents", reflect.TypeOf((*MockStore)(nil).ListLoginLockoutEvents), arg0, arg1)
 }
 
-// CreateCategory mocks base method.
-func (m *MockStore) CreateCategory(arg0 context.Context, arg1 string) (db.Category, error) {
+// ListOrdersByUser mocks base method.
+func (m *MockStore) ListOrdersByUser(arg0 context.Context, arg1 db.ListOrdersByUserParams) ([]db.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "ListOrdersByUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Order)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateCategory indicates an expected call of CreateCategory.
-func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 any) *gomock.Call {
+// ListOrdersByUser indicates an expected call of ListOrdersByUser.
+func (mr *MockStoreMockRecorder) ListOrdersByUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrdersByUser", reflect.TypeOf((*MockStore)(nil).ListOrdersByUser), arg0, arg1)
 }
 
-// CreateProduct mocks base method.
-func (m *MockStore) CreateProduct(arg0 context.Context, arg1 db.CreateProductParams) (db.Product, error) {
+// ListProducts mocks base method.
+func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateProduct indicates an expected call of CreateProduct.
-func (mr *MockStoreMockRecorder) CreateProduct(arg0, arg1 any) *gomock.Call {
+// ListProducts indicates an expected call of ListProducts.
+func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProduct", reflect.TypeOf((*MockStore)(nil).CreateProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+// ListProductsByCursor mocks base method.
+func (m *MockStore) ListProductsByCursor(arg0 context.Context, arg1 db.ListProductsByCursorParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ListProductsByCursor", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 any) *gomock.Call {
+// ListProductsByCursor indicates an expected call of ListProductsByCursor.
+func (mr *MockStoreMockRecorder) ListProductsByCursor(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsByCursor", reflect.TypeOf((*MockStore)(nil).ListProductsByCursor), arg0, arg1)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// ListProductsBySeller mocks base method.
+func (m *MockStore) ListProductsBySeller(arg0 context.Context, arg1 db.ListProductsBySellerParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListProductsBySeller", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 any) *gomock.Call {
+// ListProductsBySeller indicates an expected call of ListProductsBySeller.
+func (mr *MockStoreMockRecorder) ListProductsBySeller(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsBySeller", reflect.TypeOf((*MockStore)(nil).ListProductsBySeller), arg0, arg1)
 }
 
-// DeleteCartProduct mocks base method.
-func (m *MockStore) DeleteCartProduct(arg0 context.Context, arg1 db.DeleteCartProductParams) error {
+// ListProductsBySellerByCursor mocks base method.
+func (m *MockStore) ListProductsBySellerByCursor(arg0 context.Context, arg1 db.ListProductsBySellerByCursorParams) ([]db.Product, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCartProduct", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListProductsBySellerByCursor", arg0, arg1)
+	ret0, _ := ret[0].([]db.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCartProduct indicates an expected call of DeleteCartProduct.
-func (mr *MockStoreMockRecorder) DeleteCartProduct(arg0, arg1 any) *gomock.Call {
+// ListProductsBySellerByCursor indicates an expected call of ListProductsBySellerByCursor.
+func (mr *MockStoreMockRecorder) ListProductsBySellerByCursor(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCartProduct", reflect.TypeOf((*MockStore)(nil).DeleteCartProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsBySellerByCursor", reflect.TypeOf((*MockStore)(nil).ListProductsBySellerByCursor), arg0, arg1)
 }
 
-// DeleteCategory mocks base method.
-func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 uuid.UUID) error {
+// ListReviewsByProductID mocks base method.
+func (m *MockStore) ListReviewsByProductID(arg0 context.Context, arg1 db.ListReviewsByProductIDParams) ([]db.Review, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListReviewsByProductID", arg0, arg1)
+	ret0, _ := ret[0].([]db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteCategory indicates an expected call of DeleteCategory.
-func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 any) *gomock.Call {
+// ListReviewsByProductID indicates an expected call of ListReviewsByProductID.
+func (mr *MockStoreMockRecorder) ListReviewsByProductID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewsByProductID", reflect.TypeOf((*MockStore)(nil).ListReviewsByProductID), arg0, arg1)
 }
 
-// DeleteSession mocks base method.
-func (m *MockStore) DeleteSession(arg0 context.Context, arg1 uuid.UUID) error {
+// ListSessionsByUserID mocks base method.
+func (m *MockStore) ListSessionsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteSession", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListSessionsByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteSession indicates an expected call of DeleteSession.
-func (mr *MockStoreMockRecorder) DeleteSession(arg0, arg1 any) *gomock.Call {
+// ListSessionsByUserID indicates an expected call of ListSessionsByUserID.
+func (mr *MockStoreMockRecorder) ListSessionsByUserID(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByUserID", reflect.TypeOf((*MockStore)(nil).ListSessionsByUserID), arg0, arg1)
 }
 
-// GetCartProductByUserIDAndProductID mocks base method.
-func (m *MockStore) GetCartProductByUserIDAndProductID(arg0 context.Context, arg1 db.GetCartProductByUserIDAndProductIDParams) (db.CartProduct, error) {
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartProductByUserIDAndProductID", arg0, arg1)
-	ret0, _ := ret[0].(db.CartProduct)
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartProductByUserIDAndProductID indicates an expected call of GetCartProductByUserIDAndProductID.
-func (mr *MockStoreMockRecorder) GetCartProductByUserIDAndProductID(arg0, arg1 any) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartProductByUserIDAndProductID", reflect.TypeOf((*MockStore)(nil).GetCartProductByUserIDAndProductID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
 }
 
-// GetCartProductsByUserID mocks base method.
-func (m *MockStore) GetCartProductsByUserID(arg0 context.Context, arg1 uuid.UUID) ([]db.CartProduct, error) {
+// LockLogin mocks base method.
+func (m *MockStore) LockLogin(arg0 context.Context, arg1 db.LockLoginParams) (db.LoginFailure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCartProductsByUserID", arg0, arg1)
-	ret0, _ := ret[0].([]db.CartProduct)
+	ret := m.ctrl.Call(m, "LockLogin", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginFailure)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCartProductsByUserID indicates an expected call of GetCartProductsByUserID.
-func (mr *MockStoreMockRecorder) GetCartProductsByUserID(arg0, arg1 any) *gomock.Call {
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockStoreMockRecorder) LockLogin(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCartProductsByUserID", reflect.TypeOf((*MockStore)(nil).GetCartProductsByUserID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockStore)(nil).LockLogin), arg0, arg1)
 }
 
-// GetCategoriesByIDs mocks base method.
-func (m *MockStore) GetCategoriesByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.Category, error) {
+// LockLoginTx mocks base method.
+func (m *MockStore) LockLoginTx(arg0 context.Context, arg1 db.CreateLoginLockoutEventParams) (db.LoginLockoutEvent, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategoriesByIDs", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
+	ret := m.ctrl.Call(m, "LockLoginTx", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginLockoutEvent)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategoriesByIDs indicates an expected call of GetCategoriesByIDs.
-func (mr *MockStoreMockRecorder) GetCategoriesByIDs(arg0, arg1 any) *gomock.Call {
+// LockLoginTx indicates an expected call of LockLoginTx.
+func (mr *MockStoreMockRecorder) LockLoginTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategoriesByIDs", reflect.TypeOf((*MockStore)(nil).GetCategoriesByIDs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginTx", reflect.TypeOf((*MockStore)(nil).LockLoginTx), arg0, arg1)
 }
 
-// GetCategory mocks base method.
-func (m *MockStore) GetCategory(arg0 context.Context, arg1 uuid.UUID) (db.Category, error) {
+// MoveWishlistProductToCartTx mocks base method.
+func (m *MockStore) MoveWishlistProductToCartTx(arg0 context.Context, arg1 db.AddCartProductTxParams) (db.CartProduct, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCategory", arg0, arg1)
-	ret0, _ := ret[0].(db.Category)
+	ret := m.ctrl.Call(m, "MoveWishlistProductToCartTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CartProduct)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetCategory indicates an expected call of GetCategory.
-func (mr *MockStoreMockRecorder) GetCategory(arg0, arg1 any) *gomock.Call {
+// MoveWishlistProductToCartTx indicates an expected call of MoveWishlistProductToCartTx.
+func (mr *MockStoreMockRecorder) MoveWishlistProductToCartTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCategory", reflect.TypeOf((*MockStore)(nil).GetCategory), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveWishlistProductToCartTx", reflect.TypeOf((*MockStore)(nil).MoveWishlistProductToCartTx), arg0, arg1)
 }
 
-// GetProduct mocks base method.
-func (m *MockStore) GetProduct(arg0 context.Context, arg1 uuid.UUID) (db.Product, error) {
+// RecordLoginFailure mocks base method.
+func (m *MockStore) RecordLoginFailure(arg0 context.Context, arg1 db.RecordLoginFailureParams) (db.LoginFailure, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetProduct", arg0, arg1)
-	ret0, _ := ret[0].(db.Product)
+	ret := m.ctrl.Call(m, "RecordLoginFailure", arg0, arg1)
+	ret0, _ := ret[0].(db.LoginFailure)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetProduct indicates an expected call of GetProduct.
-func (mr *MockStoreMockRecorder) GetProduct(arg0, arg1 any) *gomock.Call {
+// RecordLoginFailure indicates an expected call of RecordLoginFailure.
+func (mr *MockStoreMockRecorder) RecordLoginFailure(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProduct", reflect.TypeOf((*MockStore)(nil).GetProduct), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoginFailure", reflect.TypeOf((*MockStore)(nil).RecordLoginFailure), arg0, arg1)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// RotateSessionTokens mocks base method.
+func (m *MockStore) RotateSessionTokens(arg0 context.Context, arg1 db.RotateSessionTokensParams) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret := m.ctrl.Call(m, "RotateSessionTokens", arg0, arg1)
 	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 any) *gomock.Call {
+// RotateSessionTokens indicates an expected call of RotateSessionTokens.
+func (mr *MockStoreMockRecorder) RotateSessionTokens(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTokens", reflect.TypeOf((*MockStore)(nil).RotateSessionTokens), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 uuid.UUID) (db.User, error) {
+// RotateSessionTx mocks base method.
+func (m *MockStore) RotateSessionTx(arg0 context.Context, arg1 db.RotateSessionTokensParams) (db.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "RotateSessionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 any) *gomock.Call {
+// RotateSessionTx indicates an expected call of RotateSessionTx.
+func (mr *MockStoreMockRecorder) RotateSessionTx(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionTx", reflect.TypeOf((*MockStore)(nil).RotateSessionTx), arg0, arg1)
 }
 
-// GetUserByEmail mocks base method.
-func (m *MockStore) GetUserByEmail(arg0 context.Context, arg1 string) (db.User, error) {
+// SoftDeleteProduct mocks base method.
+func (m *MockStore) SoftDeleteProduct(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByEmail", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "SoftDeleteProduct", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetUserByEmail indicates an expected call of GetUserByEmail.
-func (mr *MockStoreMockRecorder) GetUserByEmail(arg0, arg1 any) *gomock.Call {
+// SoftDeleteProduct indicates an expected call of SoftDeleteProduct.
+func (mr *MockStoreMockRecorder) SoftDeleteProduct(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStore)(nil).GetUserByEmail), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteProduct", reflect.TypeOf((*MockStore)(nil).SoftDeleteProduct), arg0, arg1)
 }
 
-// GetUsersByIDs mocks base method.
-func (m *MockStore) GetUsersByIDs(arg0 context.Context, arg1 []uuid.UUID) ([]db.User, error) {
+// SubtractCartProductQuantity mocks base method.
+func (m *MockStore) SubtractCartProductQuantity(arg0 context.Context, arg1 db.SubtractCartProductQuantityParams) (db.CartProduct, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUsersByIDs", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "SubtractCartProductQuantity", arg0, arg1)
+	ret0, _ := ret[0].(db.CartProduct)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUsersByIDs indicates an expected call of GetUsersByIDs.
-func (mr *MockStoreMockRecorder) GetUsersByIDs(arg0, arg1 any) *gomock.Call {
+// SubtractCartProductQuantity indicates an expected call of SubtractCartProductQuantity.
+func (mr *MockStoreMockRecorder) SubtractCartProductQuantity(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByIDs", reflect.TypeOf((*MockStore)(nil).GetUsersByIDs), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubtractCartProductQuantity", reflect.TypeOf((*MockStore)(nil).SubtractCartProductQuantity), arg0, arg1)
 }
 
-// ListCategories mocks base method.
-func (m *MockStore) ListCategories(arg0 context.Context, arg1 db.ListCategoriesParams) ([]db.Category, error) {
+// TouchApiKey mocks base method.
+func (m *MockStore) TouchApiKey(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListCategories", arg0, arg1)
-	ret0, _ := ret[0].([]db.Category)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "TouchApiKey", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListCategories indicates an expected call of ListCategories.
-func (mr *MockStoreMockRecorder) ListCategories(arg0, arg1 any) *gomock.Call {
+// TouchApiKey indicates an expected call of TouchApiKey.
+func (mr *MockStoreMockRecorder) TouchApiKey(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchApiKey", reflect.TypeOf((*MockStore)(nil).TouchApiKey), arg0, arg1)
 }
 
-// ListProducts mocks base method.
-func (m *MockStore) ListProducts(arg0 context.Context, arg1 db.ListProductsParams) ([]db.Product, error) {
+// TouchSession mocks base method.
+func (m *MockStore) TouchSession(arg0 context.Context, arg1 uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProducts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "TouchSession", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListProducts indicates an expected call of ListProducts.
-func (mr *MockStoreMockRecorder) ListProducts(arg0, arg1 any) *gomock.Call {
+// TouchSession indicates an expected call of TouchSession.
+func (mr *MockStoreMockRecorder) TouchSession(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProducts", reflect.TypeOf((*MockStore)(nil).ListProducts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSession", reflect.TypeOf((*MockStore)(nil).TouchSession), arg0, arg1)
 }
 
-// ListProductsBySeller mocks base method.
-func (m *MockStore) ListProductsBySeller(arg0 context.Context, arg1 db.ListProductsBySellerParams) ([]db.Product, error) {
+// TruncateApiKeysTable mocks base method.
+func (m *MockStore) TruncateApiKeysTable(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListProductsBySeller", arg0, arg1)
-	ret0, _ := ret[0].([]db.Product)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "TruncateApiKeysTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ListProductsBySeller indicates an expected call of ListProductsBySeller.
-func (mr *MockStoreMockRecorder) ListProductsBySeller(arg0, arg1 any) *gomock.Call {
+// TruncateApiKeysTable indicates an expected call of TruncateApiKeysTable.
+func (mr *MockStoreMockRecorder) TruncateApiKeysTable(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProductsBySeller", reflect.TypeOf((*MockStore)(nil).ListProductsBySeller), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateApiKeysTable", reflect.TypeOf((*MockStore)(nil).TruncateApiKeysTable), arg0)
 }
 
 // TruncateCartProductsTable mocks base method.
@@ -410,6 +1356,20 @@ func (mr *MockStoreMockRecorder) TruncateCategoriesTable(arg0 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateCategoriesTable", reflect.TypeOf((*MockStore)(nil).TruncateCategoriesTable), arg0)
 }
 
+// TruncateOrdersTable mocks base method.
+func (m *MockStore) TruncateOrdersTable(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateOrdersTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateOrdersTable indicates an expected call of TruncateOrdersTable.
+func (mr *MockStoreMockRecorder) TruncateOrdersTable(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateOrdersTable", reflect.TypeOf((*MockStore)(nil).TruncateOrdersTable), arg0)
+}
+
 // TruncateProductsTable mocks base method.
 func (m *MockStore) TruncateProductsTable(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -424,6 +1384,34 @@ func (mr *MockStoreMockRecorder) TruncateProductsTable(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateProductsTable", reflect.TypeOf((*MockStore)(nil).TruncateProductsTable), arg0)
 }
 
+// TruncateReviewsTable mocks base method.
+func (m *MockStore) TruncateReviewsTable(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateReviewsTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateReviewsTable indicates an expected call of TruncateReviewsTable.
+func (mr *MockStoreMockRecorder) TruncateReviewsTable(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateReviewsTable", reflect.TypeOf((*MockStore)(nil).TruncateReviewsTable), arg0)
+}
+
+// TruncateRotatedRefreshTokensTable mocks base method.
+func (m *MockStore) TruncateRotatedRefreshTokensTable(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateRotatedRefreshTokensTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateRotatedRefreshTokensTable indicates an expected call of TruncateRotatedRefreshTokensTable.
+func (mr *MockStoreMockRecorder) TruncateRotatedRefreshTokensTable(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateRotatedRefreshTokensTable", reflect.TypeOf((*MockStore)(nil).TruncateRotatedRefreshTokensTable), arg0)
+}
+
 // TruncateSessionsTable mocks base method.
 func (m *MockStore) TruncateSessionsTable(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -452,6 +1440,20 @@ func (mr *MockStoreMockRecorder) TruncateUsersTable(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateUsersTable", reflect.TypeOf((*MockStore)(nil).TruncateUsersTable), arg0)
 }
 
+// TruncateWishlistProductsTable mocks base method.
+func (m *MockStore) TruncateWishlistProductsTable(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TruncateWishlistProductsTable", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TruncateWishlistProductsTable indicates an expected call of TruncateWishlistProductsTable.
+func (mr *MockStoreMockRecorder) TruncateWishlistProductsTable(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TruncateWishlistProductsTable", reflect.TypeOf((*MockStore)(nil).TruncateWishlistProductsTable), arg0)
+}
+
 // UpdateCartProduct mocks base method.
 func (m *MockStore) UpdateCartProduct(arg0 context.Context, arg1 db.UpdateCartProductParams) (db.CartProduct, error) {
 	m.ctrl.T.Helper()
@@ -467,6 +1469,21 @@ func (mr *MockStoreMockRecorder) UpdateCartProduct(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartProduct", reflect.TypeOf((*MockStore)(nil).UpdateCartProduct), arg0, arg1)
 }
 
+// UpdateCategory mocks base method.
+func (m *MockStore) UpdateCategory(arg0 context.Context, arg1 db.UpdateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCategory indicates an expected call of UpdateCategory.
+func (mr *MockStoreMockRecorder) UpdateCategory(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCategory", reflect.TypeOf((*MockStore)(nil).UpdateCategory), arg0, arg1)
+}
+
 // UpdateProduct mocks base method.
 func (m *MockStore) UpdateProduct(arg0 context.Context, arg1 db.UpdateProductParams) (db.Product, error) {
 	m.ctrl.T.Helper()
@@ -482,6 +1499,21 @@ func (mr *MockStoreMockRecorder) UpdateProduct(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProduct", reflect.TypeOf((*MockStore)(nil).UpdateProduct), arg0, arg1)
 }
 
+// UpdateReview mocks base method.
+func (m *MockStore) UpdateReview(arg0 context.Context, arg1 db.UpdateReviewParams) (db.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", arg0, arg1)
+	ret0, _ := ret[0].(db.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockStoreMockRecorder) UpdateReview(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockStore)(nil).UpdateReview), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockStore) UpdateUser(arg0 context.Context, arg1 db.UpdateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -496,3 +1528,18 @@ func (mr *MockStoreMockRecorder) UpdateUser(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockStore)(nil).UpdateUser), arg0, arg1)
 }
+
+// UpdateUserRole mocks base method.
+func (m *MockStore) UpdateUserRole(arg0 context.Context, arg1 db.UpdateUserRoleParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockStoreMockRecorder) UpdateUserRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockStore)(nil).UpdateUserRole), arg0, arg1)
+}
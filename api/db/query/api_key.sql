@@ -0,0 +1,35 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (
+  user_id,
+  name,
+  key_prefix,
+  key_hash,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetApiKey :one
+SELECT * FROM api_keys
+WHERE id = $1 LIMIT 1;
+
+-- name: GetApiKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1 LIMIT 1;
+
+-- name: ListApiKeysByUserID :many
+SELECT * FROM api_keys
+WHERE user_id = $1
+ORDER BY created_at DESC, id;
+
+-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1;
+
+-- name: DeleteApiKey :exec
+DELETE FROM api_keys
+WHERE id = $1;
+
+-- name: TruncateApiKeysTable :exec
+TRUNCATE TABLE api_keys CASCADE;
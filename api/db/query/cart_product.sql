@@ -10,7 +10,8 @@ INSERT INTO cart_products (
 -- name: UpdateCartProduct :one
 UPDATE cart_products
 SET
-  quantity = $3
+  quantity = $3,
+  updated_at = now()
 WHERE user_id = $1 AND product_id = $2
 RETURNING *;
 
@@ -18,6 +19,18 @@ RETURNING *;
 DELETE FROM cart_products
 WHERE user_id = $1 AND product_id = $2;
 
+-- name: SubtractCartProductQuantity :one
+UPDATE cart_products
+SET
+  quantity = quantity - sqlc.arg(quantity),
+  updated_at = now()
+WHERE user_id = $1 AND product_id = $2 AND quantity >= sqlc.arg(quantity)
+RETURNING *;
+
+-- name: DeleteCartProductsByUserID :exec
+DELETE FROM cart_products
+WHERE user_id = $1;
+
 -- name: GetCartProductByUserIDAndProductID :one
 SELECT * FROM cart_products
 WHERE user_id = $1 AND product_id = $2
@@ -25,7 +38,17 @@ ORDER BY created_at;
 
 -- name: GetCartProductsByUserID :many
 SELECT * FROM cart_products
-WHERE user_id = $1;
+WHERE user_id = $1 AND product_id IN (
+  SELECT id FROM products WHERE deleted_at IS NULL
+);
+
+-- name: DeleteStaleCartProducts :execrows
+DELETE FROM cart_products
+WHERE user_id IN (
+  SELECT user_id FROM cart_products
+  GROUP BY user_id
+  HAVING max(updated_at) < sqlc.arg(inactive_since)::timestamptz
+);
 
 -- name: TruncateCartProductsTable :exec
 TRUNCATE TABLE cart_products CASCADE;
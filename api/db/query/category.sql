@@ -5,6 +5,22 @@ INSERT INTO categories (
   $1
 ) RETURNING *;
 
+-- name: CreateCategoryWithParent :one
+INSERT INTO categories (
+  name,
+  parent_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET
+  name = $2,
+  parent_id = $3
+WHERE id = $1
+RETURNING *;
+
 -- name: GetCategory :one
 SELECT * FROM categories
 WHERE id = $1 LIMIT 1;
@@ -20,6 +36,18 @@ ORDER BY created_at
 LIMIT $1
 OFFSET $2;
 
+-- name: ListAllCategories :many
+SELECT * FROM categories
+ORDER BY name;
+
+-- name: GetCategoryDescendantIDs :many
+WITH RECURSIVE descendants(id) AS (
+  SELECT sqlc.arg('id')::uuid
+  UNION
+  SELECT categories.id FROM categories JOIN descendants ON categories.parent_id = descendants.id
+)
+SELECT descendants.id::uuid FROM descendants;
+
 -- name: DeleteCategory :exec
 DELETE FROM categories
 WHERE id = $1;
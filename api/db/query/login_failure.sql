@@ -0,0 +1,34 @@
+-- name: GetLoginFailure :one
+SELECT * FROM login_failures
+WHERE scope = $1 AND key = $2 LIMIT 1;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_failures (
+  scope,
+  key,
+  failure_count
+) VALUES (
+  sqlc.arg(scope), sqlc.arg(key), 1
+)
+ON CONFLICT (scope, key) DO UPDATE
+SET
+  failure_count = CASE
+    WHEN GREATEST(login_failures.last_failed_at, login_failures.locked_until) < sqlc.arg(reset_before) THEN 1
+    ELSE login_failures.failure_count + 1
+  END,
+  last_failed_at = now()
+RETURNING *;
+
+-- name: LockLogin :one
+UPDATE login_failures
+SET locked_until = $3
+WHERE scope = $1 AND key = $2
+RETURNING *;
+
+-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND key = $2;
+
+-- name: DeleteStaleLoginFailures :execrows
+DELETE FROM login_failures
+WHERE GREATEST(last_failed_at, locked_until) < sqlc.arg(stale_before);
@@ -0,0 +1,14 @@
+-- name: CreateLoginLockoutEvent :one
+INSERT INTO login_lockout_events (
+  scope,
+  key,
+  failure_count,
+  locked_until
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListLoginLockoutEvents :many
+SELECT * FROM login_lockout_events
+WHERE scope = $1 AND key = $2
+ORDER BY created_at DESC, id;
@@ -0,0 +1,28 @@
+-- name: CreateOrder :one
+INSERT INTO orders (
+  user_id,
+  subtotal,
+  shipping,
+  tax,
+  total
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = $1 LIMIT 1;
+
+-- name: ListOrdersByUser :many
+SELECT * FROM orders
+WHERE user_id = sqlc.arg('user_id')
+ORDER BY created_at DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: CountOrdersByUser :one
+SELECT count(*) FROM orders
+WHERE user_id = sqlc.arg('user_id');
+
+-- name: TruncateOrdersTable :exec
+TRUNCATE TABLE orders CASCADE;
@@ -0,0 +1,28 @@
+-- name: CreateOrderItem :one
+INSERT INTO order_items (
+  order_id,
+  product_id,
+  name,
+  description,
+  price,
+  quantity,
+  image_url
+) VALUES (
+  sqlc.arg('order_id'),
+  sqlc.arg('product_id'),
+  sqlc.arg('name'),
+  sqlc.narg('description'),
+  sqlc.arg('price'),
+  sqlc.arg('quantity'),
+  sqlc.narg('image_url')
+) RETURNING *;
+
+-- name: GetOrderItemsByOrderID :many
+SELECT * FROM order_items
+WHERE order_id = $1
+ORDER BY created_at;
+
+-- name: GetOrderItemsByOrderIDs :many
+SELECT * FROM order_items
+WHERE order_id = ANY((sqlc.arg('order_ids'))::uuid[])
+ORDER BY created_at;
@@ -19,28 +19,128 @@ INSERT INTO products (
 
 -- name: GetProduct :one
 SELECT * FROM products
-WHERE id = $1 LIMIT 1;
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1;
 
 -- name: ListProducts :many
 SELECT * FROM products
-WHERE category_id = sqlc.narg('category_id') OR sqlc.narg('category_id') IS NULL
-ORDER BY created_at
+WHERE (category_id = ANY(sqlc.narg('category_ids')::uuid[]) OR sqlc.narg('category_ids')::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', sqlc.narg('query')) OR sqlc.narg('query') IS NULL)
+  AND (price >= sqlc.narg('min_price') OR sqlc.narg('min_price') IS NULL)
+  AND (price <= sqlc.narg('max_price') OR sqlc.narg('max_price') IS NULL)
+  AND (stock_quantity > 0 OR NOT sqlc.arg('in_stock')::boolean)
+  AND (seller_id = sqlc.narg('seller_id') OR sqlc.narg('seller_id') IS NULL)
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN sqlc.arg('sort')::text = 'price_asc' THEN price END ASC,
+  CASE WHEN sqlc.arg('sort')::text = 'price_desc' THEN price END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'newest' THEN created_at END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'name' THEN name END ASC,
+  ts_rank(search_vector, websearch_to_tsquery('english', sqlc.narg('query'))) DESC,
+  created_at,
+  id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListProductsByCursor :many
+SELECT * FROM products
+WHERE (category_id = ANY(sqlc.narg('category_ids')::uuid[]) OR sqlc.narg('category_ids')::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', sqlc.narg('query')) OR sqlc.narg('query') IS NULL)
+  AND (price >= sqlc.narg('min_price') OR sqlc.narg('min_price') IS NULL)
+  AND (price <= sqlc.narg('max_price') OR sqlc.narg('max_price') IS NULL)
+  AND (stock_quantity > 0 OR NOT sqlc.arg('in_stock')::boolean)
+  AND (seller_id = sqlc.narg('seller_id') OR sqlc.narg('seller_id') IS NULL)
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (NOT sqlc.arg('newest')::boolean AND (created_at, id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+    OR (sqlc.arg('newest')::boolean AND (created_at, id) < (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+  )
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN sqlc.arg('newest')::boolean THEN created_at END DESC,
+  CASE WHEN sqlc.arg('newest')::boolean THEN id END DESC,
+  created_at,
+  id
+LIMIT $1;
+
 -- name: CountProducts :one
-SELECT count(*) FROM products;
+SELECT count(*) FROM products
+WHERE (category_id = ANY(sqlc.narg('category_ids')::uuid[]) OR sqlc.narg('category_ids')::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', sqlc.narg('query')) OR sqlc.narg('query') IS NULL)
+  AND (price >= sqlc.narg('min_price') OR sqlc.narg('min_price') IS NULL)
+  AND (price <= sqlc.narg('max_price') OR sqlc.narg('max_price') IS NULL)
+  AND (stock_quantity > 0 OR NOT sqlc.arg('in_stock')::boolean)
+  AND (seller_id = sqlc.narg('seller_id') OR sqlc.narg('seller_id') IS NULL)
+  AND deleted_at IS NULL;
+
+-- name: CountProductsByCategory :many
+-- Products are counted for their category and every ancestor of it, since filtering by a category
+-- includes its descendants.
+WITH RECURSIVE category_tree(ancestor_id, id) AS (
+  SELECT categories.id, categories.id FROM categories
+  UNION
+  SELECT category_tree.ancestor_id, categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+)
+SELECT
+  categories.id AS category_id,
+  categories.name AS category,
+  count(*) AS count
+FROM products
+JOIN category_tree ON category_tree.id = products.category_id
+JOIN categories ON categories.id = category_tree.ancestor_id
+WHERE (products.search_vector @@ websearch_to_tsquery('english', sqlc.narg('query')) OR sqlc.narg('query') IS NULL)
+  AND (products.price >= sqlc.narg('min_price') OR sqlc.narg('min_price') IS NULL)
+  AND (products.price <= sqlc.narg('max_price') OR sqlc.narg('max_price') IS NULL)
+  AND (products.stock_quantity > 0 OR NOT sqlc.arg('in_stock')::boolean)
+  AND (products.seller_id = sqlc.narg('seller_id') OR sqlc.narg('seller_id') IS NULL)
+  AND products.deleted_at IS NULL
+GROUP BY categories.id, categories.name
+ORDER BY categories.name;
+
+-- name: CountProductsByPriceBucket :many
+SELECT
+  width_bucket(price, sqlc.arg('bounds')::decimal[])::int AS bucket,
+  count(*) AS count
+FROM products
+WHERE (category_id = ANY(sqlc.narg('category_ids')::uuid[]) OR sqlc.narg('category_ids')::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', sqlc.narg('query')) OR sqlc.narg('query') IS NULL)
+  AND (stock_quantity > 0 OR NOT sqlc.arg('in_stock')::boolean)
+  AND (seller_id = sqlc.narg('seller_id') OR sqlc.narg('seller_id') IS NULL)
+  AND deleted_at IS NULL
+GROUP BY bucket
+ORDER BY bucket;
 
 -- name: ListProductsBySeller :many
 SELECT * FROM products
-WHERE seller_id = sqlc.arg('seller_id')
-ORDER BY created_at
+WHERE seller_id = sqlc.arg('seller_id') AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN sqlc.arg('sort')::text = 'price_asc' THEN price END ASC,
+  CASE WHEN sqlc.arg('sort')::text = 'price_desc' THEN price END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'newest' THEN created_at END DESC,
+  CASE WHEN sqlc.arg('sort')::text = 'name' THEN name END ASC,
+  created_at,
+  id
 LIMIT $1
 OFFSET $2;
 
+-- name: ListProductsBySellerByCursor :many
+SELECT * FROM products
+WHERE seller_id = sqlc.arg('seller_id')
+  AND (
+    sqlc.narg('cursor_created_at')::timestamptz IS NULL
+    OR (NOT sqlc.arg('newest')::boolean AND (created_at, id) > (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+    OR (sqlc.arg('newest')::boolean AND (created_at, id) < (sqlc.narg('cursor_created_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+  )
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN sqlc.arg('newest')::boolean THEN created_at END DESC,
+  CASE WHEN sqlc.arg('newest')::boolean THEN id END DESC,
+  created_at,
+  id
+LIMIT $1;
+
 -- name: CountProductsBySeller :one
 SELECT count(*) FROM products
-WHERE seller_id = sqlc.arg('seller_id');
+WHERE seller_id = sqlc.arg('seller_id') AND deleted_at IS NULL;
 
 -- name: AddProduct :one
 INSERT INTO products (
@@ -71,8 +171,21 @@ SET
   category_id = sqlc.arg('category_id'),
   seller_id = sqlc.arg('seller_id'),
   image_url = sqlc.narg('image_url')
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING *;
+
+-- name: DecrementProductStock :one
+UPDATE products
+SET
+  stock_quantity = stock_quantity - sqlc.arg('quantity')
+WHERE id = sqlc.arg('id') AND stock_quantity >= sqlc.arg('quantity') AND deleted_at IS NULL
 RETURNING *;
 
+-- name: SoftDeleteProduct :exec
+UPDATE products
+SET
+  deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL;
+
 -- name: TruncateProductsTable :exec
 TRUNCATE TABLE products CASCADE;
@@ -0,0 +1,55 @@
+-- name: CreateReview :one
+INSERT INTO reviews (
+  product_id,
+  user_id,
+  rating,
+  title,
+  body
+) VALUES (
+  sqlc.arg('product_id'),
+  sqlc.arg('user_id'),
+  sqlc.arg('rating'),
+  sqlc.arg('title'),
+  sqlc.narg('body')
+) RETURNING *;
+
+-- name: GetReviewByProductIDAndUserID :one
+SELECT * FROM reviews
+WHERE product_id = $1 AND user_id = $2 LIMIT 1;
+
+-- name: ListReviewsByProductID :many
+SELECT * FROM reviews
+WHERE product_id = sqlc.arg('product_id')
+ORDER BY created_at DESC, id
+LIMIT $1
+OFFSET $2;
+
+-- name: CountReviewsByProductID :one
+SELECT count(*) FROM reviews
+WHERE product_id = sqlc.arg('product_id');
+
+-- name: GetProductRatingsByProductIDs :many
+SELECT
+  product_id,
+  round(avg(rating), 2)::float8 AS average_rating,
+  count(*) AS review_count
+FROM reviews
+WHERE product_id = ANY(sqlc.arg('product_ids')::uuid[])
+GROUP BY product_id;
+
+-- name: UpdateReview :one
+UPDATE reviews
+SET
+  rating = sqlc.arg('rating'),
+  title = sqlc.arg('title'),
+  body = sqlc.narg('body'),
+  updated_at = now()
+WHERE product_id = sqlc.arg('product_id') AND user_id = sqlc.arg('user_id')
+RETURNING *;
+
+-- name: DeleteReview :exec
+DELETE FROM reviews
+WHERE product_id = $1 AND user_id = $2;
+
+-- name: TruncateReviewsTable :exec
+TRUNCATE TABLE reviews CASCADE;
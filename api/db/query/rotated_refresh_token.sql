@@ -0,0 +1,19 @@
+-- name: CreateRotatedRefreshToken :exec
+INSERT INTO rotated_refresh_tokens (
+  refresh_token_hash,
+  family_id,
+  expired_at
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: GetRotatedRefreshToken :one
+SELECT * FROM rotated_refresh_tokens
+WHERE refresh_token_hash = $1 LIMIT 1;
+
+-- name: DeleteExpiredRotatedRefreshTokens :execrows
+DELETE FROM rotated_refresh_tokens
+WHERE expired_at < sqlc.arg(expired_before);
+
+-- name: TruncateRotatedRefreshTokensTable :exec
+TRUNCATE TABLE rotated_refresh_tokens CASCADE;
@@ -1,21 +1,72 @@
 -- name: CreateSession :one
 INSERT INTO sessions (
   user_id,
-  session_token,
+  session_token_hash,
   session_token_expired_at,
-  refresh_token,
-  refresh_token_expired_at
+  refresh_token_hash,
+  refresh_token_expired_at,
+  user_agent,
+  client_ip
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 -- name: GetSession :one
 SELECT * FROM sessions
-WHERE session_token = $1 LIMIT 1;
+WHERE session_token_hash = $1 LIMIT 1;
+
+-- name: GetSessionByID :one
+SELECT * FROM sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetSessionByFamilyID :one
+SELECT * FROM sessions
+WHERE family_id = $1 LIMIT 1;
+
+-- name: ListSessionsByUserID :many
+SELECT * FROM sessions
+WHERE user_id = $1 AND refresh_token_expired_at > now()
+ORDER BY last_used_at DESC, id;
+
+-- name: RotateSessionTokens :one
+UPDATE sessions
+SET
+  session_token_hash = sqlc.arg(session_token_hash),
+  session_token_expired_at = sqlc.arg(session_token_expired_at),
+  refresh_token_hash = sqlc.arg(refresh_token_hash),
+  refresh_token_expired_at = sqlc.arg(refresh_token_expired_at),
+  last_used_at = now()
+WHERE refresh_token_hash = sqlc.arg(current_refresh_token_hash) AND refresh_token_expired_at > now()
+RETURNING *;
+
+-- name: TouchSession :exec
+UPDATE sessions
+SET last_used_at = now()
+WHERE id = $1;
 
 -- name: DeleteSession :exec
 DELETE FROM sessions
-WHERE session_token = $1;
+WHERE session_token_hash = $1;
+
+-- name: DeleteSessionByID :exec
+DELETE FROM sessions
+WHERE id = $1;
+
+-- name: DeleteSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = $1;
+
+-- name: DeleteOtherSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = sqlc.arg(user_id) AND id <> sqlc.arg(current_session_id);
+
+-- name: DeleteSessionsByFamilyID :exec
+DELETE FROM sessions
+WHERE family_id = $1;
+
+-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE refresh_token_expired_at < sqlc.arg(expired_before);
 
 -- name: TruncateSessionsTable :exec
 TRUNCATE TABLE sessions CASCADE;
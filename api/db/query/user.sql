@@ -29,5 +29,21 @@ SELECT * FROM users
 WHERE id = ANY((sqlc.arg('ids'))::uuid[])
 ORDER BY id;
 
+-- name: ListUsers :many
+SELECT * FROM users
+ORDER BY created_at
+LIMIT $1
+OFFSET $2;
+
+-- name: CountUsers :one
+SELECT count(*) FROM users;
+
+-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2
+WHERE id = $1
+RETURNING *;
+
 -- name: TruncateUsersTable :exec
 TRUNCATE TABLE users CASCADE;
@@ -0,0 +1,25 @@
+-- name: CreateWishlistProduct :one
+INSERT INTO wishlist_products (
+  user_id,
+  product_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: DeleteWishlistProduct :exec
+DELETE FROM wishlist_products
+WHERE user_id = $1 AND product_id = $2;
+
+-- name: GetWishlistProductByUserIDAndProductID :one
+SELECT * FROM wishlist_products
+WHERE user_id = $1 AND product_id = $2;
+
+-- name: GetWishlistProductsByUserID :many
+SELECT * FROM wishlist_products
+WHERE user_id = $1 AND product_id IN (
+  SELECT id FROM products WHERE deleted_at IS NULL
+)
+ORDER BY created_at DESC;
+
+-- name: TruncateWishlistProductsTable :exec
+TRUNCATE TABLE wishlist_products CASCADE;
@@ -0,0 +1,165 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: api_key.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO api_keys (
+  user_id,
+  name,
+  key_prefix,
+  key_hash,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, user_id, name, key_prefix, key_hash, scopes, last_used_at, created_at
+`
+
+type CreateApiKeyParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	KeyPrefix string    `json:"key_prefix"`
+	KeyHash   []byte    `json:"key_hash"`
+	Scopes    []string  `json:"scopes"`
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createApiKey,
+		arg.UserID,
+		arg.Name,
+		arg.KeyPrefix,
+		arg.KeyHash,
+		pq.Array(arg.Scopes),
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteApiKey = `-- name: DeleteApiKey :exec
+DELETE FROM api_keys
+WHERE id = $1
+`
+
+func (q *Queries) DeleteApiKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteApiKey, id)
+	return err
+}
+
+const getApiKey = `-- name: GetApiKey :one
+SELECT id, user_id, name, key_prefix, key_hash, scopes, last_used_at, created_at FROM api_keys
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetApiKey(ctx context.Context, id uuid.UUID) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getApiKeyByHash = `-- name: GetApiKeyByHash :one
+SELECT id, user_id, name, key_prefix, key_hash, scopes, last_used_at, created_at FROM api_keys
+WHERE key_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetApiKeyByHash(ctx context.Context, keyHash []byte) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getApiKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		pq.Array(&i.Scopes),
+		&i.LastUsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listApiKeysByUserID = `-- name: ListApiKeysByUserID :many
+SELECT id, user_id, name, key_prefix, key_hash, scopes, last_used_at, created_at FROM api_keys
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+`
+
+func (q *Queries) ListApiKeysByUserID(ctx context.Context, userID uuid.UUID) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listApiKeysByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.KeyPrefix,
+			&i.KeyHash,
+			pq.Array(&i.Scopes),
+			&i.LastUsedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) TouchApiKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchApiKey, id)
+	return err
+}
+
+const truncateApiKeysTable = `-- name: TruncateApiKeysTable :exec
+TRUNCATE TABLE api_keys CASCADE
+`
+
+func (q *Queries) TruncateApiKeysTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, truncateApiKeysTable)
+	return err
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomApiKey(t *testing.T, testQueries *Queries, user User) ApiKey {
+	arg := CreateApiKeyParams{
+		UserID:    user.ID,
+		Name:      util.RandomName(),
+		KeyPrefix: "nbz_" + util.RandomString(8),
+		KeyHash:   util.RandomTokenHash(),
+		Scopes:    []string{"catalog:read", "cart:manage"},
+	}
+
+	apiKey, err := testQueries.CreateApiKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, apiKey)
+
+	require.Equal(t, arg.UserID, apiKey.UserID)
+	require.Equal(t, arg.Name, apiKey.Name)
+	require.Equal(t, arg.KeyPrefix, apiKey.KeyPrefix)
+	require.Equal(t, arg.KeyHash, apiKey.KeyHash)
+	require.Equal(t, arg.Scopes, apiKey.Scopes)
+	require.False(t, apiKey.LastUsedAt.Valid)
+
+	require.NotEmpty(t, apiKey.ID)
+	require.NotZero(t, apiKey.CreatedAt)
+
+	return apiKey
+}
+
+func TestCreateApiKey(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	createRandomApiKey(t, testQueries, user)
+}
+
+func TestCreateApiKeyInvalidScope(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+
+	_, err := testQueries.CreateApiKey(context.Background(), CreateApiKeyParams{
+		UserID:    user.ID,
+		Name:      util.RandomName(),
+		KeyPrefix: "nbz_" + util.RandomString(8),
+		KeyHash:   util.RandomTokenHash(),
+		Scopes:    []string{"admin"},
+	})
+	require.Error(t, err)
+}
+
+func TestGetApiKeyByHash(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	apiKey1 := createRandomApiKey(t, testQueries, user)
+
+	apiKey2, err := testQueries.GetApiKeyByHash(context.Background(), apiKey1.KeyHash)
+	require.NoError(t, err)
+
+	require.Equal(t, apiKey1.ID, apiKey2.ID)
+	require.Equal(t, apiKey1.UserID, apiKey2.UserID)
+	require.Equal(t, apiKey1.Scopes, apiKey2.Scopes)
+	require.WithinDuration(t, apiKey1.CreatedAt, apiKey2.CreatedAt, time.Second)
+
+	apiKey3, err := testQueries.GetApiKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+	require.Equal(t, apiKey1.KeyHash, apiKey3.KeyHash)
+}
+
+func TestListApiKeysByUserID(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	for i := 0; i < 3; i++ {
+		createRandomApiKey(t, testQueries, user)
+	}
+
+	otherUser := createRandomUser(t, testQueries)
+	createRandomApiKey(t, testQueries, otherUser)
+
+	apiKeys, err := testQueries.ListApiKeysByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, apiKeys, 3)
+
+	for _, apiKey := range apiKeys {
+		require.Equal(t, user.ID, apiKey.UserID)
+	}
+}
+
+func TestTouchApiKey(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	apiKey1 := createRandomApiKey(t, testQueries, user)
+
+	err := testQueries.TouchApiKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+
+	apiKey2, err := testQueries.GetApiKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+	require.True(t, apiKey2.LastUsedAt.Valid)
+	require.WithinDuration(t, time.Now(), apiKey2.LastUsedAt.Time, time.Second)
+}
+
+func TestDeleteApiKey(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	apiKey1 := createRandomApiKey(t, testQueries, user)
+
+	err := testQueries.DeleteApiKey(context.Background(), apiKey1.ID)
+	require.NoError(t, err)
+
+	apiKey2, err := testQueries.GetApiKey(context.Background(), apiKey1.ID)
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, apiKey2)
+}
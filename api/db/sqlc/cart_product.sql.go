@@ -7,6 +7,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -18,7 +19,7 @@ INSERT INTO cart_products (
   quantity
 ) VALUES (
   $1, $2, $3
-) RETURNING user_id, product_id, quantity, created_at
+) RETURNING user_id, product_id, quantity, created_at, updated_at
 `
 
 type CreateCartProductParams struct {
@@ -35,6 +36,7 @@ func (q *Queries) CreateCartProduct(ctx context.Context, arg CreateCartProductPa
 		&i.ProductID,
 		&i.Quantity,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -54,8 +56,35 @@ func (q *Queries) DeleteCartProduct(ctx context.Context, arg DeleteCartProductPa
 	return err
 }
 
+const deleteCartProductsByUserID = `-- name: DeleteCartProductsByUserID :exec
+DELETE FROM cart_products
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteCartProductsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteCartProductsByUserID, userID)
+	return err
+}
+
+const deleteStaleCartProducts = `-- name: DeleteStaleCartProducts :execrows
+DELETE FROM cart_products
+WHERE user_id IN (
+  SELECT user_id FROM cart_products
+  GROUP BY user_id
+  HAVING max(updated_at) < $1::timestamptz
+)
+`
+
+func (q *Queries) DeleteStaleCartProducts(ctx context.Context, inactiveSince time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStaleCartProducts, inactiveSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getCartProductByUserIDAndProductID = `-- name: GetCartProductByUserIDAndProductID :one
-SELECT user_id, product_id, quantity, created_at FROM cart_products
+SELECT user_id, product_id, quantity, created_at, updated_at FROM cart_products
 WHERE user_id = $1 AND product_id = $2
 ORDER BY created_at
 `
@@ -73,13 +102,16 @@ func (q *Queries) GetCartProductByUserIDAndProductID(ctx context.Context, arg Ge
 		&i.ProductID,
 		&i.Quantity,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const getCartProductsByUserID = `-- name: GetCartProductsByUserID :many
-SELECT user_id, product_id, quantity, created_at FROM cart_products
-WHERE user_id = $1
+SELECT user_id, product_id, quantity, created_at, updated_at FROM cart_products
+WHERE user_id = $1 AND product_id IN (
+  SELECT id FROM products WHERE deleted_at IS NULL
+)
 `
 
 func (q *Queries) GetCartProductsByUserID(ctx context.Context, userID uuid.UUID) ([]CartProduct, error) {
@@ -96,6 +128,7 @@ func (q *Queries) GetCartProductsByUserID(ctx context.Context, userID uuid.UUID)
 			&i.ProductID,
 			&i.Quantity,
 			&i.CreatedAt,
+			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -110,6 +143,34 @@ func (q *Queries) GetCartProductsByUserID(ctx context.Context, userID uuid.UUID)
 	return items, nil
 }
 
+const subtractCartProductQuantity = `-- name: SubtractCartProductQuantity :one
+UPDATE cart_products
+SET
+  quantity = quantity - $3,
+  updated_at = now()
+WHERE user_id = $1 AND product_id = $2 AND quantity >= $3
+RETURNING user_id, product_id, quantity, created_at, updated_at
+`
+
+type SubtractCartProductQuantityParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+	Quantity  int32     `json:"quantity"`
+}
+
+func (q *Queries) SubtractCartProductQuantity(ctx context.Context, arg SubtractCartProductQuantityParams) (CartProduct, error) {
+	row := q.db.QueryRowContext(ctx, subtractCartProductQuantity, arg.UserID, arg.ProductID, arg.Quantity)
+	var i CartProduct
+	err := row.Scan(
+		&i.UserID,
+		&i.ProductID,
+		&i.Quantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const truncateCartProductsTable = `-- name: TruncateCartProductsTable :exec
 TRUNCATE TABLE cart_products CASCADE
 `
@@ -122,9 +183,10 @@ func (q *Queries) TruncateCartProductsTable(ctx context.Context) error {
 const updateCartProduct = `-- name: UpdateCartProduct :one
 UPDATE cart_products
 SET
-  quantity = $3
+  quantity = $3,
+  updated_at = now()
 WHERE user_id = $1 AND product_id = $2
-RETURNING user_id, product_id, quantity, created_at
+RETURNING user_id, product_id, quantity, created_at, updated_at
 `
 
 type UpdateCartProductParams struct {
@@ -141,6 +203,7 @@ func (q *Queries) UpdateCartProduct(ctx context.Context, arg UpdateCartProductPa
 		&i.ProductID,
 		&i.Quantity,
 		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomCartProduct(t *testing.T, testQueries *Queries, user User) CartProduct {
+	product := createRandomProductWithStock(t, testQueries, 10)
+
+	arg := CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  1,
+	}
+
+	cartProduct, err := testQueries.CreateCartProduct(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.UserID, cartProduct.UserID)
+	require.Equal(t, arg.ProductID, cartProduct.ProductID)
+	require.Equal(t, arg.Quantity, cartProduct.Quantity)
+	require.NotZero(t, cartProduct.CreatedAt)
+	require.NotZero(t, cartProduct.UpdatedAt)
+
+	return cartProduct
+}
+
+func TestDeleteStaleCartProducts(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	staleUser := createRandomUser(t, testQueries)
+	activeUser := createRandomUser(t, testQueries)
+
+	for i := 0; i < 2; i++ {
+		createRandomCartProduct(t, testQueries, staleUser)
+	}
+
+	// A cart stays active as long as any of its products was added or changed recently
+	activeCartProduct := createRandomCartProduct(t, testQueries, activeUser)
+	createRandomCartProduct(t, testQueries, activeUser)
+
+	for _, user := range []User{staleUser, activeUser} {
+		_, err := db.Exec(
+			"UPDATE cart_products SET created_at = $1, updated_at = $1 WHERE user_id = $2",
+			time.Now().Add(-48*time.Hour), user.ID,
+		)
+		require.NoError(t, err)
+	}
+
+	updatedCartProduct, err := testQueries.UpdateCartProduct(context.Background(), UpdateCartProductParams{
+		UserID:    activeUser.ID,
+		ProductID: activeCartProduct.ProductID,
+		Quantity:  2,
+	})
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), updatedCartProduct.UpdatedAt, time.Minute)
+
+	count, err := testQueries.DeleteStaleCartProducts(context.Background(), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(2))
+
+	staleCart, err := testQueries.GetCartProductsByUserID(context.Background(), staleUser.ID)
+	require.NoError(t, err)
+	require.Empty(t, staleCart)
+
+	activeCart, err := testQueries.GetCartProductsByUserID(context.Background(), activeUser.ID)
+	require.NoError(t, err)
+	require.Len(t, activeCart, 2)
+}
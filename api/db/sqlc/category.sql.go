@@ -17,13 +17,44 @@ INSERT INTO categories (
   name
 ) VALUES (
   $1
-) RETURNING id, name, created_at
+) RETURNING id, name, created_at, parent_id
 `
 
 func (q *Queries) CreateCategory(ctx context.Context, name string) (Category, error) {
 	row := q.db.QueryRowContext(ctx, createCategory, name)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const createCategoryWithParent = `-- name: CreateCategoryWithParent :one
+INSERT INTO categories (
+  name,
+  parent_id
+) VALUES (
+  $1, $2
+) RETURNING id, name, created_at, parent_id
+`
+
+type CreateCategoryWithParentParams struct {
+	Name     string        `json:"name"`
+	ParentID uuid.NullUUID `json:"parent_id"`
+}
+
+func (q *Queries) CreateCategoryWithParent(ctx context.Context, arg CreateCategoryWithParentParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, createCategoryWithParent, arg.Name, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ParentID,
+	)
 	return i, err
 }
 
@@ -38,7 +69,7 @@ func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getCategoriesByIDs = `-- name: GetCategoriesByIDs :many
-SELECT id, name, created_at FROM categories
+SELECT id, name, created_at, parent_id FROM categories
 WHERE id = ANY(($1)::uuid[])
 ORDER BY id
 `
@@ -52,7 +83,12 @@ func (q *Queries) GetCategoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Ca
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ParentID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -67,19 +103,89 @@ func (q *Queries) GetCategoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Ca
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, name, created_at FROM categories
+SELECT id, name, created_at, parent_id FROM categories
 WHERE id = $1 LIMIT 1
 `
 
 func (q *Queries) GetCategory(ctx context.Context, id uuid.UUID) (Category, error) {
 	row := q.db.QueryRowContext(ctx, getCategory, id)
 	var i Category
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ParentID,
+	)
 	return i, err
 }
 
+const getCategoryDescendantIDs = `-- name: GetCategoryDescendantIDs :many
+WITH RECURSIVE descendants(id) AS (
+  SELECT $1::uuid
+  UNION
+  SELECT categories.id FROM categories JOIN descendants ON categories.parent_id = descendants.id
+)
+SELECT descendants.id::uuid FROM descendants
+`
+
+func (q *Queries) GetCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getCategoryDescendantIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var descendants_id uuid.UUID
+		if err := rows.Scan(&descendants_id); err != nil {
+			return nil, err
+		}
+		items = append(items, descendants_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllCategories = `-- name: ListAllCategories :many
+SELECT id, name, created_at, parent_id FROM categories
+ORDER BY name
+`
+
+func (q *Queries) ListAllCategories(ctx context.Context) ([]Category, error) {
+	rows, err := q.db.QueryContext(ctx, listAllCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Category{}
+	for rows.Next() {
+		var i Category
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCategories = `-- name: ListCategories :many
-SELECT id, name, created_at FROM categories
+SELECT id, name, created_at, parent_id FROM categories
 ORDER BY created_at
 LIMIT $1
 OFFSET $2
@@ -99,7 +205,12 @@ func (q *Queries) ListCategories(ctx context.Context, arg ListCategoriesParams)
 	items := []Category{}
 	for rows.Next() {
 		var i Category
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ParentID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -121,3 +232,30 @@ func (q *Queries) TruncateCategoriesTable(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, truncateCategoriesTable)
 	return err
 }
+
+const updateCategory = `-- name: UpdateCategory :one
+UPDATE categories
+SET
+  name = $2,
+  parent_id = $3
+WHERE id = $1
+RETURNING id, name, created_at, parent_id
+`
+
+type UpdateCategoryParams struct {
+	ID       uuid.UUID     `json:"id"`
+	Name     string        `json:"name"`
+	ParentID uuid.NullUUID `json:"parent_id"`
+}
+
+func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
+	row := q.db.QueryRowContext(ctx, updateCategory, arg.ID, arg.Name, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ParentID,
+	)
+	return i, err
+}
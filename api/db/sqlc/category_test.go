@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ot07/next-bazaar/test_util"
 	"github.com/ot07/next-bazaar/util"
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,73 @@ func TestDeleteCategory(t *testing.T) {
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, category2)
 }
+
+func createRandomChildCategory(t *testing.T, testQueries *Queries, parentID uuid.UUID) Category {
+	name := util.RandomName()
+
+	category, err := testQueries.CreateCategoryWithParent(context.Background(), CreateCategoryWithParentParams{
+		Name:     name,
+		ParentID: uuid.NullUUID{UUID: parentID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, name, category.Name)
+	require.Equal(t, uuid.NullUUID{UUID: parentID, Valid: true}, category.ParentID)
+
+	return category
+}
+
+func TestUpdateCategory(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	parent := createRandomCategory(t, testQueries)
+	category := createRandomCategory(t, testQueries)
+	require.False(t, category.ParentID.Valid)
+
+	newName := util.RandomName()
+	updatedCategory, err := testQueries.UpdateCategory(context.Background(), UpdateCategoryParams{
+		ID:       category.ID,
+		Name:     newName,
+		ParentID: uuid.NullUUID{UUID: parent.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, category.ID, updatedCategory.ID)
+	require.Equal(t, newName, updatedCategory.Name)
+	require.Equal(t, uuid.NullUUID{UUID: parent.ID, Valid: true}, updatedCategory.ParentID)
+
+	_, err = testQueries.UpdateCategory(context.Background(), UpdateCategoryParams{
+		ID:       category.ID,
+		Name:     newName,
+		ParentID: uuid.NullUUID{UUID: category.ID, Valid: true},
+	})
+	require.Error(t, err)
+}
+
+func TestGetCategoryDescendantIDs(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	root := createRandomCategory(t, testQueries)
+	child := createRandomChildCategory(t, testQueries, root.ID)
+	grandchild := createRandomChildCategory(t, testQueries, child.ID)
+	other := createRandomCategory(t, testQueries)
+
+	ids, err := testQueries.GetCategoryDescendantIDs(context.Background(), root.ID)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{root.ID, child.ID, grandchild.ID}, ids)
+	require.NotContains(t, ids, other.ID)
+
+	ids, err = testQueries.GetCategoryDescendantIDs(context.Background(), grandchild.ID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{grandchild.ID}, ids)
+}
@@ -0,0 +1,126 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: login_failure.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+const deleteLoginFailure = `-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND key = $2
+`
+
+type DeleteLoginFailureParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error {
+	_, err := q.db.ExecContext(ctx, deleteLoginFailure, arg.Scope, arg.Key)
+	return err
+}
+
+const deleteStaleLoginFailures = `-- name: DeleteStaleLoginFailures :execrows
+DELETE FROM login_failures
+WHERE GREATEST(last_failed_at, locked_until) < $1
+`
+
+func (q *Queries) DeleteStaleLoginFailures(ctx context.Context, staleBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteStaleLoginFailures, staleBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getLoginFailure = `-- name: GetLoginFailure :one
+SELECT scope, key, failure_count, last_failed_at, locked_until FROM login_failures
+WHERE scope = $1 AND key = $2 LIMIT 1
+`
+
+type GetLoginFailureParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (LoginFailure, error) {
+	row := q.db.QueryRowContext(ctx, getLoginFailure, arg.Scope, arg.Key)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailureCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const lockLogin = `-- name: LockLogin :one
+UPDATE login_failures
+SET locked_until = $3
+WHERE scope = $1 AND key = $2
+RETURNING scope, key, failure_count, last_failed_at, locked_until
+`
+
+type LockLoginParams struct {
+	Scope       string       `json:"scope"`
+	Key         string       `json:"key"`
+	LockedUntil sql.NullTime `json:"locked_until"`
+}
+
+func (q *Queries) LockLogin(ctx context.Context, arg LockLoginParams) (LoginFailure, error) {
+	row := q.db.QueryRowContext(ctx, lockLogin, arg.Scope, arg.Key, arg.LockedUntil)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailureCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
+
+const recordLoginFailure = `-- name: RecordLoginFailure :one
+INSERT INTO login_failures (
+  scope,
+  key,
+  failure_count
+) VALUES (
+  $1, $2, 1
+)
+ON CONFLICT (scope, key) DO UPDATE
+SET
+  failure_count = CASE
+    WHEN GREATEST(login_failures.last_failed_at, login_failures.locked_until) < $3 THEN 1
+    ELSE login_failures.failure_count + 1
+  END,
+  last_failed_at = now()
+RETURNING scope, key, failure_count, last_failed_at, locked_until
+`
+
+type RecordLoginFailureParams struct {
+	Scope       string    `json:"scope"`
+	Key         string    `json:"key"`
+	ResetBefore time.Time `json:"reset_before"`
+}
+
+func (q *Queries) RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error) {
+	row := q.db.QueryRowContext(ctx, recordLoginFailure, arg.Scope, arg.Key, arg.ResetBefore)
+	var i LoginFailure
+	err := row.Scan(
+		&i.Scope,
+		&i.Key,
+		&i.FailureCount,
+		&i.LastFailedAt,
+		&i.LockedUntil,
+	)
+	return i, err
+}
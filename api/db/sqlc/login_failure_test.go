@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func recordRandomLoginFailure(t *testing.T, testQueries *Queries, key string) LoginFailure {
+	failure, err := testQueries.RecordLoginFailure(context.Background(), RecordLoginFailureParams{
+		Scope:       "email",
+		Key:         key,
+		ResetBefore: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "email", failure.Scope)
+	require.Equal(t, key, failure.Key)
+	require.WithinDuration(t, time.Now(), failure.LastFailedAt, time.Second)
+
+	return failure
+}
+
+func TestRecordLoginFailure(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	key := util.RandomEmail()
+
+	failure1 := recordRandomLoginFailure(t, testQueries, key)
+	require.Equal(t, int32(1), failure1.FailureCount)
+	require.False(t, failure1.LockedUntil.Valid)
+
+	failure2 := recordRandomLoginFailure(t, testQueries, key)
+	require.Equal(t, int32(2), failure2.FailureCount)
+
+	failure3, err := testQueries.GetLoginFailure(context.Background(), GetLoginFailureParams{
+		Scope: "email",
+		Key:   key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, failure2.FailureCount, failure3.FailureCount)
+}
+
+func TestRecordLoginFailureResetsAfterWindow(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	key := util.RandomEmail()
+
+	recordRandomLoginFailure(t, testQueries, key)
+	recordRandomLoginFailure(t, testQueries, key)
+
+	// Every earlier failure is outside of the window
+	failure, err := testQueries.RecordLoginFailure(context.Background(), RecordLoginFailureParams{
+		Scope:       "email",
+		Key:         key,
+		ResetBefore: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), failure.FailureCount)
+}
+
+func TestRecordLoginFailureKeepsCountWhileLocked(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	key := util.RandomEmail()
+
+	recordRandomLoginFailure(t, testQueries, key)
+
+	_, err := testQueries.LockLogin(context.Background(), LockLoginParams{
+		Scope:       "email",
+		Key:         key,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	// The window is measured from the end of the lockout
+	failure, err := testQueries.RecordLoginFailure(context.Background(), RecordLoginFailureParams{
+		Scope:       "email",
+		Key:         key,
+		ResetBefore: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), failure.FailureCount)
+}
+
+func TestLockLoginTx(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	key := util.RandomEmail()
+
+	failure := recordRandomLoginFailure(t, store.Queries, key)
+
+	lockedUntil := time.Now().Add(time.Minute)
+
+	event, err := store.LockLoginTx(context.Background(), LockLoginTxParams{
+		Scope:        failure.Scope,
+		Key:          failure.Key,
+		FailureCount: failure.FailureCount,
+		LockedUntil:  lockedUntil,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, event.ID)
+	require.Equal(t, failure.Scope, event.Scope)
+	require.Equal(t, failure.Key, event.Key)
+	require.Equal(t, failure.FailureCount, event.FailureCount)
+	require.WithinDuration(t, lockedUntil, event.LockedUntil, time.Second)
+	require.NotZero(t, event.CreatedAt)
+
+	lockedFailure, err := store.GetLoginFailure(context.Background(), GetLoginFailureParams{
+		Scope: failure.Scope,
+		Key:   failure.Key,
+	})
+	require.NoError(t, err)
+	require.True(t, lockedFailure.LockedUntil.Valid)
+	require.WithinDuration(t, lockedUntil, lockedFailure.LockedUntil.Time, time.Second)
+
+	events, err := store.ListLoginLockoutEvents(context.Background(), ListLoginLockoutEventsParams{
+		Scope: failure.Scope,
+		Key:   failure.Key,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, event.ID, events[0].ID)
+}
+
+func TestDeleteLoginFailure(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	failure1 := recordRandomLoginFailure(t, testQueries, util.RandomEmail())
+
+	err := testQueries.DeleteLoginFailure(context.Background(), DeleteLoginFailureParams{
+		Scope: failure1.Scope,
+		Key:   failure1.Key,
+	})
+	require.NoError(t, err)
+
+	failure2, err := testQueries.GetLoginFailure(context.Background(), GetLoginFailureParams{
+		Scope: failure1.Scope,
+		Key:   failure1.Key,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, failure2)
+}
+
+func TestDeleteStaleLoginFailures(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	staleFailure := recordRandomLoginFailure(t, testQueries, util.RandomEmail())
+
+	lockedFailure := recordRandomLoginFailure(t, testQueries, util.RandomEmail())
+	_, err := testQueries.LockLogin(context.Background(), LockLoginParams{
+		Scope:       lockedFailure.Scope,
+		Key:         lockedFailure.Key,
+		LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.DeleteStaleLoginFailures(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	_, err = testQueries.GetLoginFailure(context.Background(), GetLoginFailureParams{
+		Scope: staleFailure.Scope,
+		Key:   staleFailure.Key,
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.GetLoginFailure(context.Background(), GetLoginFailureParams{
+		Scope: lockedFailure.Scope,
+		Key:   lockedFailure.Key,
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,89 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: login_lockout_event.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const createLoginLockoutEvent = `-- name: CreateLoginLockoutEvent :one
+INSERT INTO login_lockout_events (
+  scope,
+  key,
+  failure_count,
+  locked_until
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING id, scope, key, failure_count, locked_until, created_at
+`
+
+type CreateLoginLockoutEventParams struct {
+	Scope        string    `json:"scope"`
+	Key          string    `json:"key"`
+	FailureCount int32     `json:"failure_count"`
+	LockedUntil  time.Time `json:"locked_until"`
+}
+
+func (q *Queries) CreateLoginLockoutEvent(ctx context.Context, arg CreateLoginLockoutEventParams) (LoginLockoutEvent, error) {
+	row := q.db.QueryRowContext(ctx, createLoginLockoutEvent,
+		arg.Scope,
+		arg.Key,
+		arg.FailureCount,
+		arg.LockedUntil,
+	)
+	var i LoginLockoutEvent
+	err := row.Scan(
+		&i.ID,
+		&i.Scope,
+		&i.Key,
+		&i.FailureCount,
+		&i.LockedUntil,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listLoginLockoutEvents = `-- name: ListLoginLockoutEvents :many
+SELECT id, scope, key, failure_count, locked_until, created_at FROM login_lockout_events
+WHERE scope = $1 AND key = $2
+ORDER BY created_at DESC, id
+`
+
+type ListLoginLockoutEventsParams struct {
+	Scope string `json:"scope"`
+	Key   string `json:"key"`
+}
+
+func (q *Queries) ListLoginLockoutEvents(ctx context.Context, arg ListLoginLockoutEventsParams) ([]LoginLockoutEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listLoginLockoutEvents, arg.Scope, arg.Key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []LoginLockoutEvent{}
+	for rows.Next() {
+		var i LoginLockoutEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.Scope,
+			&i.Key,
+			&i.FailureCount,
+			&i.LockedUntil,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -6,24 +6,120 @@ package db
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type UserRole string
+
+const (
+	UserRoleCustomer UserRole = "customer"
+	UserRoleSeller   UserRole = "seller"
+	UserRoleAdmin    UserRole = "admin"
+)
+
+func (e *UserRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserRole(s)
+	case string:
+		*e = UserRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserRole: %T", src)
+	}
+	return nil
+}
+
+type NullUserRole struct {
+	UserRole UserRole `json:"user_role"`
+	Valid    bool     `json:"valid"` // Valid is true if UserRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullUserRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.UserRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.UserRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullUserRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.UserRole), nil
+}
+
+type ApiKey struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Name       string       `json:"name"`
+	KeyPrefix  string       `json:"key_prefix"`
+	KeyHash    []byte       `json:"key_hash"`
+	Scopes     []string     `json:"scopes"`
+	LastUsedAt sql.NullTime `json:"last_used_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
 type CartProduct struct {
 	UserID    uuid.UUID `json:"user_id"`
 	ProductID uuid.UUID `json:"product_id"`
 	Quantity  int32     `json:"quantity"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Category struct {
+	ID        uuid.UUID     `json:"id"`
+	Name      string        `json:"name"`
+	CreatedAt time.Time     `json:"created_at"`
+	ParentID  uuid.NullUUID `json:"parent_id"`
+}
+
+type LoginFailure struct {
+	Scope        string       `json:"scope"`
+	Key          string       `json:"key"`
+	FailureCount int32        `json:"failure_count"`
+	LastFailedAt time.Time    `json:"last_failed_at"`
+	LockedUntil  sql.NullTime `json:"locked_until"`
+}
+
+type LoginLockoutEvent struct {
+	ID           uuid.UUID `json:"id"`
+	Scope        string    `json:"scope"`
+	Key          string    `json:"key"`
+	FailureCount int32     `json:"failure_count"`
+	LockedUntil  time.Time `json:"locked_until"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Order struct {
 	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
+	UserID    uuid.UUID `json:"user_id"`
+	Subtotal  string    `json:"subtotal"`
+	Shipping  string    `json:"shipping"`
+	Tax       string    `json:"tax"`
+	Total     string    `json:"total"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type OrderItem struct {
+	OrderID     uuid.UUID      `json:"order_id"`
+	ProductID   uuid.UUID      `json:"product_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	Price       string         `json:"price"`
+	Quantity    int32          `json:"quantity"`
+	ImageUrl    sql.NullString `json:"image_url"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type Product struct {
 	ID            uuid.UUID      `json:"id"`
 	Name          string         `json:"name"`
@@ -34,16 +130,40 @@ type Product struct {
 	SellerID      uuid.UUID      `json:"seller_id"`
 	ImageUrl      sql.NullString `json:"image_url"`
 	CreatedAt     time.Time      `json:"created_at"`
+	DeletedAt     sql.NullTime   `json:"deleted_at"`
+	SearchVector  string         `json:"search_vector"`
+}
+
+type Review struct {
+	ID        uuid.UUID      `json:"id"`
+	ProductID uuid.UUID      `json:"product_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Rating    int32          `json:"rating"`
+	Title     string         `json:"title"`
+	Body      sql.NullString `json:"body"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type RotatedRefreshToken struct {
+	FamilyID         uuid.UUID `json:"family_id"`
+	ExpiredAt        time.Time `json:"expired_at"`
+	RotatedAt        time.Time `json:"rotated_at"`
+	RefreshTokenHash []byte    `json:"refresh_token_hash"`
 }
 
 type Session struct {
 	ID                    uuid.UUID `json:"id"`
 	UserID                uuid.UUID `json:"user_id"`
-	SessionToken          uuid.UUID `json:"session_token"`
 	SessionTokenExpiredAt time.Time `json:"session_token_expired_at"`
-	RefreshToken          uuid.UUID `json:"refresh_token"`
 	RefreshTokenExpiredAt time.Time `json:"refresh_token_expired_at"`
 	CreatedAt             time.Time `json:"created_at"`
+	UserAgent             string    `json:"user_agent"`
+	ClientIp              string    `json:"client_ip"`
+	LastUsedAt            time.Time `json:"last_used_at"`
+	FamilyID              uuid.UUID `json:"family_id"`
+	SessionTokenHash      []byte    `json:"session_token_hash"`
+	RefreshTokenHash      []byte    `json:"refresh_token_hash"`
 }
 
 type User struct {
@@ -53,4 +173,11 @@ type User struct {
 	HashedPassword    string    `json:"hashed_password"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              UserRole  `json:"role"`
+}
+
+type WishlistProduct struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+	CreatedAt time.Time `json:"created_at"`
 }
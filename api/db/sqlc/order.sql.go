@@ -0,0 +1,139 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: order.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const countOrdersByUser = `-- name: CountOrdersByUser :one
+SELECT count(*) FROM orders
+WHERE user_id = $1
+`
+
+func (q *Queries) CountOrdersByUser(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOrdersByUser, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createOrder = `-- name: CreateOrder :one
+INSERT INTO orders (
+  user_id,
+  subtotal,
+  shipping,
+  tax,
+  total
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING id, user_id, subtotal, shipping, tax, total, created_at
+`
+
+type CreateOrderParams struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Subtotal string    `json:"subtotal"`
+	Shipping string    `json:"shipping"`
+	Tax      string    `json:"tax"`
+	Total    string    `json:"total"`
+}
+
+func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
+	row := q.db.QueryRowContext(ctx, createOrder,
+		arg.UserID,
+		arg.Subtotal,
+		arg.Shipping,
+		arg.Tax,
+		arg.Total,
+	)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Subtotal,
+		&i.Shipping,
+		&i.Tax,
+		&i.Total,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getOrder = `-- name: GetOrder :one
+SELECT id, user_id, subtotal, shipping, tax, total, created_at FROM orders
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetOrder(ctx context.Context, id uuid.UUID) (Order, error) {
+	row := q.db.QueryRowContext(ctx, getOrder, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Subtotal,
+		&i.Shipping,
+		&i.Tax,
+		&i.Total,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listOrdersByUser = `-- name: ListOrdersByUser :many
+SELECT id, user_id, subtotal, shipping, tax, total, created_at FROM orders
+WHERE user_id = $3
+ORDER BY created_at DESC
+LIMIT $1
+OFFSET $2
+`
+
+type ListOrdersByUserParams struct {
+	Limit  int32     `json:"limit"`
+	Offset int32     `json:"offset"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) ListOrdersByUser(ctx context.Context, arg ListOrdersByUserParams) ([]Order, error) {
+	rows, err := q.db.QueryContext(ctx, listOrdersByUser, arg.Limit, arg.Offset, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Order{}
+	for rows.Next() {
+		var i Order
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Subtotal,
+			&i.Shipping,
+			&i.Tax,
+			&i.Total,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const truncateOrdersTable = `-- name: TruncateOrdersTable :exec
+TRUNCATE TABLE orders CASCADE
+`
+
+func (q *Queries) TruncateOrdersTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, truncateOrdersTable)
+	return err
+}
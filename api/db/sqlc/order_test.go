@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomOrder(t *testing.T, testQueries *Queries, user User) Order {
+	arg := CreateOrderParams{
+		UserID:   user.ID,
+		Subtotal: "100",
+		Shipping: "5",
+		Tax:      "10",
+		Total:    "115",
+	}
+
+	order, err := testQueries.CreateOrder(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, order)
+
+	require.Equal(t, arg.UserID, order.UserID)
+	require.Equal(t, arg.Subtotal, order.Subtotal)
+	require.Equal(t, arg.Shipping, order.Shipping)
+	require.Equal(t, arg.Tax, order.Tax)
+	require.Equal(t, arg.Total, order.Total)
+
+	require.NotEmpty(t, order.ID)
+	require.NotZero(t, order.CreatedAt)
+
+	return order
+}
+
+func createRandomOrderItem(t *testing.T, testQueries *Queries, order Order) OrderItem {
+	product := createRandomProduct(t, testQueries)
+
+	arg := CreateOrderItemParams{
+		OrderID:     order.ID,
+		ProductID:   product.ID,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Quantity:    util.RandomInt32(10) + 1,
+		ImageUrl:    product.ImageUrl,
+	}
+
+	item, err := testQueries.CreateOrderItem(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, item)
+
+	require.Equal(t, arg.OrderID, item.OrderID)
+	require.Equal(t, arg.ProductID, item.ProductID)
+	require.Equal(t, arg.Name, item.Name)
+	require.Equal(t, arg.Description, item.Description)
+	require.Equal(t, arg.Price, item.Price)
+	require.Equal(t, arg.Quantity, item.Quantity)
+	require.Equal(t, arg.ImageUrl, item.ImageUrl)
+
+	require.NotZero(t, item.CreatedAt)
+
+	return item
+}
+
+func TestCreateOrder(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	createRandomOrder(t, testQueries, user)
+}
+
+func TestGetOrder(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	order1 := createRandomOrder(t, testQueries, user)
+	order2, err := testQueries.GetOrder(context.Background(), order1.ID)
+	require.NoError(t, err)
+	require.NotEmpty(t, order2)
+
+	require.Equal(t, order1.ID, order2.ID)
+	require.Equal(t, order1.UserID, order2.UserID)
+	require.Equal(t, order1.Subtotal, order2.Subtotal)
+	require.Equal(t, order1.Shipping, order2.Shipping)
+	require.Equal(t, order1.Tax, order2.Tax)
+	require.Equal(t, order1.Total, order2.Total)
+	require.WithinDuration(t, order1.CreatedAt, order2.CreatedAt, time.Second)
+}
+
+func TestGetOrderNotFound(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	order, err := testQueries.GetOrder(context.Background(), util.RandomUUID())
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, order)
+}
+
+func TestListOrdersByUser(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	otherUser := createRandomUser(t, testQueries)
+
+	for i := 0; i < 10; i++ {
+		createRandomOrder(t, testQueries, user)
+		createRandomOrder(t, testQueries, otherUser)
+	}
+
+	arg := ListOrdersByUserParams{UserID: user.ID, Limit: 5, Offset: 5}
+
+	orders, err := testQueries.ListOrdersByUser(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, orders, 5)
+
+	for _, order := range orders {
+		require.Equal(t, user.ID, order.UserID)
+	}
+
+	count, err := testQueries.CountOrdersByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), count)
+}
+
+func TestGetOrderItemsByOrderIDs(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user := createRandomUser(t, testQueries)
+	orders := []Order{
+		createRandomOrder(t, testQueries, user),
+		createRandomOrder(t, testQueries, user),
+	}
+
+	for _, order := range orders {
+		for i := 0; i < 3; i++ {
+			createRandomOrderItem(t, testQueries, order)
+		}
+	}
+
+	items, err := testQueries.GetOrderItemsByOrderID(context.Background(), orders[0].ID)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+
+	for _, item := range items {
+		require.Equal(t, orders[0].ID, item.OrderID)
+	}
+
+	items, err = testQueries.GetOrderItemsByOrderIDs(context.Background(), []uuid.UUID{orders[0].ID, orders[1].ID})
+	require.NoError(t, err)
+	require.Len(t, items, 6)
+}
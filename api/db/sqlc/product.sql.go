@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const addProduct = `-- name: AddProduct :one
@@ -29,7 +30,7 @@ INSERT INTO products (
   $5,
   $6,
   $7
-) RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at
+) RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector
 `
 
 type AddProductParams struct {
@@ -63,24 +64,173 @@ func (q *Queries) AddProduct(ctx context.Context, arg AddProductParams) (Product
 		&i.SellerID,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const countProducts = `-- name: CountProducts :one
 SELECT count(*) FROM products
+WHERE (category_id = ANY($1::uuid[]) OR $1::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', $2) OR $2 IS NULL)
+  AND (price >= $3 OR $3 IS NULL)
+  AND (price <= $4 OR $4 IS NULL)
+  AND (stock_quantity > 0 OR NOT $5::boolean)
+  AND (seller_id = $6 OR $6 IS NULL)
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countProducts)
+type CountProductsParams struct {
+	CategoryIds []uuid.UUID    `json:"category_ids"`
+	Query       sql.NullString `json:"query"`
+	MinPrice    sql.NullString `json:"min_price"`
+	MaxPrice    sql.NullString `json:"max_price"`
+	InStock     bool           `json:"in_stock"`
+	SellerID    uuid.NullUUID  `json:"seller_id"`
+}
+
+func (q *Queries) CountProducts(ctx context.Context, arg CountProductsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProducts,
+		pq.Array(arg.CategoryIds),
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.InStock,
+		arg.SellerID,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const countProductsByCategory = `-- name: CountProductsByCategory :many
+WITH RECURSIVE category_tree(ancestor_id, id) AS (
+  SELECT categories.id, categories.id FROM categories
+  UNION
+  SELECT category_tree.ancestor_id, categories.id FROM categories JOIN category_tree ON categories.parent_id = category_tree.id
+)
+SELECT
+  categories.id AS category_id,
+  categories.name AS category,
+  count(*) AS count
+FROM products
+JOIN category_tree ON category_tree.id = products.category_id
+JOIN categories ON categories.id = category_tree.ancestor_id
+WHERE (products.search_vector @@ websearch_to_tsquery('english', $1) OR $1 IS NULL)
+  AND (products.price >= $2 OR $2 IS NULL)
+  AND (products.price <= $3 OR $3 IS NULL)
+  AND (products.stock_quantity > 0 OR NOT $4::boolean)
+  AND (products.seller_id = $5 OR $5 IS NULL)
+  AND products.deleted_at IS NULL
+GROUP BY categories.id, categories.name
+ORDER BY categories.name
+`
+
+type CountProductsByCategoryParams struct {
+	Query    sql.NullString `json:"query"`
+	MinPrice sql.NullString `json:"min_price"`
+	MaxPrice sql.NullString `json:"max_price"`
+	InStock  bool           `json:"in_stock"`
+	SellerID uuid.NullUUID  `json:"seller_id"`
+}
+
+type CountProductsByCategoryRow struct {
+	CategoryID uuid.UUID `json:"category_id"`
+	Category   string    `json:"category"`
+	Count      int64     `json:"count"`
+}
+
+// Products are counted for their category and every ancestor of it, since filtering by a category
+// includes its descendants.
+func (q *Queries) CountProductsByCategory(ctx context.Context, arg CountProductsByCategoryParams) ([]CountProductsByCategoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, countProductsByCategory,
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.InStock,
+		arg.SellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountProductsByCategoryRow{}
+	for rows.Next() {
+		var i CountProductsByCategoryRow
+		if err := rows.Scan(&i.CategoryID, &i.Category, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countProductsByPriceBucket = `-- name: CountProductsByPriceBucket :many
+SELECT
+  width_bucket(price, $1::decimal[])::int AS bucket,
+  count(*) AS count
+FROM products
+WHERE (category_id = ANY($2::uuid[]) OR $2::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', $3) OR $3 IS NULL)
+  AND (stock_quantity > 0 OR NOT $4::boolean)
+  AND (seller_id = $5 OR $5 IS NULL)
+  AND deleted_at IS NULL
+GROUP BY bucket
+ORDER BY bucket
+`
+
+type CountProductsByPriceBucketParams struct {
+	Bounds      []string       `json:"bounds"`
+	CategoryIds []uuid.UUID    `json:"category_ids"`
+	Query       sql.NullString `json:"query"`
+	InStock     bool           `json:"in_stock"`
+	SellerID    uuid.NullUUID  `json:"seller_id"`
+}
+
+type CountProductsByPriceBucketRow struct {
+	Bucket int32 `json:"bucket"`
+	Count  int64 `json:"count"`
+}
+
+func (q *Queries) CountProductsByPriceBucket(ctx context.Context, arg CountProductsByPriceBucketParams) ([]CountProductsByPriceBucketRow, error) {
+	rows, err := q.db.QueryContext(ctx, countProductsByPriceBucket,
+		pq.Array(arg.Bounds),
+		pq.Array(arg.CategoryIds),
+		arg.Query,
+		arg.InStock,
+		arg.SellerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountProductsByPriceBucketRow{}
+	for rows.Next() {
+		var i CountProductsByPriceBucketRow
+		if err := rows.Scan(&i.Bucket, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countProductsBySeller = `-- name: CountProductsBySeller :one
 SELECT count(*) FROM products
-WHERE seller_id = $1
+WHERE seller_id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) CountProductsBySeller(ctx context.Context, sellerID uuid.UUID) (int64, error) {
@@ -107,7 +257,7 @@ INSERT INTO products (
   $5,
   $6,
   $7
-) RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at
+) RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector
 `
 
 type CreateProductParams struct {
@@ -141,13 +291,47 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.SellerID,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
+	)
+	return i, err
+}
+
+const decrementProductStock = `-- name: DecrementProductStock :one
+UPDATE products
+SET
+  stock_quantity = stock_quantity - $1
+WHERE id = $2 AND stock_quantity >= $1 AND deleted_at IS NULL
+RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector
+`
+
+type DecrementProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) DecrementProductStock(ctx context.Context, arg DecrementProductStockParams) (Product, error) {
+	row := q.db.QueryRowContext(ctx, decrementProductStock, arg.Quantity, arg.ID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.StockQuantity,
+		&i.CategoryID,
+		&i.SellerID,
+		&i.ImageUrl,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const getProduct = `-- name: GetProduct :one
-SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at FROM products
-WHERE id = $1 LIMIT 1
+SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector FROM products
+WHERE id = $1 AND deleted_at IS NULL LIMIT 1
 `
 
 func (q *Queries) GetProduct(ctx context.Context, id uuid.UUID) (Product, error) {
@@ -163,26 +347,57 @@ func (q *Queries) GetProduct(ctx context.Context, id uuid.UUID) (Product, error)
 		&i.SellerID,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
 
 const listProducts = `-- name: ListProducts :many
-SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at FROM products
-WHERE category_id = $3 OR $3 IS NULL
-ORDER BY created_at
+SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector FROM products
+WHERE (category_id = ANY($3::uuid[]) OR $3::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', $4) OR $4 IS NULL)
+  AND (price >= $5 OR $5 IS NULL)
+  AND (price <= $6 OR $6 IS NULL)
+  AND (stock_quantity > 0 OR NOT $7::boolean)
+  AND (seller_id = $8 OR $8 IS NULL)
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN $9::text = 'price_asc' THEN price END ASC,
+  CASE WHEN $9::text = 'price_desc' THEN price END DESC,
+  CASE WHEN $9::text = 'newest' THEN created_at END DESC,
+  CASE WHEN $9::text = 'name' THEN name END ASC,
+  ts_rank(search_vector, websearch_to_tsquery('english', $4)) DESC,
+  created_at,
+  id
 LIMIT $1
 OFFSET $2
 `
 
 type ListProductsParams struct {
-	Limit      int32         `json:"limit"`
-	Offset     int32         `json:"offset"`
-	CategoryID uuid.NullUUID `json:"category_id"`
+	Limit       int32          `json:"limit"`
+	Offset      int32          `json:"offset"`
+	CategoryIds []uuid.UUID    `json:"category_ids"`
+	Query       sql.NullString `json:"query"`
+	MinPrice    sql.NullString `json:"min_price"`
+	MaxPrice    sql.NullString `json:"max_price"`
+	InStock     bool           `json:"in_stock"`
+	SellerID    uuid.NullUUID  `json:"seller_id"`
+	Sort        string         `json:"sort"`
 }
 
 func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, listProducts, arg.Limit, arg.Offset, arg.CategoryID)
+	rows, err := q.db.QueryContext(ctx, listProducts,
+		arg.Limit,
+		arg.Offset,
+		pq.Array(arg.CategoryIds),
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.InStock,
+		arg.SellerID,
+		arg.Sort,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +415,89 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.SellerID,
 			&i.ImageUrl,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsByCursor = `-- name: ListProductsByCursor :many
+SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector FROM products
+WHERE (category_id = ANY($2::uuid[]) OR $2::uuid[] IS NULL)
+  AND (search_vector @@ websearch_to_tsquery('english', $3) OR $3 IS NULL)
+  AND (price >= $4 OR $4 IS NULL)
+  AND (price <= $5 OR $5 IS NULL)
+  AND (stock_quantity > 0 OR NOT $6::boolean)
+  AND (seller_id = $7 OR $7 IS NULL)
+  AND (
+    $8::timestamptz IS NULL
+    OR (NOT $9::boolean AND (created_at, id) > ($8::timestamptz, $10::uuid))
+    OR ($9::boolean AND (created_at, id) < ($8::timestamptz, $10::uuid))
+  )
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN $9::boolean THEN created_at END DESC,
+  CASE WHEN $9::boolean THEN id END DESC,
+  created_at,
+  id
+LIMIT $1
+`
+
+type ListProductsByCursorParams struct {
+	Limit           int32          `json:"limit"`
+	CategoryIds     []uuid.UUID    `json:"category_ids"`
+	Query           sql.NullString `json:"query"`
+	MinPrice        sql.NullString `json:"min_price"`
+	MaxPrice        sql.NullString `json:"max_price"`
+	InStock         bool           `json:"in_stock"`
+	SellerID        uuid.NullUUID  `json:"seller_id"`
+	CursorCreatedAt sql.NullTime   `json:"cursor_created_at"`
+	Newest          bool           `json:"newest"`
+	CursorID        uuid.NullUUID  `json:"cursor_id"`
+}
+
+func (q *Queries) ListProductsByCursor(ctx context.Context, arg ListProductsByCursorParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsByCursor,
+		arg.Limit,
+		pq.Array(arg.CategoryIds),
+		arg.Query,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.InStock,
+		arg.SellerID,
+		arg.CursorCreatedAt,
+		arg.Newest,
+		arg.CursorID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.CategoryID,
+			&i.SellerID,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -215,9 +513,15 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 }
 
 const listProductsBySeller = `-- name: ListProductsBySeller :many
-SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at FROM products
-WHERE seller_id = $3
-ORDER BY created_at
+SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector FROM products
+WHERE seller_id = $3 AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN $4::text = 'price_asc' THEN price END ASC,
+  CASE WHEN $4::text = 'price_desc' THEN price END DESC,
+  CASE WHEN $4::text = 'newest' THEN created_at END DESC,
+  CASE WHEN $4::text = 'name' THEN name END ASC,
+  created_at,
+  id
 LIMIT $1
 OFFSET $2
 `
@@ -226,10 +530,82 @@ type ListProductsBySellerParams struct {
 	Limit    int32     `json:"limit"`
 	Offset   int32     `json:"offset"`
 	SellerID uuid.UUID `json:"seller_id"`
+	Sort     string    `json:"sort"`
 }
 
 func (q *Queries) ListProductsBySeller(ctx context.Context, arg ListProductsBySellerParams) ([]Product, error) {
-	rows, err := q.db.QueryContext(ctx, listProductsBySeller, arg.Limit, arg.Offset, arg.SellerID)
+	rows, err := q.db.QueryContext(ctx, listProductsBySeller,
+		arg.Limit,
+		arg.Offset,
+		arg.SellerID,
+		arg.Sort,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.CategoryID,
+			&i.SellerID,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductsBySellerByCursor = `-- name: ListProductsBySellerByCursor :many
+SELECT id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector FROM products
+WHERE seller_id = $2
+  AND (
+    $3::timestamptz IS NULL
+    OR (NOT $4::boolean AND (created_at, id) > ($3::timestamptz, $5::uuid))
+    OR ($4::boolean AND (created_at, id) < ($3::timestamptz, $5::uuid))
+  )
+  AND deleted_at IS NULL
+ORDER BY
+  CASE WHEN $4::boolean THEN created_at END DESC,
+  CASE WHEN $4::boolean THEN id END DESC,
+  created_at,
+  id
+LIMIT $1
+`
+
+type ListProductsBySellerByCursorParams struct {
+	Limit           int32         `json:"limit"`
+	SellerID        uuid.UUID     `json:"seller_id"`
+	CursorCreatedAt sql.NullTime  `json:"cursor_created_at"`
+	Newest          bool          `json:"newest"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+}
+
+func (q *Queries) ListProductsBySellerByCursor(ctx context.Context, arg ListProductsBySellerByCursorParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsBySellerByCursor,
+		arg.Limit,
+		arg.SellerID,
+		arg.CursorCreatedAt,
+		arg.Newest,
+		arg.CursorID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +623,8 @@ func (q *Queries) ListProductsBySeller(ctx context.Context, arg ListProductsBySe
 			&i.SellerID,
 			&i.ImageUrl,
 			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.SearchVector,
 		); err != nil {
 			return nil, err
 		}
@@ -261,6 +639,18 @@ func (q *Queries) ListProductsBySeller(ctx context.Context, arg ListProductsBySe
 	return items, nil
 }
 
+const softDeleteProduct = `-- name: SoftDeleteProduct :exec
+UPDATE products
+SET
+  deleted_at = now()
+WHERE id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) SoftDeleteProduct(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, softDeleteProduct, id)
+	return err
+}
+
 const truncateProductsTable = `-- name: TruncateProductsTable :exec
 TRUNCATE TABLE products CASCADE
 `
@@ -280,8 +670,8 @@ SET
   category_id = $6,
   seller_id = $7,
   image_url = $8
-WHERE id = $1
-RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, description, price, stock_quantity, category_id, seller_id, image_url, created_at, deleted_at, search_vector
 `
 
 type UpdateProductParams struct {
@@ -317,6 +707,8 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		&i.SellerID,
 		&i.ImageUrl,
 		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.SearchVector,
 	)
 	return i, err
 }
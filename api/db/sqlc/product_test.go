@@ -7,12 +7,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ot07/next-bazaar/test_util"
 	"github.com/ot07/next-bazaar/util"
 	"github.com/stretchr/testify/require"
 )
 
 func createRandomProduct(t *testing.T, testQueries *Queries) Product {
+	return createRandomProductWithStock(t, testQueries, rand.Int31n(100))
+}
+
+func createRandomProductWithStock(t *testing.T, testQueries *Queries, stockQuantity int32) Product {
 	price := util.RandomPrice()
 
 	category := createRandomCategory(t, testQueries)
@@ -22,7 +27,7 @@ func createRandomProduct(t *testing.T, testQueries *Queries) Product {
 		Name:          util.RandomName(),
 		Description:   sql.NullString{String: util.RandomName(), Valid: true},
 		Price:         price.String(),
-		StockQuantity: rand.Int31n(100),
+		StockQuantity: stockQuantity,
 		CategoryID:    category.ID,
 		SellerID:      user.ID,
 		ImageUrl:      sql.NullString{String: "test-image-url", Valid: true},
@@ -80,3 +85,125 @@ func TestGetProduct(t *testing.T) {
 	require.Equal(t, product1.ImageUrl, product2.ImageUrl)
 	require.WithinDuration(t, product1.CreatedAt, product2.CreatedAt, time.Second)
 }
+
+func TestSoftDeleteProduct(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+
+	err := testQueries.SoftDeleteProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetProduct(context.Background(), product.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	count, err := testQueries.CountProductsBySeller(context.Background(), product.SellerID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestListProductsBySellerTies(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	seller := createRandomUser(t, testQueries)
+	category := createRandomCategory(t, testQueries)
+
+	n := 6
+	for i := 0; i < n; i++ {
+		_, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+			Name:          util.RandomName(),
+			Price:         "10.00",
+			StockQuantity: 1,
+			CategoryID:    category.ID,
+			SellerID:      seller.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	// Products inserted in bulk tie under every sort key but the id
+	_, err := db.Exec("UPDATE products SET created_at = $1 WHERE seller_id = $2", time.Now(), seller.ID)
+	require.NoError(t, err)
+
+	for _, sort := range []string{"price_asc", "newest"} {
+		seen := make(map[string]bool)
+
+		pageSize := int32(2)
+		for offset := int32(0); offset < int32(n); offset += pageSize {
+			products, err := testQueries.ListProductsBySeller(context.Background(), ListProductsBySellerParams{
+				Limit:    pageSize,
+				Offset:   offset,
+				SellerID: seller.ID,
+				Sort:     sort,
+			})
+			require.NoError(t, err)
+
+			for _, product := range products {
+				require.False(t, seen[product.ID.String()], "product %s repeated with sort %s", product.ID, sort)
+				seen[product.ID.String()] = true
+			}
+		}
+
+		require.Len(t, seen, n)
+	}
+}
+
+func TestCountProductsByCategoryIncludesDescendants(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	seller := createRandomUser(t, testQueries)
+
+	parent := createRandomCategory(t, testQueries)
+	child, err := testQueries.CreateCategoryWithParent(context.Background(), CreateCategoryWithParentParams{
+		Name:     util.RandomName(),
+		ParentID: uuid.NullUUID{UUID: parent.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	grandchild, err := testQueries.CreateCategoryWithParent(context.Background(), CreateCategoryWithParentParams{
+		Name:     util.RandomName(),
+		ParentID: uuid.NullUUID{UUID: child.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	for _, category := range []Category{parent, child, child, grandchild} {
+		_, err := testQueries.CreateProduct(context.Background(), CreateProductParams{
+			Name:          util.RandomName(),
+			Price:         "10.00",
+			StockQuantity: 1,
+			CategoryID:    category.ID,
+			SellerID:      seller.ID,
+		})
+		require.NoError(t, err)
+	}
+
+	rows, err := testQueries.CountProductsByCategory(context.Background(), CountProductsByCategoryParams{
+		SellerID: uuid.NullUUID{UUID: seller.ID, Valid: true},
+	})
+	require.NoError(t, err)
+
+	counts := make(map[uuid.UUID]int64)
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+
+	// The counts match the number of products found by filtering by the category
+	require.Equal(t, map[uuid.UUID]int64{
+		parent.ID:     4,
+		child.ID:      3,
+		grandchild.ID: 1,
+	}, counts)
+}
@@ -6,42 +6,110 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddProduct(ctx context.Context, arg AddProductParams) (Product, error)
-	CountProducts(ctx context.Context) (int64, error)
+	CountOrdersByUser(ctx context.Context, userID uuid.UUID) (int64, error)
+	CountProducts(ctx context.Context, arg CountProductsParams) (int64, error)
+	// Products are counted for their category and every ancestor of it, since filtering by a category
+	// includes its descendants.
+	CountProductsByCategory(ctx context.Context, arg CountProductsByCategoryParams) ([]CountProductsByCategoryRow, error)
+	CountProductsByPriceBucket(ctx context.Context, arg CountProductsByPriceBucketParams) ([]CountProductsByPriceBucketRow, error)
 	CountProductsBySeller(ctx context.Context, sellerID uuid.UUID) (int64, error)
+	CountReviewsByProductID(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountUsers(ctx context.Context) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
 	CreateCartProduct(ctx context.Context, arg CreateCartProductParams) (CartProduct, error)
 	CreateCategory(ctx context.Context, name string) (Category, error)
+	CreateCategoryWithParent(ctx context.Context, arg CreateCategoryWithParentParams) (Category, error)
+	CreateLoginLockoutEvent(ctx context.Context, arg CreateLoginLockoutEventParams) (LoginLockoutEvent, error)
+	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error)
+	CreateRotatedRefreshToken(ctx context.Context, arg CreateRotatedRefreshTokenParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWishlistProduct(ctx context.Context, arg CreateWishlistProductParams) (WishlistProduct, error)
+	DecrementProductStock(ctx context.Context, arg DecrementProductStockParams) (Product, error)
+	DeleteApiKey(ctx context.Context, id uuid.UUID) error
 	DeleteCartProduct(ctx context.Context, arg DeleteCartProductParams) error
+	DeleteCartProductsByUserID(ctx context.Context, userID uuid.UUID) error
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
-	DeleteSession(ctx context.Context, sessionToken uuid.UUID) error
+	DeleteExpiredRotatedRefreshTokens(ctx context.Context, expiredBefore time.Time) (int64, error)
+	DeleteExpiredSessions(ctx context.Context, expiredBefore time.Time) (int64, error)
+	DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error
+	DeleteOtherSessionsByUserID(ctx context.Context, arg DeleteOtherSessionsByUserIDParams) error
+	DeleteReview(ctx context.Context, arg DeleteReviewParams) error
+	DeleteSession(ctx context.Context, sessionTokenHash []byte) error
+	DeleteSessionByID(ctx context.Context, id uuid.UUID) error
+	DeleteSessionsByFamilyID(ctx context.Context, familyID uuid.UUID) error
+	DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error
+	DeleteStaleCartProducts(ctx context.Context, inactiveSince time.Time) (int64, error)
+	DeleteStaleLoginFailures(ctx context.Context, staleBefore time.Time) (int64, error)
+	DeleteWishlistProduct(ctx context.Context, arg DeleteWishlistProductParams) error
+	GetApiKey(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	GetApiKeyByHash(ctx context.Context, keyHash []byte) (ApiKey, error)
 	GetCartProductByUserIDAndProductID(ctx context.Context, arg GetCartProductByUserIDAndProductIDParams) (CartProduct, error)
 	GetCartProductsByUserID(ctx context.Context, userID uuid.UUID) ([]CartProduct, error)
 	GetCategoriesByIDs(ctx context.Context, ids []uuid.UUID) ([]Category, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategoryDescendantIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (LoginFailure, error)
+	GetOrder(ctx context.Context, id uuid.UUID) (Order, error)
+	GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error)
+	GetOrderItemsByOrderIDs(ctx context.Context, orderIds []uuid.UUID) ([]OrderItem, error)
 	GetProduct(ctx context.Context, id uuid.UUID) (Product, error)
-	GetSession(ctx context.Context, sessionToken uuid.UUID) (Session, error)
+	GetProductRatingsByProductIDs(ctx context.Context, productIds []uuid.UUID) ([]GetProductRatingsByProductIDsRow, error)
+	GetReviewByProductIDAndUserID(ctx context.Context, arg GetReviewByProductIDAndUserIDParams) (Review, error)
+	GetRotatedRefreshToken(ctx context.Context, refreshTokenHash []byte) (RotatedRefreshToken, error)
+	GetSession(ctx context.Context, sessionTokenHash []byte) (Session, error)
+	GetSessionByFamilyID(ctx context.Context, familyID uuid.UUID) (Session, error)
+	GetSessionByID(ctx context.Context, id uuid.UUID) (Session, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error)
+	GetWishlistProductByUserIDAndProductID(ctx context.Context, arg GetWishlistProductByUserIDAndProductIDParams) (WishlistProduct, error)
+	GetWishlistProductsByUserID(ctx context.Context, userID uuid.UUID) ([]WishlistProduct, error)
+	ListAllCategories(ctx context.Context) ([]Category, error)
+	ListApiKeysByUserID(ctx context.Context, userID uuid.UUID) ([]ApiKey, error)
 	ListCategories(ctx context.Context, arg ListCategoriesParams) ([]Category, error)
+	ListLoginLockoutEvents(ctx context.Context, arg ListLoginLockoutEventsParams) ([]LoginLockoutEvent, error)
+	ListOrdersByUser(ctx context.Context, arg ListOrdersByUserParams) ([]Order, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
+	ListProductsByCursor(ctx context.Context, arg ListProductsByCursorParams) ([]Product, error)
 	ListProductsBySeller(ctx context.Context, arg ListProductsBySellerParams) ([]Product, error)
+	ListProductsBySellerByCursor(ctx context.Context, arg ListProductsBySellerByCursorParams) ([]Product, error)
+	ListReviewsByProductID(ctx context.Context, arg ListReviewsByProductIDParams) ([]Review, error)
+	ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	LockLogin(ctx context.Context, arg LockLoginParams) (LoginFailure, error)
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error)
+	RotateSessionTokens(ctx context.Context, arg RotateSessionTokensParams) (Session, error)
+	SoftDeleteProduct(ctx context.Context, id uuid.UUID) error
+	SubtractCartProductQuantity(ctx context.Context, arg SubtractCartProductQuantityParams) (CartProduct, error)
+	TouchApiKey(ctx context.Context, id uuid.UUID) error
+	TouchSession(ctx context.Context, id uuid.UUID) error
+	TruncateApiKeysTable(ctx context.Context) error
 	TruncateCartProductsTable(ctx context.Context) error
 	TruncateCategoriesTable(ctx context.Context) error
+	TruncateOrdersTable(ctx context.Context) error
 	TruncateProductsTable(ctx context.Context) error
+	TruncateReviewsTable(ctx context.Context) error
+	TruncateRotatedRefreshTokensTable(ctx context.Context) error
 	TruncateSessionsTable(ctx context.Context) error
 	TruncateUsersTable(ctx context.Context) error
+	TruncateWishlistProductsTable(ctx context.Context) error
 	UpdateCartProduct(ctx context.Context, arg UpdateCartProductParams) (CartProduct, error)
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpdateReview(ctx context.Context, arg UpdateReviewParams) (Review, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,248 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: review.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const countReviewsByProductID = `-- name: CountReviewsByProductID :one
+SELECT count(*) FROM reviews
+WHERE product_id = $1
+`
+
+func (q *Queries) CountReviewsByProductID(ctx context.Context, productID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countReviewsByProductID, productID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createReview = `-- name: CreateReview :one
+INSERT INTO reviews (
+  product_id,
+  user_id,
+  rating,
+  title,
+  body
+) VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5
+) RETURNING id, product_id, user_id, rating, title, body, created_at, updated_at
+`
+
+type CreateReviewParams struct {
+	ProductID uuid.UUID      `json:"product_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+	Rating    int32          `json:"rating"`
+	Title     string         `json:"title"`
+	Body      sql.NullString `json:"body"`
+}
+
+func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (Review, error) {
+	row := q.db.QueryRowContext(ctx, createReview,
+		arg.ProductID,
+		arg.UserID,
+		arg.Rating,
+		arg.Title,
+		arg.Body,
+	)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deleteReview = `-- name: DeleteReview :exec
+DELETE FROM reviews
+WHERE product_id = $1 AND user_id = $2
+`
+
+type DeleteReviewParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteReview(ctx context.Context, arg DeleteReviewParams) error {
+	_, err := q.db.ExecContext(ctx, deleteReview, arg.ProductID, arg.UserID)
+	return err
+}
+
+const getProductRatingsByProductIDs = `-- name: GetProductRatingsByProductIDs :many
+SELECT
+  product_id,
+  round(avg(rating), 2)::float8 AS average_rating,
+  count(*) AS review_count
+FROM reviews
+WHERE product_id = ANY($1::uuid[])
+GROUP BY product_id
+`
+
+type GetProductRatingsByProductIDsRow struct {
+	ProductID     uuid.UUID `json:"product_id"`
+	AverageRating float64   `json:"average_rating"`
+	ReviewCount   int64     `json:"review_count"`
+}
+
+func (q *Queries) GetProductRatingsByProductIDs(ctx context.Context, productIds []uuid.UUID) ([]GetProductRatingsByProductIDsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getProductRatingsByProductIDs, pq.Array(productIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetProductRatingsByProductIDsRow{}
+	for rows.Next() {
+		var i GetProductRatingsByProductIDsRow
+		if err := rows.Scan(&i.ProductID, &i.AverageRating, &i.ReviewCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewByProductIDAndUserID = `-- name: GetReviewByProductIDAndUserID :one
+SELECT id, product_id, user_id, rating, title, body, created_at, updated_at FROM reviews
+WHERE product_id = $1 AND user_id = $2 LIMIT 1
+`
+
+type GetReviewByProductIDAndUserIDParams struct {
+	ProductID uuid.UUID `json:"product_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetReviewByProductIDAndUserID(ctx context.Context, arg GetReviewByProductIDAndUserIDParams) (Review, error) {
+	row := q.db.QueryRowContext(ctx, getReviewByProductIDAndUserID, arg.ProductID, arg.UserID)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listReviewsByProductID = `-- name: ListReviewsByProductID :many
+SELECT id, product_id, user_id, rating, title, body, created_at, updated_at FROM reviews
+WHERE product_id = $3
+ORDER BY created_at DESC, id
+LIMIT $1
+OFFSET $2
+`
+
+type ListReviewsByProductIDParams struct {
+	Limit     int32     `json:"limit"`
+	Offset    int32     `json:"offset"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) ListReviewsByProductID(ctx context.Context, arg ListReviewsByProductIDParams) ([]Review, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewsByProductID, arg.Limit, arg.Offset, arg.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Review{}
+	for rows.Next() {
+		var i Review
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.UserID,
+			&i.Rating,
+			&i.Title,
+			&i.Body,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const truncateReviewsTable = `-- name: TruncateReviewsTable :exec
+TRUNCATE TABLE reviews CASCADE
+`
+
+func (q *Queries) TruncateReviewsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, truncateReviewsTable)
+	return err
+}
+
+const updateReview = `-- name: UpdateReview :one
+UPDATE reviews
+SET
+  rating = $1,
+  title = $2,
+  body = $3,
+  updated_at = now()
+WHERE product_id = $4 AND user_id = $5
+RETURNING id, product_id, user_id, rating, title, body, created_at, updated_at
+`
+
+type UpdateReviewParams struct {
+	Rating    int32          `json:"rating"`
+	Title     string         `json:"title"`
+	Body      sql.NullString `json:"body"`
+	ProductID uuid.UUID      `json:"product_id"`
+	UserID    uuid.UUID      `json:"user_id"`
+}
+
+func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (Review, error) {
+	row := q.db.QueryRowContext(ctx, updateReview,
+		arg.Rating,
+		arg.Title,
+		arg.Body,
+		arg.ProductID,
+		arg.UserID,
+	)
+	var i Review
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.UserID,
+		&i.Rating,
+		&i.Title,
+		&i.Body,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
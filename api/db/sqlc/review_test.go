@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomReview(t *testing.T, testQueries *Queries, product Product, rating int32) Review {
+	user := createRandomUser(t, testQueries)
+
+	arg := CreateReviewParams{
+		ProductID: product.ID,
+		UserID:    user.ID,
+		Rating:    rating,
+		Title:     util.RandomName(),
+		Body:      sql.NullString{String: util.RandomName(), Valid: true},
+	}
+
+	review, err := testQueries.CreateReview(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, review)
+
+	require.Equal(t, arg.ProductID, review.ProductID)
+	require.Equal(t, arg.UserID, review.UserID)
+	require.Equal(t, arg.Rating, review.Rating)
+	require.Equal(t, arg.Title, review.Title)
+	require.Equal(t, arg.Body, review.Body)
+
+	require.NotEmpty(t, review.ID)
+	require.NotZero(t, review.CreatedAt)
+
+	return review
+}
+
+func TestCreateReview(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+	createRandomReview(t, testQueries, product, 4)
+}
+
+func TestCreateReviewDuplicate(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+	review := createRandomReview(t, testQueries, product, 4)
+
+	_, err := testQueries.CreateReview(context.Background(), CreateReviewParams{
+		ProductID: review.ProductID,
+		UserID:    review.UserID,
+		Rating:    5,
+		Title:     util.RandomName(),
+	})
+	require.Error(t, err)
+}
+
+func TestListReviewsByProductID(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+	for i := 0; i < 3; i++ {
+		createRandomReview(t, testQueries, product, int32(i+1))
+	}
+	createRandomReview(t, testQueries, createRandomProduct(t, testQueries), 5)
+
+	reviews, err := testQueries.ListReviewsByProductID(context.Background(), ListReviewsByProductIDParams{
+		Limit:     2,
+		Offset:    0,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, reviews, 2)
+
+	for _, review := range reviews {
+		require.Equal(t, product.ID, review.ProductID)
+	}
+
+	count, err := testQueries.CountReviewsByProductID(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
+
+func TestGetProductRatingsByProductIDs(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product1 := createRandomProduct(t, testQueries)
+	createRandomReview(t, testQueries, product1, 4)
+	createRandomReview(t, testQueries, product1, 5)
+
+	product2 := createRandomProduct(t, testQueries)
+	createRandomReview(t, testQueries, product2, 1)
+
+	product3 := createRandomProduct(t, testQueries)
+
+	ratings, err := testQueries.GetProductRatingsByProductIDs(
+		context.Background(),
+		[]uuid.UUID{product1.ID, product2.ID, product3.ID},
+	)
+	require.NoError(t, err)
+	require.Len(t, ratings, 2)
+
+	ratingMap := make(map[uuid.UUID]GetProductRatingsByProductIDsRow)
+	for _, rating := range ratings {
+		ratingMap[rating.ProductID] = rating
+	}
+
+	require.Equal(t, 4.5, ratingMap[product1.ID].AverageRating)
+	require.Equal(t, int64(2), ratingMap[product1.ID].ReviewCount)
+	require.Equal(t, 1.0, ratingMap[product2.ID].AverageRating)
+	require.Equal(t, int64(1), ratingMap[product2.ID].ReviewCount)
+}
+
+func TestUpdateReview(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+	review1 := createRandomReview(t, testQueries, product, 2)
+
+	arg := UpdateReviewParams{
+		Rating:    5,
+		Title:     util.RandomName(),
+		ProductID: review1.ProductID,
+		UserID:    review1.UserID,
+	}
+
+	review2, err := testQueries.UpdateReview(context.Background(), arg)
+	require.NoError(t, err)
+
+	require.Equal(t, review1.ID, review2.ID)
+	require.Equal(t, arg.Rating, review2.Rating)
+	require.Equal(t, arg.Title, review2.Title)
+	require.False(t, review2.Body.Valid)
+}
+
+func TestDeleteReview(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	product := createRandomProduct(t, testQueries)
+	review1 := createRandomReview(t, testQueries, product, 3)
+
+	err := testQueries.DeleteReview(context.Background(), DeleteReviewParams{
+		ProductID: review1.ProductID,
+		UserID:    review1.UserID,
+	})
+	require.NoError(t, err)
+
+	review2, err := testQueries.GetReviewByProductIDAndUserID(context.Background(), GetReviewByProductIDAndUserIDParams{
+		ProductID: review1.ProductID,
+		UserID:    review1.UserID,
+	})
+	require.Error(t, err)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+	require.Empty(t, review2)
+}
@@ -0,0 +1,73 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: rotated_refresh_token.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createRotatedRefreshToken = `-- name: CreateRotatedRefreshToken :exec
+INSERT INTO rotated_refresh_tokens (
+  refresh_token_hash,
+  family_id,
+  expired_at
+) VALUES (
+  $1, $2, $3
+)
+`
+
+type CreateRotatedRefreshTokenParams struct {
+	RefreshTokenHash []byte    `json:"refresh_token_hash"`
+	FamilyID         uuid.UUID `json:"family_id"`
+	ExpiredAt        time.Time `json:"expired_at"`
+}
+
+func (q *Queries) CreateRotatedRefreshToken(ctx context.Context, arg CreateRotatedRefreshTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createRotatedRefreshToken, arg.RefreshTokenHash, arg.FamilyID, arg.ExpiredAt)
+	return err
+}
+
+const deleteExpiredRotatedRefreshTokens = `-- name: DeleteExpiredRotatedRefreshTokens :execrows
+DELETE FROM rotated_refresh_tokens
+WHERE expired_at < $1
+`
+
+func (q *Queries) DeleteExpiredRotatedRefreshTokens(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredRotatedRefreshTokens, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getRotatedRefreshToken = `-- name: GetRotatedRefreshToken :one
+SELECT family_id, expired_at, rotated_at, refresh_token_hash FROM rotated_refresh_tokens
+WHERE refresh_token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetRotatedRefreshToken(ctx context.Context, refreshTokenHash []byte) (RotatedRefreshToken, error) {
+	row := q.db.QueryRowContext(ctx, getRotatedRefreshToken, refreshTokenHash)
+	var i RotatedRefreshToken
+	err := row.Scan(
+		&i.FamilyID,
+		&i.ExpiredAt,
+		&i.RotatedAt,
+		&i.RefreshTokenHash,
+	)
+	return i, err
+}
+
+const truncateRotatedRefreshTokensTable = `-- name: TruncateRotatedRefreshTokensTable :exec
+TRUNCATE TABLE rotated_refresh_tokens CASCADE
+`
+
+func (q *Queries) TruncateRotatedRefreshTokensTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, truncateRotatedRefreshTokensTable)
+	return err
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomRotatedRefreshToken(t *testing.T, testQueries *Queries, expiredAt time.Time) RotatedRefreshToken {
+	session := createRandomSession(t, testQueries)
+
+	arg := CreateRotatedRefreshTokenParams{
+		RefreshTokenHash: util.RandomTokenHash(),
+		FamilyID:         session.FamilyID,
+		ExpiredAt:        expiredAt,
+	}
+
+	err := testQueries.CreateRotatedRefreshToken(context.Background(), arg)
+	require.NoError(t, err)
+
+	rotatedRefreshToken, err := testQueries.GetRotatedRefreshToken(context.Background(), arg.RefreshTokenHash)
+	require.NoError(t, err)
+
+	require.Equal(t, arg.RefreshTokenHash, rotatedRefreshToken.RefreshTokenHash)
+	require.Equal(t, arg.FamilyID, rotatedRefreshToken.FamilyID)
+	require.WithinDuration(t, arg.ExpiredAt, rotatedRefreshToken.ExpiredAt, time.Second)
+	require.NotZero(t, rotatedRefreshToken.RotatedAt)
+
+	return rotatedRefreshToken
+}
+
+func TestCreateRotatedRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	createRandomRotatedRefreshToken(t, testQueries, time.Now().Add(time.Minute))
+}
+
+func TestGetRotatedRefreshTokenNotFound(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	_, err := testQueries.GetRotatedRefreshToken(context.Background(), util.RandomTokenHash())
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestDeleteExpiredRotatedRefreshTokens(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	validToken := createRandomRotatedRefreshToken(t, testQueries, time.Now().Add(time.Minute))
+	expiredToken := createRandomRotatedRefreshToken(t, testQueries, time.Now().Add(-time.Minute))
+
+	count, err := testQueries.DeleteExpiredRotatedRefreshTokens(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(1))
+
+	_, err = testQueries.GetRotatedRefreshToken(context.Background(), expiredToken.RefreshTokenHash)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.GetRotatedRefreshToken(context.Background(), validToken.RefreshTokenHash)
+	require.NoError(t, err)
+}
@@ -15,74 +15,291 @@ import (
 const createSession = `-- name: CreateSession :one
 INSERT INTO sessions (
   user_id,
-  session_token,
+  session_token_hash,
   session_token_expired_at,
-  refresh_token,
-  refresh_token_expired_at
+  refresh_token_hash,
+  refresh_token_expired_at,
+  user_agent,
+  client_ip
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING id, user_id, session_token, session_token_expired_at, refresh_token, refresh_token_expired_at, created_at
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash
 `
 
 type CreateSessionParams struct {
 	UserID                uuid.UUID `json:"user_id"`
-	SessionToken          uuid.UUID `json:"session_token"`
+	SessionTokenHash      []byte    `json:"session_token_hash"`
 	SessionTokenExpiredAt time.Time `json:"session_token_expired_at"`
-	RefreshToken          uuid.UUID `json:"refresh_token"`
+	RefreshTokenHash      []byte    `json:"refresh_token_hash"`
 	RefreshTokenExpiredAt time.Time `json:"refresh_token_expired_at"`
+	UserAgent             string    `json:"user_agent"`
+	ClientIp              string    `json:"client_ip"`
 }
 
 func (q *Queries) CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error) {
 	row := q.db.QueryRowContext(ctx, createSession,
 		arg.UserID,
-		arg.SessionToken,
+		arg.SessionTokenHash,
 		arg.SessionTokenExpiredAt,
-		arg.RefreshToken,
+		arg.RefreshTokenHash,
 		arg.RefreshTokenExpiredAt,
+		arg.UserAgent,
+		arg.ClientIp,
 	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.SessionToken,
 		&i.SessionTokenExpiredAt,
-		&i.RefreshToken,
 		&i.RefreshTokenExpiredAt,
 		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.SessionTokenHash,
+		&i.RefreshTokenHash,
 	)
 	return i, err
 }
 
+const deleteExpiredSessions = `-- name: DeleteExpiredSessions :execrows
+DELETE FROM sessions
+WHERE refresh_token_expired_at < $1
+`
+
+func (q *Queries) DeleteExpiredSessions(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredSessions, expiredBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteOtherSessionsByUserID = `-- name: DeleteOtherSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = $1 AND id <> $2
+`
+
+type DeleteOtherSessionsByUserIDParams struct {
+	UserID           uuid.UUID `json:"user_id"`
+	CurrentSessionID uuid.UUID `json:"current_session_id"`
+}
+
+func (q *Queries) DeleteOtherSessionsByUserID(ctx context.Context, arg DeleteOtherSessionsByUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteOtherSessionsByUserID, arg.UserID, arg.CurrentSessionID)
+	return err
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
-WHERE session_token = $1
+WHERE session_token_hash = $1
+`
+
+func (q *Queries) DeleteSession(ctx context.Context, sessionTokenHash []byte) error {
+	_, err := q.db.ExecContext(ctx, deleteSession, sessionTokenHash)
+	return err
+}
+
+const deleteSessionByID = `-- name: DeleteSessionByID :exec
+DELETE FROM sessions
+WHERE id = $1
+`
+
+func (q *Queries) DeleteSessionByID(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionByID, id)
+	return err
+}
+
+const deleteSessionsByFamilyID = `-- name: DeleteSessionsByFamilyID :exec
+DELETE FROM sessions
+WHERE family_id = $1
 `
 
-func (q *Queries) DeleteSession(ctx context.Context, sessionToken uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteSession, sessionToken)
+func (q *Queries) DeleteSessionsByFamilyID(ctx context.Context, familyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionsByFamilyID, familyID)
+	return err
+}
+
+const deleteSessionsByUserID = `-- name: DeleteSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteSessionsByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteSessionsByUserID, userID)
 	return err
 }
 
 const getSession = `-- name: GetSession :one
-SELECT id, user_id, session_token, session_token_expired_at, refresh_token, refresh_token_expired_at, created_at FROM sessions
-WHERE session_token = $1 LIMIT 1
+SELECT id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash FROM sessions
+WHERE session_token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetSession(ctx context.Context, sessionTokenHash []byte) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSession, sessionTokenHash)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SessionTokenExpiredAt,
+		&i.RefreshTokenExpiredAt,
+		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.SessionTokenHash,
+		&i.RefreshTokenHash,
+	)
+	return i, err
+}
+
+const getSessionByFamilyID = `-- name: GetSessionByFamilyID :one
+SELECT id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash FROM sessions
+WHERE family_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSessionByFamilyID(ctx context.Context, familyID uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSessionByFamilyID, familyID)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SessionTokenExpiredAt,
+		&i.RefreshTokenExpiredAt,
+		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.SessionTokenHash,
+		&i.RefreshTokenHash,
+	)
+	return i, err
+}
+
+const getSessionByID = `-- name: GetSessionByID :one
+SELECT id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash FROM sessions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetSessionByID(ctx context.Context, id uuid.UUID) (Session, error) {
+	row := q.db.QueryRowContext(ctx, getSessionByID, id)
+	var i Session
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SessionTokenExpiredAt,
+		&i.RefreshTokenExpiredAt,
+		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.SessionTokenHash,
+		&i.RefreshTokenHash,
+	)
+	return i, err
+}
+
+const listSessionsByUserID = `-- name: ListSessionsByUserID :many
+SELECT id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash FROM sessions
+WHERE user_id = $1 AND refresh_token_expired_at > now()
+ORDER BY last_used_at DESC, id
+`
+
+func (q *Queries) ListSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]Session, error) {
+	rows, err := q.db.QueryContext(ctx, listSessionsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Session{}
+	for rows.Next() {
+		var i Session
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SessionTokenExpiredAt,
+			&i.RefreshTokenExpiredAt,
+			&i.CreatedAt,
+			&i.UserAgent,
+			&i.ClientIp,
+			&i.LastUsedAt,
+			&i.FamilyID,
+			&i.SessionTokenHash,
+			&i.RefreshTokenHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotateSessionTokens = `-- name: RotateSessionTokens :one
+UPDATE sessions
+SET
+  session_token_hash = $1,
+  session_token_expired_at = $2,
+  refresh_token_hash = $3,
+  refresh_token_expired_at = $4,
+  last_used_at = now()
+WHERE refresh_token_hash = $5 AND refresh_token_expired_at > now()
+RETURNING id, user_id, session_token_expired_at, refresh_token_expired_at, created_at, user_agent, client_ip, last_used_at, family_id, session_token_hash, refresh_token_hash
 `
 
-func (q *Queries) GetSession(ctx context.Context, sessionToken uuid.UUID) (Session, error) {
-	row := q.db.QueryRowContext(ctx, getSession, sessionToken)
+type RotateSessionTokensParams struct {
+	SessionTokenHash        []byte    `json:"session_token_hash"`
+	SessionTokenExpiredAt   time.Time `json:"session_token_expired_at"`
+	RefreshTokenHash        []byte    `json:"refresh_token_hash"`
+	RefreshTokenExpiredAt   time.Time `json:"refresh_token_expired_at"`
+	CurrentRefreshTokenHash []byte    `json:"current_refresh_token_hash"`
+}
+
+func (q *Queries) RotateSessionTokens(ctx context.Context, arg RotateSessionTokensParams) (Session, error) {
+	row := q.db.QueryRowContext(ctx, rotateSessionTokens,
+		arg.SessionTokenHash,
+		arg.SessionTokenExpiredAt,
+		arg.RefreshTokenHash,
+		arg.RefreshTokenExpiredAt,
+		arg.CurrentRefreshTokenHash,
+	)
 	var i Session
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.SessionToken,
 		&i.SessionTokenExpiredAt,
-		&i.RefreshToken,
 		&i.RefreshTokenExpiredAt,
 		&i.CreatedAt,
+		&i.UserAgent,
+		&i.ClientIp,
+		&i.LastUsedAt,
+		&i.FamilyID,
+		&i.SessionTokenHash,
+		&i.RefreshTokenHash,
 	)
 	return i, err
 }
 
+const touchSession = `-- name: TouchSession :exec
+UPDATE sessions
+SET last_used_at = now()
+WHERE id = $1
+`
+
+func (q *Queries) TouchSession(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchSession, id)
+	return err
+}
+
 const truncateSessionsTable = `-- name: TruncateSessionsTable :exec
 TRUNCATE TABLE sessions CASCADE
 `
@@ -16,9 +16,9 @@ func createRandomSession(t *testing.T, testQueries *Queries) Session {
 
 	arg := CreateSessionParams{
 		UserID:                user.ID,
-		SessionToken:          util.RandomUUID(),
+		SessionTokenHash:      util.RandomTokenHash(),
 		SessionTokenExpiredAt: time.Now().Add(time.Minute),
-		RefreshToken:          util.RandomUUID(),
+		RefreshTokenHash:      util.RandomTokenHash(),
 		RefreshTokenExpiredAt: time.Now().Add(time.Minute),
 	}
 
@@ -27,9 +27,9 @@ func createRandomSession(t *testing.T, testQueries *Queries) Session {
 	require.NotEmpty(t, session)
 
 	require.Equal(t, arg.UserID, session.UserID)
-	require.Equal(t, arg.SessionToken, session.SessionToken)
+	require.Equal(t, arg.SessionTokenHash, session.SessionTokenHash)
 	require.WithinDuration(t, arg.SessionTokenExpiredAt, session.SessionTokenExpiredAt, time.Second)
-	require.Equal(t, arg.RefreshToken, session.RefreshToken)
+	require.Equal(t, arg.RefreshTokenHash, session.RefreshTokenHash)
 	require.WithinDuration(t, arg.RefreshTokenExpiredAt, session.RefreshTokenExpiredAt, time.Second)
 
 	require.NotEmpty(t, session.ID)
@@ -58,15 +58,15 @@ func TestGetSession(t *testing.T) {
 	testQueries := New(db)
 
 	session1 := createRandomSession(t, testQueries)
-	session2, err := testQueries.GetSession(context.Background(), session1.SessionToken)
+	session2, err := testQueries.GetSession(context.Background(), session1.SessionTokenHash)
 	require.NoError(t, err)
 	require.NotEmpty(t, session2)
 
 	require.Equal(t, session1.ID, session2.ID)
 	require.Equal(t, session1.UserID, session2.UserID)
-	require.Equal(t, session1.SessionToken, session2.SessionToken)
+	require.Equal(t, session1.SessionTokenHash, session2.SessionTokenHash)
 	require.Equal(t, session1.SessionTokenExpiredAt, session2.SessionTokenExpiredAt)
-	require.Equal(t, session1.RefreshToken, session2.RefreshToken)
+	require.Equal(t, session1.RefreshTokenHash, session2.RefreshTokenHash)
 	require.Equal(t, session1.RefreshTokenExpiredAt, session2.RefreshTokenExpiredAt)
 	require.WithinDuration(t, session1.CreatedAt, session2.CreatedAt, time.Second)
 }
@@ -80,11 +80,163 @@ func TestDeleteSession(t *testing.T) {
 	testQueries := New(db)
 
 	session1 := createRandomSession(t, testQueries)
-	err := testQueries.DeleteSession(context.Background(), session1.ID)
+	err := testQueries.DeleteSession(context.Background(), session1.SessionTokenHash)
 	require.NoError(t, err)
 
-	session2, err := testQueries.GetSession(context.Background(), session1.ID)
+	session2, err := testQueries.GetSession(context.Background(), session1.SessionTokenHash)
 	require.Error(t, err)
 	require.EqualError(t, err, sql.ErrNoRows.Error())
 	require.Empty(t, session2)
 }
+
+func TestListSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	session1 := createRandomSession(t, testQueries)
+
+	session2, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		UserID:                session1.UserID,
+		SessionTokenHash:      util.RandomTokenHash(),
+		SessionTokenExpiredAt: time.Now().Add(time.Minute),
+		RefreshTokenHash:      util.RandomTokenHash(),
+		RefreshTokenExpiredAt: time.Now().Add(time.Minute),
+		UserAgent:             "test-user-agent",
+		ClientIp:              "192.0.2.1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "test-user-agent", session2.UserAgent)
+	require.Equal(t, "192.0.2.1", session2.ClientIp)
+	require.NotZero(t, session2.LastUsedAt)
+
+	// Sessions whose refresh token has expired are not listed
+	_, err = testQueries.CreateSession(context.Background(), CreateSessionParams{
+		UserID:                session1.UserID,
+		SessionTokenHash:      util.RandomTokenHash(),
+		SessionTokenExpiredAt: time.Now().Add(-time.Minute),
+		RefreshTokenHash:      util.RandomTokenHash(),
+		RefreshTokenExpiredAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_ = createRandomSession(t, testQueries)
+
+	sessions, err := testQueries.ListSessionsByUserID(context.Background(), session1.UserID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	for _, session := range sessions {
+		require.Equal(t, session1.UserID, session.UserID)
+	}
+}
+
+func TestTouchSession(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	session1 := createRandomSession(t, testQueries)
+
+	err := testQueries.TouchSession(context.Background(), session1.ID)
+	require.NoError(t, err)
+
+	session2, err := testQueries.GetSessionByID(context.Background(), session1.ID)
+	require.NoError(t, err)
+	require.False(t, session2.LastUsedAt.Before(session1.LastUsedAt))
+}
+
+func TestDeleteOtherSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	session1 := createRandomSession(t, testQueries)
+
+	session2, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		UserID:                session1.UserID,
+		SessionTokenHash:      util.RandomTokenHash(),
+		SessionTokenExpiredAt: time.Now().Add(time.Minute),
+		RefreshTokenHash:      util.RandomTokenHash(),
+		RefreshTokenExpiredAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	otherUserSession := createRandomSession(t, testQueries)
+
+	err = testQueries.DeleteOtherSessionsByUserID(context.Background(), DeleteOtherSessionsByUserIDParams{
+		UserID:           session1.UserID,
+		CurrentSessionID: session1.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetSessionByID(context.Background(), session1.ID)
+	require.NoError(t, err)
+
+	_, err = testQueries.GetSessionByID(context.Background(), session2.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.GetSessionByID(context.Background(), otherUserSession.ID)
+	require.NoError(t, err)
+}
+
+func TestDeleteSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	session1 := createRandomSession(t, testQueries)
+	otherUserSession := createRandomSession(t, testQueries)
+
+	err := testQueries.DeleteSessionsByUserID(context.Background(), session1.UserID)
+	require.NoError(t, err)
+
+	sessions, err := testQueries.ListSessionsByUserID(context.Background(), session1.UserID)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	_, err = testQueries.GetSessionByID(context.Background(), otherUserSession.ID)
+	require.NoError(t, err)
+}
+
+func TestDeleteExpiredSessions(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	validSession := createRandomSession(t, testQueries)
+
+	expiredSession, err := testQueries.CreateSession(context.Background(), CreateSessionParams{
+		UserID:                validSession.UserID,
+		SessionTokenHash:      util.RandomTokenHash(),
+		SessionTokenExpiredAt: time.Now().Add(-time.Hour),
+		RefreshTokenHash:      util.RandomTokenHash(),
+		RefreshTokenExpiredAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	count, err := testQueries.DeleteExpiredSessions(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, count, int64(1))
+
+	_, err = testQueries.GetSessionByID(context.Background(), expiredSession.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = testQueries.GetSessionByID(context.Background(), validSession.ID)
+	require.NoError(t, err)
+}
@@ -1,20 +1,97 @@
 package db
 
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxTxRetries is the number of attempts made for a transaction that fails
+// with a retryable error, see isRetryableTxError
+const maxTxRetries = 3
+
 // Store provides all functions to execute db queries and transactions
 type Store interface {
 	Querier
+	ExecTx(ctx context.Context, fn func(*Queries) error) error
+	RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error)
+	LockLoginTx(ctx context.Context, arg LockLoginTxParams) (LoginLockoutEvent, error)
+	AddCartProductTx(ctx context.Context, arg AddCartProductTxParams) (CartProduct, error)
+	CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error)
+	MoveWishlistProductToCartTx(ctx context.Context, arg MoveWishlistProductToCartTxParams) (CartProduct, error)
 }
 
 // SQLStore provides all functions to execute SQL queries and transactions
 type SQLStore struct {
 	*Queries
-	db DBTX
+	db *sql.DB
 }
 
-// NewStore creates a new Store
-func NewStore(db DBTX) *SQLStore {
+// NewStore creates a new Store, which traces every query
+func NewStore(db *sql.DB) *SQLStore {
 	return &SQLStore{
 		db:      db,
-		Queries: New(db),
+		Queries: New(newTracingDBTX(db)),
+	}
+}
+
+// ExecTx executes a function within a serializable database transaction.
+// The transaction is retried when it fails with a serialization failure.
+func (store *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
+	ctx, span := tracing.Start(ctx, "db.ExecTx")
+	defer span.End()
+
+	var err error
+	for i := 0; i < maxTxRetries; i++ {
+		span.SetAttributes(attribute.Int("db.tx.attempts", i+1))
+
+		err = store.execTx(ctx, fn)
+		if !isRetryableTxError(err) {
+			break
+		}
+	}
+
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) error {
+	tx, err := store.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return err
+	}
+
+	err = fn(New(newTracingDBTX(tx)))
+	if err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isRetryableTxError checks if the error is a serialization failure, a deadlock
+// or a change of the stock during the transaction
+func isRetryableTxError(err error) bool {
+	if errors.Is(err, errStockChanged) {
+		return true
+	}
+
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code.Name() {
+	case "serialization_failure", "deadlock_detected":
+		return true
 	}
+	return false
 }
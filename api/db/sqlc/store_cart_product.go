@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+// AddCartProductTxParams contains the input parameters of adding a product to the cart
+type AddCartProductTxParams struct {
+	UserID    uuid.UUID
+	ProductID uuid.UUID
+	Quantity  int32
+}
+
+// AddCartProductTx adds a product to the cart, or increments its quantity
+// if the product is already in the cart, in a single transaction.
+// It fails with an InsufficientStockError if the resulting quantity exceeds the stock.
+func (store *SQLStore) AddCartProductTx(ctx context.Context, arg AddCartProductTxParams) (CartProduct, error) {
+	var cartProduct CartProduct
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+		cartProduct, err = addCartProduct(ctx, q, arg)
+		return err
+	})
+
+	return cartProduct, err
+}
+
+// addCartProduct creates the cart product, or merges the quantity into the existing one.
+// It must be called within a transaction.
+func addCartProduct(ctx context.Context, q *Queries, arg AddCartProductTxParams) (CartProduct, error) {
+	product, err := q.GetProduct(ctx, arg.ProductID)
+	if err != nil {
+		return CartProduct{}, err
+	}
+
+	cartProduct, err := q.GetCartProductByUserIDAndProductID(ctx, GetCartProductByUserIDAndProductIDParams{
+		UserID:    arg.UserID,
+		ProductID: arg.ProductID,
+	})
+	if err == sql.ErrNoRows {
+		err = CheckProductStock(product, arg.Quantity)
+		if err != nil {
+			return CartProduct{}, err
+		}
+
+		return q.CreateCartProduct(ctx, CreateCartProductParams{
+			UserID:    arg.UserID,
+			ProductID: arg.ProductID,
+			Quantity:  arg.Quantity,
+		})
+	} else if err != nil {
+		return CartProduct{}, err
+	}
+
+	quantity := cartProduct.Quantity + arg.Quantity
+
+	err = CheckProductStock(product, quantity)
+	if err != nil {
+		return CartProduct{}, err
+	}
+
+	return q.UpdateCartProduct(ctx, UpdateCartProductParams{
+		UserID:    arg.UserID,
+		ProductID: arg.ProductID,
+		Quantity:  quantity,
+	})
+}
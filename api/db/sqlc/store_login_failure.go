@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// LockLoginTxParams contains the input parameters of the login lockout
+type LockLoginTxParams = CreateLoginLockoutEventParams
+
+// LockLoginTx locks logins for the scope and key until the given time and records the lockout as an event
+// in a single transaction.
+func (store *SQLStore) LockLoginTx(ctx context.Context, arg LockLoginTxParams) (LoginLockoutEvent, error) {
+	var event LoginLockoutEvent
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		_, err = q.LockLogin(ctx, LockLoginParams{
+			Scope:       arg.Scope,
+			Key:         arg.Key,
+			LockedUntil: sql.NullTime{Time: arg.LockedUntil, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		event, err = q.CreateLoginLockoutEvent(ctx, arg)
+		return err
+	})
+	if err != nil {
+		return LoginLockoutEvent{}, err
+	}
+
+	return event, nil
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// CheckoutTxParams contains the input parameters of the checkout.
+// The OrderID of the items is filled in by CheckoutTx.
+type CheckoutTxParams struct {
+	CreateOrderParams
+	Items []CreateOrderItemParams
+}
+
+// CheckoutTxResult is the result of the checkout
+type CheckoutTxResult struct {
+	Order Order
+	Items []OrderItem
+}
+
+// ErrCartChanged is returned by CheckoutTx when the cart no longer holds the ordered quantities,
+// e.g. because it has been checked out concurrently or a quantity has been lowered since it was read
+var ErrCartChanged = errors.New("cart changed during the checkout")
+
+// CheckoutTx creates the order with its items, decrements the stock of the ordered products
+// and removes the ordered quantities from the user's cart in a single transaction.
+// Only the ordered quantities are removed, so that products added to the cart after the items were read
+// stay in the cart. It fails with an InsufficientStockError if any product doesn't have enough stock,
+// and with ErrCartChanged if the cart doesn't hold the ordered quantities anymore.
+func (store *SQLStore) CheckoutTx(ctx context.Context, arg CheckoutTxParams) (CheckoutTxResult, error) {
+	var result CheckoutTxResult
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		result.Order, err = q.CreateOrder(ctx, arg.CreateOrderParams)
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]OrderItem, len(arg.Items))
+		for i, item := range arg.Items {
+			err = consumeProductStock(ctx, q, item.ProductID, item.Quantity)
+			if err != nil {
+				return err
+			}
+
+			item.OrderID = result.Order.ID
+
+			result.Items[i], err = q.CreateOrderItem(ctx, item)
+			if err != nil {
+				return err
+			}
+
+			err = removeOrderedCartProduct(ctx, q, arg.UserID, item.ProductID, item.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// removeOrderedCartProduct subtracts the ordered quantity from the cart product,
+// and deletes the cart product once nothing is left.
+// ErrCartChanged is returned if the cart product is gone or holds less than the ordered quantity,
+// so that nothing is ordered twice or beyond what is in the cart.
+func removeOrderedCartProduct(ctx context.Context, q *Queries, userID uuid.UUID, productID uuid.UUID, quantity int32) error {
+	cartProduct, err := q.SubtractCartProductQuantity(ctx, SubtractCartProductQuantityParams{
+		UserID:    userID,
+		ProductID: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCartChanged
+		}
+		return err
+	}
+
+	if cartProduct.Quantity > 0 {
+		return nil
+	}
+
+	return q.DeleteCartProduct(ctx, DeleteCartProductParams{
+		UserID:    userID,
+		ProductID: productID,
+	})
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// errStockChanged is returned when the stock of a product could not be decremented although enough stock is left,
+// which means that the stock has changed during the transaction. The transaction is retried.
+var errStockChanged = errors.New("product stock changed during the transaction")
+
+// InsufficientStockError is returned when the requested quantity of a product exceeds its stock
+type InsufficientStockError struct {
+	ProductID uuid.UUID
+	Requested int32
+	Available int32
+}
+
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for product %s: requested %d, available %d", e.ProductID, e.Requested, e.Available)
+}
+
+// CheckProductStock returns an InsufficientStockError if the product has less than `quantity` in stock
+func CheckProductStock(product Product, quantity int32) error {
+	if quantity > product.StockQuantity {
+		return &InsufficientStockError{
+			ProductID: product.ID,
+			Requested: quantity,
+			Available: product.StockQuantity,
+		}
+	}
+	return nil
+}
+
+// consumeProductStock decrements the stock of the product only if enough stock is left,
+// so that concurrent purchases can never oversell the product.
+// The product is only read to report the available stock if the stock could not be decremented.
+func consumeProductStock(ctx context.Context, q *Queries, productID uuid.UUID, quantity int32) error {
+	_, err := q.DecrementProductStock(ctx, DecrementProductStockParams{
+		ID:       productID,
+		Quantity: quantity,
+	})
+	if err != sql.ErrNoRows {
+		return err
+	}
+
+	product, err := q.GetProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if err := CheckProductStock(product, quantity); err != nil {
+		return err
+	}
+
+	// The stock was not decremented, so the order must not go through even if the stock is sufficient by now
+	return errStockChanged
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// ErrRefreshTokenReused is returned when a refresh token that has already been rotated is presented again
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+// refreshTokenGracePeriod is how long a rotated refresh token is still accepted.
+// Clients such as browsers with several tabs may refresh concurrently with the same token,
+// which must not be mistaken for a stolen token.
+const refreshTokenGracePeriod = 10 * time.Second
+
+// RotateSessionTxParams contains the input parameters of the session rotation
+type RotateSessionTxParams = RotateSessionTokensParams
+
+// RotateSessionTx replaces the session and refresh tokens of the session that owns the current refresh token
+// in a single transaction. The session keeps its ID, family, creation time and client information.
+//
+// The replaced refresh token is remembered for its family. If it is presented again within the grace period,
+// the successor session of the family is rotated instead, so the last concurrent refresh wins.
+// If it is presented again later, the whole family is revoked and ErrRefreshTokenReused is returned,
+// since the token must have been stolen.
+// sql.ErrNoRows is returned if the refresh token is unknown or has expired.
+func (store *SQLStore) RotateSessionTx(ctx context.Context, arg RotateSessionTxParams) (Session, error) {
+	var session Session
+	var reused bool
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		var err error
+
+		reused = false
+
+		session, err = rotateSession(ctx, q, arg)
+		if err != sql.ErrNoRows {
+			return err
+		}
+
+		rotated, err := q.GetRotatedRefreshToken(ctx, arg.CurrentRefreshTokenHash)
+		if err != nil {
+			return err
+		}
+
+		if time.Since(rotated.RotatedAt) > refreshTokenGracePeriod {
+			// The revocation must be committed, so the reuse is reported after the transaction
+			reused = true
+			return q.DeleteSessionsByFamilyID(ctx, rotated.FamilyID)
+		}
+
+		successor, err := q.GetSessionByFamilyID(ctx, rotated.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		successorArg := arg
+		successorArg.CurrentRefreshTokenHash = successor.RefreshTokenHash
+
+		session, err = rotateSession(ctx, q, successorArg)
+		return err
+	})
+	if err != nil {
+		return Session{}, err
+	}
+	if reused {
+		return Session{}, ErrRefreshTokenReused
+	}
+
+	return session, nil
+}
+
+// rotateSession replaces the tokens of the session and remembers the replaced refresh token for its family.
+func rotateSession(ctx context.Context, q *Queries, arg RotateSessionTxParams) (Session, error) {
+	session, err := q.RotateSessionTokens(ctx, arg)
+	if err != nil {
+		return Session{}, err
+	}
+
+	err = q.CreateRotatedRefreshToken(ctx, CreateRotatedRefreshTokenParams{
+		RefreshTokenHash: arg.CurrentRefreshTokenHash,
+		FamilyID:         session.FamilyID,
+		ExpiredAt:        arg.RefreshTokenExpiredAt,
+	})
+	if err != nil {
+		return Session{}, err
+	}
+
+	return session, nil
+}
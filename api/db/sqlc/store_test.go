@@ -0,0 +1,662 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/ot07/next-bazaar/test_util"
+	"github.com/ot07/next-bazaar/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecTxRollback(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	errTest := errors.New("test error")
+	name := util.RandomName()
+
+	err := store.ExecTx(context.Background(), func(q *Queries) error {
+		_, err := q.CreateCategory(context.Background(), name)
+		require.NoError(t, err)
+
+		return errTest
+	})
+	require.ErrorIs(t, err, errTest)
+
+	categories, err := store.ListCategories(context.Background(), ListCategoriesParams{Limit: 100})
+	require.NoError(t, err)
+
+	for _, category := range categories {
+		require.NotEqual(t, name, category.Name)
+	}
+}
+
+func TestIsRetryableTxError(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, isRetryableTxError(&pq.Error{Code: "40001"}))
+	require.True(t, isRetryableTxError(&pq.Error{Code: "40P01"}))
+	require.True(t, isRetryableTxError(fmt.Errorf("checkout: %w", errStockChanged)))
+	require.False(t, isRetryableTxError(&pq.Error{Code: "23505"}))
+	require.False(t, isRetryableTxError(sql.ErrNoRows))
+	require.False(t, isRetryableTxError(nil))
+}
+
+func TestRotateSessionTx(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	session1 := createRandomSession(t, store.Queries)
+
+	arg := RotateSessionTxParams{
+		CurrentRefreshTokenHash: session1.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	}
+
+	session2, err := store.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, session1.ID, session2.ID)
+	require.Equal(t, session1.FamilyID, session2.FamilyID)
+	require.Equal(t, arg.SessionTokenHash, session2.SessionTokenHash)
+	require.Equal(t, arg.RefreshTokenHash, session2.RefreshTokenHash)
+	require.WithinDuration(t, arg.RefreshTokenExpiredAt, session2.RefreshTokenExpiredAt, time.Second)
+	require.Equal(t, session1.UserAgent, session2.UserAgent)
+	require.Equal(t, session1.ClientIp, session2.ClientIp)
+	require.WithinDuration(t, session1.CreatedAt, session2.CreatedAt, time.Second)
+
+	_, err = store.GetSession(context.Background(), session1.SessionTokenHash)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	_, err = store.GetSession(context.Background(), session2.SessionTokenHash)
+	require.NoError(t, err)
+
+	rotated, err := store.GetRotatedRefreshToken(context.Background(), session1.RefreshTokenHash)
+	require.NoError(t, err)
+	require.Equal(t, session1.FamilyID, rotated.FamilyID)
+
+	// The new refresh token can be rotated again
+	arg2 := RotateSessionTxParams{
+		CurrentRefreshTokenHash: session2.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	}
+
+	session3, err := store.RotateSessionTx(context.Background(), arg2)
+	require.NoError(t, err)
+	require.Equal(t, session1.ID, session3.ID)
+}
+
+func TestRotateSessionTxReuseDetection(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	session1 := createRandomSession(t, store.Queries)
+	otherSession := createRandomSession(t, store.Queries)
+
+	arg := RotateSessionTxParams{
+		CurrentRefreshTokenHash: session1.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	}
+
+	_, err := store.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	_, err = db.Exec(
+		"UPDATE rotated_refresh_tokens SET rotated_at = $1 WHERE refresh_token_hash = $2",
+		time.Now().Add(-refreshTokenGracePeriod-time.Second), session1.RefreshTokenHash,
+	)
+	require.NoError(t, err)
+
+	// Presenting the rotated refresh token again after the grace period revokes the whole family
+	reuseArg := arg
+	reuseArg.SessionTokenHash = util.RandomTokenHash()
+	reuseArg.RefreshTokenHash = util.RandomTokenHash()
+
+	_, err = store.RotateSessionTx(context.Background(), reuseArg)
+	require.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, err = store.GetSessionByID(context.Background(), session1.ID)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// The rotated refresh token of the legitimate user no longer works either
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		CurrentRefreshTokenHash: arg.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+
+	// Other families are not affected
+	_, err = store.GetSessionByID(context.Background(), otherSession.ID)
+	require.NoError(t, err)
+}
+
+func TestRotateSessionTxGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	session1 := createRandomSession(t, store.Queries)
+
+	arg := RotateSessionTxParams{
+		CurrentRefreshTokenHash: session1.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	}
+
+	session2, err := store.RotateSessionTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	// A concurrent refresh with the same token rotates the successor session instead of revoking the family
+	concurrentArg := arg
+	concurrentArg.SessionTokenHash = util.RandomTokenHash()
+	concurrentArg.RefreshTokenHash = util.RandomTokenHash()
+
+	session3, err := store.RotateSessionTx(context.Background(), concurrentArg)
+	require.NoError(t, err)
+	require.Equal(t, session1.ID, session3.ID)
+	require.Equal(t, concurrentArg.SessionTokenHash, session3.SessionTokenHash)
+	require.Equal(t, concurrentArg.RefreshTokenHash, session3.RefreshTokenHash)
+
+	_, err = store.GetSession(context.Background(), session3.SessionTokenHash)
+	require.NoError(t, err)
+
+	// The refresh token of the other concurrent refresh is also just rotated
+	rotated, err := store.GetRotatedRefreshToken(context.Background(), session2.RefreshTokenHash)
+	require.NoError(t, err)
+	require.Equal(t, session1.FamilyID, rotated.FamilyID)
+
+	session4, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		CurrentRefreshTokenHash: session2.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Equal(t, session1.ID, session4.ID)
+}
+
+func TestRotateSessionTxUnknownRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	_, err := store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		CurrentRefreshTokenHash: util.RandomTokenHash(),
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestRotateSessionTxExpiredRefreshToken(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+
+	session, err := store.CreateSession(context.Background(), CreateSessionParams{
+		UserID:                user.ID,
+		SessionTokenHash:      util.RandomTokenHash(),
+		SessionTokenExpiredAt: time.Now().Add(-time.Hour),
+		RefreshTokenHash:      util.RandomTokenHash(),
+		RefreshTokenExpiredAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	_, err = store.RotateSessionTx(context.Background(), RotateSessionTxParams{
+		CurrentRefreshTokenHash: session.RefreshTokenHash,
+		SessionTokenHash:        util.RandomTokenHash(),
+		SessionTokenExpiredAt:   time.Now().Add(time.Minute),
+		RefreshTokenHash:        util.RandomTokenHash(),
+		RefreshTokenExpiredAt:   time.Now().Add(time.Hour),
+	})
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestAddCartProductTx(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	product := createRandomProductWithStock(t, store.Queries, 5)
+
+	arg := AddCartProductTxParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  2,
+	}
+
+	cartProduct, err := store.AddCartProductTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), cartProduct.Quantity)
+
+	cartProduct, err = store.AddCartProductTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), cartProduct.Quantity)
+
+	_, err = store.AddCartProductTx(context.Background(), arg)
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, product.ID, stockErr.ProductID)
+	require.Equal(t, int32(6), stockErr.Requested)
+	require.Equal(t, int32(5), stockErr.Available)
+
+	cartProduct, err = store.GetCartProductByUserIDAndProductID(context.Background(), GetCartProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), cartProduct.Quantity)
+}
+
+func TestMoveWishlistProductToCartTx(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	product := createRandomProductWithStock(t, store.Queries, 5)
+
+	arg := MoveWishlistProductToCartTxParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  2,
+	}
+
+	_, err := store.MoveWishlistProductToCartTx(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  2,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateWishlistProduct(context.Background(), CreateWishlistProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.MoveWishlistProductToCartTx(context.Background(), MoveWishlistProductToCartTxParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  4,
+	})
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, int32(6), stockErr.Requested)
+
+	_, err = store.GetWishlistProductByUserIDAndProductID(context.Background(), GetWishlistProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+
+	cartProduct, err := store.MoveWishlistProductToCartTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), cartProduct.Quantity)
+
+	_, err = store.GetWishlistProductByUserIDAndProductID(context.Background(), GetWishlistProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestCheckoutTx(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+
+	products := []Product{
+		createRandomProductWithStock(t, store.Queries, 10),
+		createRandomProductWithStock(t, store.Queries, 10),
+	}
+
+	items := make([]CreateOrderItemParams, len(products))
+	for i, product := range products {
+		_, err := store.CreateCartProduct(context.Background(), CreateCartProductParams{
+			UserID:    user.ID,
+			ProductID: product.ID,
+			Quantity:  1,
+		})
+		require.NoError(t, err)
+
+		items[i] = CreateOrderItemParams{
+			ProductID: product.ID,
+			Name:      product.Name,
+			Price:     product.Price,
+			Quantity:  1,
+		}
+	}
+
+	result, err := store.CheckoutTx(context.Background(), CheckoutTxParams{
+		CreateOrderParams: CreateOrderParams{
+			UserID:   user.ID,
+			Subtotal: "100",
+			Shipping: "5",
+			Tax:      "10",
+			Total:    "115",
+		},
+		Items: items,
+	})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, result.Order.UserID)
+	require.Len(t, result.Items, len(products))
+
+	for _, item := range result.Items {
+		require.Equal(t, result.Order.ID, item.OrderID)
+	}
+
+	cartProducts, err := store.GetCartProductsByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Empty(t, cartProducts)
+
+	for _, product := range products {
+		gotProduct, err := store.GetProduct(context.Background(), product.ID)
+		require.NoError(t, err)
+		require.Equal(t, int32(9), gotProduct.StockQuantity)
+	}
+}
+
+func TestCheckoutTxConcurrent(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	product := createRandomProductWithStock(t, store.Queries, 10)
+
+	_, err := store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  2,
+	})
+	require.NoError(t, err)
+
+	// Both checkouts are built from the same cart, as with a double-submitted checkout
+	arg := CheckoutTxParams{
+		CreateOrderParams: CreateOrderParams{
+			UserID:   user.ID,
+			Subtotal: "100",
+			Shipping: "5",
+			Tax:      "10",
+			Total:    "115",
+		},
+		Items: []CreateOrderItemParams{
+			{
+				ProductID: product.ID,
+				Name:      product.Name,
+				Price:     product.Price,
+				Quantity:  2,
+			},
+		},
+	}
+
+	n := 2
+	errs := make(chan error, n)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.CheckoutTx(context.Background(), arg)
+			errs <- err
+		}()
+	}
+
+	var succeeded int
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrCartChanged)
+	}
+	require.Equal(t, 1, succeeded)
+
+	orders, err := store.ListOrdersByUser(context.Background(), ListOrdersByUserParams{
+		UserID: user.ID,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+
+	gotProduct, err := store.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(8), gotProduct.StockQuantity)
+}
+
+func TestCheckoutTxCartQuantityLowered(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	product := createRandomProductWithStock(t, store.Queries, 10)
+
+	_, err := store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  1,
+	})
+	require.NoError(t, err)
+
+	// The cart held 2 when the items were read
+	_, err = store.CheckoutTx(context.Background(), CheckoutTxParams{
+		CreateOrderParams: CreateOrderParams{
+			UserID:   user.ID,
+			Subtotal: "100",
+			Shipping: "5",
+			Tax:      "10",
+			Total:    "115",
+		},
+		Items: []CreateOrderItemParams{
+			{
+				ProductID: product.ID,
+				Name:      product.Name,
+				Price:     product.Price,
+				Quantity:  2,
+			},
+		},
+	})
+	require.ErrorIs(t, err, ErrCartChanged)
+
+	// Nothing is ordered
+	gotProduct, err := store.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), gotProduct.StockQuantity)
+
+	cartProduct, err := store.GetCartProductByUserIDAndProductID(context.Background(), GetCartProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), cartProduct.Quantity)
+}
+
+func TestCheckoutTxCartChangedAfterRead(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	orderedProduct := createRandomProductWithStock(t, store.Queries, 10)
+	addedProduct := createRandomProductWithStock(t, store.Queries, 10)
+
+	_, err := store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: orderedProduct.ID,
+		Quantity:  2,
+	})
+	require.NoError(t, err)
+
+	// The items are built from the cart as it was read
+	items := []CreateOrderItemParams{
+		{
+			ProductID: orderedProduct.ID,
+			Name:      orderedProduct.Name,
+			Price:     orderedProduct.Price,
+			Quantity:  2,
+		},
+	}
+
+	// The cart changes between the read and the checkout
+	_, err = store.UpdateCartProduct(context.Background(), UpdateCartProductParams{
+		UserID:    user.ID,
+		ProductID: orderedProduct.ID,
+		Quantity:  3,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: addedProduct.ID,
+		Quantity:  1,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CheckoutTx(context.Background(), CheckoutTxParams{
+		CreateOrderParams: CreateOrderParams{
+			UserID:   user.ID,
+			Subtotal: "100",
+			Shipping: "5",
+			Tax:      "10",
+			Total:    "115",
+		},
+		Items: items,
+	})
+	require.NoError(t, err)
+
+	// Only the ordered quantity is removed from the cart
+	gotOrderedProduct, err := store.GetCartProductByUserIDAndProductID(context.Background(), GetCartProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: orderedProduct.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), gotOrderedProduct.Quantity)
+
+	gotAddedProduct, err := store.GetCartProductByUserIDAndProductID(context.Background(), GetCartProductByUserIDAndProductIDParams{
+		UserID:    user.ID,
+		ProductID: addedProduct.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), gotAddedProduct.Quantity)
+}
+
+func TestCheckoutTxInsufficientStock(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	store := NewStore(db)
+
+	user := createRandomUser(t, store.Queries)
+	product := createRandomProductWithStock(t, store.Queries, 3)
+
+	_, err := store.CreateCartProduct(context.Background(), CreateCartProductParams{
+		UserID:    user.ID,
+		ProductID: product.ID,
+		Quantity:  5,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CheckoutTx(context.Background(), CheckoutTxParams{
+		CreateOrderParams: CreateOrderParams{
+			UserID:   user.ID,
+			Subtotal: "100",
+			Shipping: "5",
+			Tax:      "10",
+			Total:    "115",
+		},
+		Items: []CreateOrderItemParams{
+			{
+				ProductID: product.ID,
+				Name:      product.Name,
+				Price:     product.Price,
+				Quantity:  5,
+			},
+		},
+	})
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, int32(3), stockErr.Available)
+
+	gotProduct, err := store.GetProduct(context.Background(), product.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), gotProduct.StockQuantity)
+
+	count, err := store.CountOrdersByUser(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	cartProducts, err := store.GetCartProductsByUserID(context.Background(), user.ID)
+	require.NoError(t, err)
+	require.Len(t, cartProducts, 1)
+}
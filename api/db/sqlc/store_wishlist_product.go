@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+)
+
+// MoveWishlistProductToCartTxParams contains the input parameters of moving a wishlist product to the cart
+type MoveWishlistProductToCartTxParams = AddCartProductTxParams
+
+// MoveWishlistProductToCartTx adds a wishlist product to the cart, merging the quantity
+// the same way as AddCartProductTx, and removes it from the wishlist in a single transaction.
+// It fails with sql.ErrNoRows if the product is not in the wishlist.
+func (store *SQLStore) MoveWishlistProductToCartTx(ctx context.Context, arg MoveWishlistProductToCartTxParams) (CartProduct, error) {
+	var cartProduct CartProduct
+
+	err := store.ExecTx(ctx, func(q *Queries) error {
+		_, err := q.GetWishlistProductByUserIDAndProductID(ctx, GetWishlistProductByUserIDAndProductIDParams{
+			UserID:    arg.UserID,
+			ProductID: arg.ProductID,
+		})
+		if err != nil {
+			return err
+		}
+
+		cartProduct, err = addCartProduct(ctx, q, arg)
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteWishlistProduct(ctx, DeleteWishlistProductParams{
+			UserID:    arg.UserID,
+			ProductID: arg.ProductID,
+		})
+	})
+
+	return cartProduct, err
+}
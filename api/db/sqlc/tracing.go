@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/ot07/next-bazaar/tracing"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// queryNamePrefix starts the comment sqlc puts in front of every query, e.g. `-- name: GetProduct :one`.
+const queryNamePrefix = "-- name: "
+
+// tracingDBTX records a span for every query, named after the sqlc query.
+// Queries are only traced as part of a trace, e.g. of an HTTP request, or if a global tracer provider is set.
+type tracingDBTX struct {
+	db DBTX
+}
+
+func newTracingDBTX(db DBTX) DBTX {
+	return tracingDBTX{db: db}
+}
+
+func (t tracingDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	result, err := t.db.ExecContext(ctx, query, args...)
+	tracing.RecordError(span, err)
+	return result, err
+}
+
+func (t tracingDBTX) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	tracing.RecordError(span, err)
+	return stmt, err
+}
+
+func (t tracingDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	tracing.RecordError(span, err)
+	return rows, err
+}
+
+func (t tracingDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	defer span.End()
+
+	row := t.db.QueryRowContext(ctx, query, args...)
+	// No rows is a regular result of a lookup, not a failure of the query
+	if err := row.Err(); err != sql.ErrNoRows {
+		tracing.RecordError(span, err)
+	}
+	return row
+}
+
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	name := queryName(query)
+	return tracing.Start(ctx, "db."+name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperation(name),
+			semconv.DBStatement(query),
+		),
+	)
+}
+
+// queryName returns the name sqlc has given the query, or "query" for queries not generated by sqlc.
+func queryName(query string) string {
+	if !strings.HasPrefix(query, queryNamePrefix) {
+		return "query"
+	}
+
+	name := strings.TrimPrefix(query, queryNamePrefix)
+	if end := strings.IndexAny(name, " \n"); end >= 0 {
+		name = name[:end]
+	}
+	return name
+}
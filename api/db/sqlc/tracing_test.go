@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/ot07/next-bazaar/tracing"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+)
+
+// execDBTX is a DBTX that only supports ExecContext, failing with err.
+type execDBTX struct {
+	DBTX
+	err error
+}
+
+func (db execDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, db.err
+}
+
+func TestQueryName(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "Sqlc",
+			query: deleteCartProduct,
+			want:  "DeleteCartProduct",
+		},
+		{
+			name:  "SqlcWithoutStatement",
+			query: "-- name: GetProduct :one",
+			want:  "GetProduct",
+		},
+		{
+			name:  "Raw",
+			query: "SELECT 1",
+			want:  "query",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.want, queryName(tc.query))
+		})
+	}
+}
+
+func TestTracingDBTX(t *testing.T) {
+	queryErr := errors.New("query failed")
+
+	testCases := []struct {
+		name  string
+		err   error
+		check func(t *testing.T, span sdktrace.ReadOnlySpan)
+	}{
+		{
+			name: "OK",
+			check: func(t *testing.T, span sdktrace.ReadOnlySpan) {
+				require.Equal(t, codes.Unset, span.Status().Code)
+			},
+		},
+		{
+			name: "Error",
+			err:  queryErr,
+			check: func(t *testing.T, span sdktrace.ReadOnlySpan) {
+				require.Equal(t, codes.Error, span.Status().Code)
+				require.Equal(t, queryErr.Error(), span.Status().Description)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			ctx, parent := provider.Tracer(tracing.InstrumentationName).Start(context.Background(), "parent")
+
+			_, err := newTracingDBTX(execDBTX{err: tc.err}).ExecContext(ctx, deleteCartProduct)
+			require.ErrorIs(t, err, tc.err)
+			parent.End()
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 2)
+
+			span := spans[0]
+			require.Equal(t, "db.DeleteCartProduct", span.Name())
+			require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+			require.Contains(t, span.Attributes(), semconv.DBOperation("DeleteCartProduct"))
+			require.Contains(t, span.Attributes(), semconv.DBStatement(deleteCartProduct))
+			tc.check(t, span)
+		})
+	}
+}
@@ -12,6 +12,17 @@ import (
 	"github.com/lib/pq"
 )
 
+const countUsers = `-- name: CountUsers :one
+SELECT count(*) FROM users
+`
+
+func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsers)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
   name,
@@ -19,7 +30,7 @@ INSERT INTO users (
   hashed_password
 ) VALUES (
   $1, $2, $3
-) RETURNING id, name, email, hashed_password, password_changed_at, created_at
+) RETURNING id, name, email, hashed_password, password_changed_at, created_at, role
 `
 
 type CreateUserParams struct {
@@ -38,12 +49,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, name, email, hashed_password, password_changed_at, created_at FROM users
+SELECT id, name, email, hashed_password, password_changed_at, created_at, role FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -57,12 +69,13 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, hashed_password, password_changed_at, created_at FROM users
+SELECT id, name, email, hashed_password, password_changed_at, created_at, role FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -76,12 +89,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
 
 const getUsersByIDs = `-- name: GetUsersByIDs :many
-SELECT id, name, email, hashed_password, password_changed_at, created_at FROM users
+SELECT id, name, email, hashed_password, password_changed_at, created_at, role FROM users
 WHERE id = ANY(($1)::uuid[])
 ORDER BY id
 `
@@ -102,6 +116,50 @@ func (q *Queries) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, e
 			&i.HashedPassword,
 			&i.PasswordChangedAt,
 			&i.CreatedAt,
+			&i.Role,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUsers = `-- name: ListUsers :many
+SELECT id, name, email, hashed_password, password_changed_at, created_at, role FROM users
+ORDER BY created_at
+LIMIT $1
+OFFSET $2
+`
+
+type ListUsersParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsers, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Email,
+			&i.HashedPassword,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -132,7 +190,7 @@ SET
   email = $3,
   hashed_password = $4
 WHERE id = $1
-RETURNING id, name, email, hashed_password, password_changed_at, created_at
+RETURNING id, name, email, hashed_password, password_changed_at, created_at, role
 `
 
 type UpdateUserParams struct {
@@ -157,6 +215,35 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.HashedPassword,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+	)
+	return i, err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :one
+UPDATE users
+SET
+  role = $2
+WHERE id = $1
+RETURNING id, name, email, hashed_password, password_changed_at, created_at, role
+`
+
+type UpdateUserRoleParams struct {
+	ID   uuid.UUID `json:"id"`
+	Role UserRole  `json:"role"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRole, arg.ID, arg.Role)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.HashedPassword,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
 	)
 	return i, err
 }
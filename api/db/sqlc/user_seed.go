@@ -18,7 +18,16 @@ func CreateUserTestData(ctx context.Context, store *SQLStore, config util.Config
 			HashedPassword: hashedPassword,
 		}
 
-		_, err = store.CreateUser(ctx, arg)
+		user, err := store.CreateUser(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		// The test accounts sell the test products
+		_, err = store.UpdateUserRole(ctx, UpdateUserRoleParams{
+			ID:   user.ID,
+			Role: UserRoleSeller,
+		})
 		if err != nil {
 			return err
 		}
@@ -27,6 +27,7 @@ func createRandomUser(t *testing.T, testQueries *Queries) User {
 	require.Equal(t, arg.Name, user.Name)
 	require.Equal(t, arg.Email, user.Email)
 	require.Equal(t, arg.HashedPassword, user.HashedPassword)
+	require.Equal(t, UserRoleCustomer, user.Role)
 
 	require.NotEmpty(t, user.ID)
 	require.True(t, user.PasswordChangedAt.IsZero())
@@ -87,3 +88,47 @@ func TestGetUserByEmail(t *testing.T) {
 	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 }
+
+func TestUpdateUserRole(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	user1 := createRandomUser(t, testQueries)
+
+	user2, err := testQueries.UpdateUserRole(context.Background(), UpdateUserRoleParams{
+		ID:   user1.ID,
+		Role: UserRoleAdmin,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, user1.ID, user2.ID)
+	require.Equal(t, UserRoleAdmin, user2.Role)
+}
+
+func TestListUsers(t *testing.T) {
+	t.Parallel()
+
+	db := test_util.OpenTestDB(t)
+	defer db.Close()
+
+	testQueries := New(db)
+
+	for i := 0; i < 3; i++ {
+		createRandomUser(t, testQueries)
+	}
+
+	users, err := testQueries.ListUsers(context.Background(), ListUsersParams{
+		Limit:  2,
+		Offset: 0,
+	})
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	count, err := testQueries.CountUsers(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
@@ -0,0 +1,105 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.22.0
+// source: wishlist_product.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createWishlistProduct = `-- name: CreateWishlistProduct :one
+INSERT INTO wishlist_products (
+  user_id,
+  product_id
+) VALUES (
+  $1, $2
+) RETURNING user_id, product_id, created_at
+`
+
+type CreateWishlistProductParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) CreateWishlistProduct(ctx context.Context, arg CreateWishlistProductParams) (WishlistProduct, error) {
+	row := q.db.QueryRowContext(ctx, createWishlistProduct, arg.UserID, arg.ProductID)
+	var i WishlistProduct
+	err := row.Scan(&i.UserID, &i.ProductID, &i.CreatedAt)
+	return i, err
+}
+
+const deleteWishlistProduct = `-- name: DeleteWishlistProduct :exec
+DELETE FROM wishlist_products
+WHERE user_id = $1 AND product_id = $2
+`
+
+type DeleteWishlistProductParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) DeleteWishlistProduct(ctx context.Context, arg DeleteWishlistProductParams) error {
+	_, err := q.db.ExecContext(ctx, deleteWishlistProduct, arg.UserID, arg.ProductID)
+	return err
+}
+
+const getWishlistProductByUserIDAndProductID = `-- name: GetWishlistProductByUserIDAndProductID :one
+SELECT user_id, product_id, created_at FROM wishlist_products
+WHERE user_id = $1 AND product_id = $2
+`
+
+type GetWishlistProductByUserIDAndProductIDParams struct {
+	UserID    uuid.UUID `json:"user_id"`
+	ProductID uuid.UUID `json:"product_id"`
+}
+
+func (q *Queries) GetWishlistProductByUserIDAndProductID(ctx context.Context, arg GetWishlistProductByUserIDAndProductIDParams) (WishlistProduct, error) {
+	row := q.db.QueryRowContext(ctx, getWishlistProductByUserIDAndProductID, arg.UserID, arg.ProductID)
+	var i WishlistProduct
+	err := row.Scan(&i.UserID, &i.ProductID, &i.CreatedAt)
+	return i, err
+}
+
+const getWishlistProductsByUserID = `-- name: GetWishlistProductsByUserID :many
+SELECT user_id, product_id, created_at FROM wishlist_products
+WHERE user_id = $1 AND product_id IN (
+  SELECT id FROM products WHERE deleted_at IS NULL
+)
+ORDER BY created_at DESC
+`
+
+func (q *Queries) GetWishlistProductsByUserID(ctx context.Context, userID uuid.UUID) ([]WishlistProduct, error) {
+	rows, err := q.db.QueryContext(ctx, getWishlistProductsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WishlistProduct{}
+	for rows.Next() {
+		var i WishlistProduct
+		if err := rows.Scan(&i.UserID, &i.ProductID, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const truncateWishlistProductsTable = `-- name: TruncateWishlistProductsTable :exec
+TRUNCATE TABLE wishlist_products CASCADE
+`
+
+func (q *Queries) TruncateWishlistProductsTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, truncateWishlistProductsTable)
+	return err
+}
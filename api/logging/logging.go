@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slog"
+)
+
+type contextKey struct{}
+
+// New creates a logger that writes JSON records of the given level and above to w.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// ParseLevel parses a level name such as "debug", "info", "warn" or "error", case-insensitively.
+// An empty name is the info level.
+func ParseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if len(strings.TrimSpace(name)) == 0 {
+		return slog.LevelInfo, nil
+	}
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return 0, fmt.Errorf("invalid log level %q", name)
+	}
+	return level, nil
+}
+
+// NewContext returns a copy of ctx that carries the logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger if there is none.
+// Loggers of HTTP requests carry the request id, so records of services can be correlated with the request.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
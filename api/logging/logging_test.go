@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slog"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := []struct {
+		name      string
+		levelName string
+		level     slog.Level
+		wantErr   bool
+	}{
+		{name: "Debug", levelName: "debug", level: slog.LevelDebug},
+		{name: "Info", levelName: "info", level: slog.LevelInfo},
+		{name: "Warn", levelName: "WARN", level: slog.LevelWarn},
+		{name: "Error", levelName: "error", level: slog.LevelError},
+		{name: "Empty", levelName: "", level: slog.LevelInfo},
+		{name: "Unknown", levelName: "verbose", wantErr: true},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			level, err := ParseLevel(tc.levelName)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.level, level)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelWarn)
+
+	logger.Info("skipped")
+	logger.Warn("written", "count", 3)
+
+	var record map[string]any
+	err := json.Unmarshal(buf.Bytes(), &record)
+	require.NoError(t, err)
+
+	require.Equal(t, "WARN", record["level"])
+	require.Equal(t, "written", record["msg"])
+	require.Equal(t, float64(3), record["count"])
+}
+
+func TestFromContext(t *testing.T) {
+	require.Equal(t, slog.Default(), FromContext(context.Background()))
+
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	ctx := NewContext(context.Background(), logger)
+	require.Equal(t, logger, FromContext(ctx))
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/ot07/next-bazaar/api"
 	db "github.com/ot07/next-bazaar/db/sqlc"
+	"github.com/ot07/next-bazaar/logging"
+	"github.com/ot07/next-bazaar/scheduler"
+	"github.com/ot07/next-bazaar/tracing"
 	"github.com/ot07/next-bazaar/util"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/exp/slog"
 
 	_ "github.com/lib/pq"
 	_ "github.com/ot07/next-bazaar/docs"
@@ -21,6 +30,32 @@ func main() {
 		log.Fatal("cannot load config:", err)
 	}
 
+	logLevel, err := logging.ParseLevel(config.LogLevel)
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+
+	// Also writes the output of the log package as JSON
+	slog.SetDefault(logging.New(os.Stdout, logLevel))
+
+	exporter, err := tracing.NewExporter(config.Tracing.Exporter, config.Tracing.OTLPEndpoint, os.Stdout)
+	if err != nil {
+		log.Fatal("cannot load config:", err)
+	}
+	if exporter != nil {
+		tracerProvider := tracing.NewTracerProvider(exporter, config.Tracing.SampleRatio)
+		defer func() {
+			// Flushes the spans that have not been exported yet
+			if err := tracerProvider.Shutdown(context.Background()); err != nil {
+				log.Println("cannot shut down tracer provider:", err)
+			}
+		}()
+
+		// Must be set before the server is created, which takes the global provider and propagator
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(tracing.NewPropagator())
+	}
+
 	conn, err := sql.Open(config.DBDriver, config.DBSource)
 	if err != nil {
 		log.Fatal("cannot connect to db:", err)
@@ -32,8 +67,32 @@ func main() {
 		log.Fatal("cannot create server:", err)
 	}
 
+	err = server.Metrics().RegisterDBStats(conn, "main")
+	if err != nil {
+		log.Fatal("cannot register db metrics:", err)
+	}
+
+	cleanupScheduler := scheduler.NewScheduler(scheduler.RealClock{})
+	cleanupScheduler.Add(scheduler.NewExpiredSessionsCleanupJob(store, config.Cleanup.SessionInterval))
+	cleanupScheduler.Add(scheduler.NewStaleCartsCleanupJob(store, config.Cleanup.CartInterval, config.Cleanup.CartRetention))
+	cleanupScheduler.Add(scheduler.NewStaleLoginFailuresCleanupJob(store, config.Cleanup.LoginFailureInterval, config.LoginLockout.FailureWindow))
+	cleanupScheduler.Start()
+
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+
+		log.Println("shutting down server...")
+		if err := server.Shutdown(); err != nil {
+			log.Println("cannot shut down server:", err)
+		}
+	}()
+
 	err = server.Start(config.ServerAddress)
 	if err != nil {
 		log.Fatal("cannot start to server:", err)
 	}
+
+	cleanupScheduler.Stop()
 }
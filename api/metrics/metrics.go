@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "bazaar"
+
+// Results of logins, as counted by LoginAttempted.
+const (
+	LoginResultSuccess = "success"
+	LoginResultFailure = "failure"
+	LoginResultLocked  = "locked"
+)
+
+// Metrics holds the collectors of the app in a registry of its own,
+// so that several servers, e.g. in tests, do not share their metrics.
+type Metrics struct {
+	registry            *prometheus.Registry
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	registrations       prometheus.Counter
+	logins              *prometheus.CounterVec
+	cartProductsAdded   prometheus.Counter
+	productsAdded       prometheus.Counter
+}
+
+// New creates the collectors of the app, along with the collectors of the Go runtime and the process.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of handled HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of handled HTTP requests by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_registrations_total",
+			Help:      "Number of registered users.",
+		}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "user_logins_total",
+			Help:      "Number of logins by result.",
+		}, []string{"result"}),
+		cartProductsAdded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cart_products_added_total",
+			Help:      "Number of products added to carts.",
+		}),
+		productsAdded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "products_added_total",
+			Help:      "Number of products put up for sale.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpRequestDuration,
+		m.registrations,
+		m.logins,
+		m.cartProductsAdded,
+		m.productsAdded,
+	)
+
+	return m
+}
+
+// RegisterDBStats exposes the connection pool stats of the database.
+func (m *Metrics) RegisterDBStats(db *sql.DB, dbName string) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// Handler returns the handler serving the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest counts a handled HTTP request. The route must be the route template, not the raw path,
+// to keep the number of series bounded.
+func (m *Metrics) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.httpRequestDuration.WithLabelValues(method, route).Observe(duration.Seconds())
+}
+
+// UserRegistered counts a registered user.
+func (m *Metrics) UserRegistered() {
+	m.registrations.Inc()
+}
+
+// LoginAttempted counts a login with one of the LoginResult* results.
+func (m *Metrics) LoginAttempted(result string) {
+	m.logins.WithLabelValues(result).Inc()
+}
+
+// CartProductAdded counts a product added to a cart.
+func (m *Metrics) CartProductAdded() {
+	m.cartProductsAdded.Inc()
+}
+
+// ProductAdded counts a product put up for sale.
+func (m *Metrics) ProductAdded() {
+	m.productsAdded.Inc()
+}
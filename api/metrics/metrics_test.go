@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"database/sql"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestObserveRequest(t *testing.T) {
+	m := New()
+
+	m.ObserveRequest(http.MethodGet, "/products/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "/products/:id", http.StatusNotFound, 2*time.Second)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `http_requests_total{method="GET",route="/products/:id",status="200"} 1`)
+	require.Contains(t, body, `http_requests_total{method="GET",route="/products/:id",status="404"} 1`)
+	require.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/products/:id",le="0.025"} 1`)
+	require.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/products/:id",le="2.5"} 2`)
+	require.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/products/:id"} 2`)
+}
+
+func TestBusinessCounters(t *testing.T) {
+	m := New()
+
+	m.UserRegistered()
+	m.LoginAttempted(LoginResultSuccess)
+	m.LoginAttempted(LoginResultFailure)
+	m.LoginAttempted(LoginResultFailure)
+	m.LoginAttempted(LoginResultLocked)
+	m.CartProductAdded()
+	m.ProductAdded()
+	m.ProductAdded()
+
+	body := scrape(t, m)
+	require.Contains(t, body, "bazaar_user_registrations_total 1")
+	require.Contains(t, body, `bazaar_user_logins_total{result="success"} 1`)
+	require.Contains(t, body, `bazaar_user_logins_total{result="failure"} 2`)
+	require.Contains(t, body, `bazaar_user_logins_total{result="locked"} 1`)
+	require.Contains(t, body, "bazaar_cart_products_added_total 1")
+	require.Contains(t, body, "bazaar_products_added_total 2")
+}
+
+func TestRegisterDBStats(t *testing.T) {
+	m := New()
+
+	// Opening does not connect, so the stats are available without a database
+	conn, err := sql.Open("postgres", "postgresql://localhost:5432/next_bazaar?sslmode=disable")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	err = m.RegisterDBStats(conn, "main")
+	require.NoError(t, err)
+
+	body := scrape(t, m)
+	require.Contains(t, body, `go_sql_open_connections{db_name="main"} 0`)
+	require.Contains(t, body, `go_sql_max_open_connections{db_name="main"} 0`)
+
+	// The same database cannot be registered twice
+	err = m.RegisterDBStats(conn, "main")
+	require.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often buckets that have been refilled completely are removed from a MemoryStore
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+// refill adds the tokens that have been refilled since the last update
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updatedAt)
+	if elapsed <= 0 {
+		return
+	}
+
+	b.tokens = math.Min(float64(b.limit.Requests), b.tokens+elapsed.Seconds()*b.rate())
+	b.updatedAt = now
+}
+
+// rate returns the number of tokens refilled per second
+func (b *bucket) rate() float64 {
+	return float64(b.limit.Requests) / b.limit.Period.Seconds()
+}
+
+// timeUntil returns the time until the bucket holds the given number of tokens
+func (b *bucket) timeUntil(tokens float64) time.Duration {
+	if b.tokens >= tokens {
+		return 0
+	}
+	return time.Duration(math.Ceil((tokens - b.tokens) / b.rate() * float64(time.Second)))
+}
+
+// MemoryStore is a Store that keeps the buckets in memory.
+// It is only suitable for a single server instance.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweptAt time.Time
+}
+
+// NewMemoryStore creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	b, ok := s.buckets[key]
+	if !ok || b.limit != limit {
+		b = &bucket{
+			tokens:    float64(limit.Requests),
+			updatedAt: now,
+			limit:     limit,
+		}
+		s.buckets[key] = b
+	}
+
+	b.refill(now)
+
+	result := Result{
+		Limit: limit.Requests,
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = b.timeUntil(1)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = b.timeUntil(float64(limit.Requests))
+
+	return result, nil
+}
+
+// sweep removes the buckets that would be full by now, since they are equal to new buckets
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.sweptAt) < sweepInterval {
+		return
+	}
+	s.sweptAt = now
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updatedAt) >= b.timeUntil(float64(b.limit.Requests)) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreTake(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 3, Period: time.Minute}
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 2; i >= 0; i-- {
+		result, err := store.Take(context.Background(), "key", limit, now)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 3, result.Limit)
+		require.Equal(t, i, result.Remaining)
+		require.Zero(t, result.RetryAfter)
+	}
+
+	result, err := store.Take(context.Background(), "key", limit, now)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, 20*time.Second, result.RetryAfter)
+	require.Equal(t, time.Minute, result.ResetAfter)
+
+	// A token is refilled every 20 seconds
+	result, err = store.Take(context.Background(), "key", limit, now.Add(20*time.Second))
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+	require.Equal(t, time.Minute, result.ResetAfter)
+
+	result, err = store.Take(context.Background(), "key", limit, now.Add(30*time.Second))
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, 10*time.Second, result.RetryAfter)
+}
+
+func TestMemoryStoreRefillIsCapped(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 2, Period: time.Minute}
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.Take(context.Background(), "key", limit, now)
+	require.NoError(t, err)
+
+	result, err := store.Take(context.Background(), "key", limit, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 1, result.Remaining)
+	require.Equal(t, 30*time.Second, result.ResetAfter)
+}
+
+func TestMemoryStoreKeysAreIndependent(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := store.Take(context.Background(), "key1", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	result, err = store.Take(context.Background(), "key1", limit, now)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+
+	result, err = store.Take(context.Background(), "key2", limit, now)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+}
+
+func TestMemoryStoreSweep(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.Take(context.Background(), "refilled", Limit{Requests: 1, Period: sweepInterval / 2}, now)
+	require.NoError(t, err)
+	_, err = store.Take(context.Background(), "empty", Limit{Requests: 1, Period: time.Hour}, now)
+	require.NoError(t, err)
+
+	_, err = store.Take(context.Background(), "other", Limit{Requests: 1, Period: time.Hour}, now.Add(sweepInterval))
+	require.NoError(t, err)
+
+	require.NotContains(t, store.buckets, "refilled")
+	require.Contains(t, store.buckets, "empty")
+	require.Contains(t, store.buckets, "other")
+}
+
+func TestMemoryStoreConcurrentTake(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 50, Period: time.Hour}
+	now := time.Now()
+
+	var mu sync.Mutex
+	allowed := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result, err := store.Take(context.Background(), "key", limit, now)
+			require.NoError(t, err)
+
+			if result.Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 50, allowed)
+}
+
+func TestLimitEnabled(t *testing.T) {
+	require.True(t, Limit{Requests: 1, Period: time.Second}.Enabled())
+	require.False(t, Limit{Requests: 0, Period: time.Second}.Enabled())
+	require.False(t, Limit{Requests: 1}.Enabled())
+}
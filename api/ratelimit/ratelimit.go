@@ -0,0 +1,40 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit is the budget of a token bucket.
+// The bucket holds up to Requests tokens and is refilled completely within Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Enabled reports whether the limit restricts requests at all.
+// A non-positive number of requests or period disables the limit.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Result describes the state of a bucket after a request has been counted.
+type Result struct {
+	// Allowed reports whether a token was available for the request
+	Allowed bool
+	// Limit is the size of the bucket
+	Limit int
+	// Remaining is the number of whole tokens left in the bucket
+	Remaining int
+	// ResetAfter is the time until the bucket is full again
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next token is available. It is zero if the request was allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the token buckets of the rate limiter.
+// Implementations must take tokens atomically, since requests are counted concurrently.
+type Store interface {
+	// Take removes a token from the bucket of the key, creating a full bucket if there is none.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+
+	db "github.com/ot07/next-bazaar/db/sqlc"
+)
+
+// NewExpiredSessionsCleanupJob creates a job that deletes sessions whose refresh token has expired,
+// along with the rotated refresh tokens that are no longer needed for reuse detection.
+func NewExpiredSessionsCleanupJob(store db.Store, interval time.Duration) Job {
+	return Job{
+		Name:     "expired sessions cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context, now time.Time) error {
+			count, err := store.DeleteExpiredSessions(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("cleanup: removed %d expired sessions", count)
+
+			count, err = store.DeleteExpiredRotatedRefreshTokens(ctx, now)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("cleanup: removed %d expired rotated refresh tokens", count)
+			return nil
+		},
+	}
+}
+
+// NewStaleCartsCleanupJob creates a job that empties carts that have not been
+// added to or changed for longer than the retention.
+func NewStaleCartsCleanupJob(store db.Store, interval time.Duration, retention time.Duration) Job {
+	return Job{
+		Name:     "stale carts cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context, now time.Time) error {
+			count, err := store.DeleteStaleCartProducts(ctx, now.Add(-retention))
+			if err != nil {
+				return err
+			}
+
+			log.Printf("cleanup: removed %d products from stale carts", count)
+			return nil
+		},
+	}
+}
+
+// NewStaleLoginFailuresCleanupJob creates a job that deletes failed login counters that are no longer locked
+// and have not grown within the failure window, since they would be reset by the next failure anyway.
+func NewStaleLoginFailuresCleanupJob(store db.Store, interval time.Duration, failureWindow time.Duration) Job {
+	return Job{
+		Name:     "stale login failures cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context, now time.Time) error {
+			count, err := store.DeleteStaleLoginFailures(ctx, now.Add(-failureWindow))
+			if err != nil {
+				return err
+			}
+
+			log.Printf("cleanup: removed %d stale login failure counters", count)
+			return nil
+		},
+	}
+}
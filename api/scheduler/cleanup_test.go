@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mockdb "github.com/ot07/next-bazaar/db/mock"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestExpiredSessionsCleanupJob(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteExpiredSessions(gomock.Any(), gomock.Eq(now)).
+					Times(1).
+					Return(int64(3), nil)
+
+				store.EXPECT().
+					DeleteExpiredRotatedRefreshTokens(gomock.Any(), gomock.Eq(now)).
+					Times(1).
+					Return(int64(2), nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteExpiredSessions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+		{
+			name: "RotatedRefreshTokensInternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteExpiredSessions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+
+				store.EXPECT().
+					DeleteExpiredRotatedRefreshTokens(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			job := NewExpiredSessionsCleanupJob(store, time.Hour)
+			require.Equal(t, time.Hour, job.Interval)
+
+			err := job.Run(context.Background(), now)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestStaleCartsCleanupJob(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteStaleCartProducts(gomock.Any(), gomock.Eq(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))).
+					Times(1).
+					Return(int64(5), nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteStaleCartProducts(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			job := NewStaleCartsCleanupJob(store, time.Hour, retention)
+
+			err := job.Run(context.Background(), now)
+			tc.checkError(t, err)
+		})
+	}
+}
+
+func TestStaleLoginFailuresCleanupJob(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC)
+	failureWindow := 15 * time.Minute
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		checkError func(t *testing.T, err error)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteStaleLoginFailures(gomock.Any(), gomock.Eq(time.Date(2023, 1, 1, 0, 45, 0, 0, time.UTC))).
+					Times(1).
+					Return(int64(4), nil)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteStaleLoginFailures(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkError: func(t *testing.T, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			job := NewStaleLoginFailuresCleanupJob(store, time.Hour, failureWindow)
+
+			err := job.Run(context.Background(), now)
+			tc.checkError(t, err)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a task that is run periodically by the scheduler.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context, now time.Time) error
+}
+
+// Scheduler runs jobs in the background at their intervals.
+// Each job is run once when the scheduler starts and then on every tick.
+type Scheduler struct {
+	clock  Clock
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(clock Clock) *Scheduler {
+	return &Scheduler{
+		clock: clock,
+	}
+}
+
+// Add registers a job. Jobs with a non-positive interval are disabled.
+// It must be called before Start.
+func (s *Scheduler) Add(job Job) {
+	if job.Interval <= 0 {
+		log.Printf("scheduler: job %q is disabled", job.Name)
+		return
+	}
+	s.jobs = append(s.jobs, job)
+}
+
+// Start runs every registered job in its own goroutine until Stop is called.
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		ticker := s.clock.NewTicker(job.Interval)
+
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			defer ticker.Stop()
+
+			s.run(ctx, job)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C():
+					s.run(ctx, job)
+				}
+			}
+		}(job)
+	}
+}
+
+// Stop stops the scheduler and waits for running jobs to finish.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	err := job.Run(ctx, s.clock.Now())
+	if err != nil && ctx.Err() == nil {
+		log.Printf("scheduler: job %q failed: %v", job.Name, err)
+	}
+}
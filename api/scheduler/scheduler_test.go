@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a Clock whose time only moves when Advance is called.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ticker := &fakeTicker{
+		clock:  c,
+		c:      make(chan time.Time, 1),
+		period: d,
+		next:   c.now.Add(d),
+	}
+	c.tickers = append(c.tickers, ticker)
+	return ticker
+}
+
+// Advance moves the clock forward and fires the tickers that are due.
+// Like time.Ticker, ticks are dropped when the receiver falls behind.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, ticker := range c.tickers {
+		for !ticker.stopped && !ticker.next.After(c.now) {
+			select {
+			case ticker.c <- ticker.next:
+			default:
+			}
+			ticker.next = ticker.next.Add(ticker.period)
+		}
+	}
+}
+
+type fakeTicker struct {
+	clock   *fakeClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+func newRecordingJob(interval time.Duration, err error) (Job, <-chan time.Time) {
+	runs := make(chan time.Time, 10)
+
+	job := Job{
+		Name:     "test job",
+		Interval: interval,
+		Run: func(ctx context.Context, now time.Time) error {
+			runs <- now
+			return err
+		},
+	}
+
+	return job, runs
+}
+
+func requireRun(t *testing.T, runs <-chan time.Time, expected time.Time) {
+	select {
+	case got := <-runs:
+		require.Equal(t, expected, got)
+	case <-time.After(time.Second):
+		t.Fatal("job was not run")
+	}
+}
+
+func requireNoRun(t *testing.T, runs <-chan time.Time) {
+	select {
+	case got := <-runs:
+		t.Fatalf("job was run unexpectedly at %v", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+
+	job, runs := newRecordingJob(time.Minute, nil)
+
+	scheduler := NewScheduler(clock)
+	scheduler.Add(job)
+	scheduler.Start()
+
+	requireRun(t, runs, start)
+
+	clock.Advance(30 * time.Second)
+	requireNoRun(t, runs)
+
+	clock.Advance(30 * time.Second)
+	requireRun(t, runs, start.Add(time.Minute))
+
+	clock.Advance(time.Minute)
+	requireRun(t, runs, start.Add(2*time.Minute))
+
+	scheduler.Stop()
+
+	clock.Advance(time.Minute)
+	requireNoRun(t, runs)
+}
+
+func TestSchedulerContinuesAfterError(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+
+	job, runs := newRecordingJob(time.Minute, errors.New("test error"))
+
+	scheduler := NewScheduler(clock)
+	scheduler.Add(job)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	requireRun(t, runs, start)
+
+	clock.Advance(time.Minute)
+	requireRun(t, runs, start.Add(time.Minute))
+}
+
+func TestSchedulerDisabledJob(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	job, runs := newRecordingJob(0, nil)
+
+	scheduler := NewScheduler(clock)
+	scheduler.Add(job)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	clock.Advance(time.Hour)
+	requireNoRun(t, runs)
+}
+
+func TestSchedulerStopWithoutStart(t *testing.T) {
+	t.Parallel()
+
+	scheduler := NewScheduler(newFakeClock(time.Now()))
+	scheduler.Stop()
+}
@@ -0,0 +1,111 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// minSecretKeySize is the minimum length of the key used to sign access tokens
+const minSecretKeySize = 32
+
+var ErrInvalidKeySize = fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
+
+// jwtClaims is the JSON representation of Payload with the registered claims
+type jwtClaims struct {
+	SessionID uuid.UUID `json:"sid"`
+	Role      string    `json:"role"`
+	jwt.RegisteredClaims
+}
+
+// JWTMaker issues and verifies access tokens as JSON Web Tokens signed with HMAC-SHA256
+type JWTMaker struct {
+	secretKey []byte
+	parser    *jwt.Parser
+}
+
+var _ Maker = (*JWTMaker)(nil)
+
+// NewJWTMaker creates a new JWTMaker
+func NewJWTMaker(secretKey string) (*JWTMaker, error) {
+	if len(secretKey) < minSecretKeySize {
+		return nil, ErrInvalidKeySize
+	}
+
+	// Only HS256 is accepted, which rules out the "none" algorithm and algorithm confusion attacks
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+
+	return &JWTMaker{secretKey: []byte(secretKey), parser: parser}, nil
+}
+
+// CreateToken creates a new signed access token for the session with a specific duration
+func (maker *JWTMaker) CreateToken(params PayloadParams, duration time.Duration) (*Token, *Payload, error) {
+	payload := NewPayload(params, duration)
+
+	claims := jwtClaims{
+		SessionID: payload.SessionID,
+		Role:      payload.Role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        payload.ID.String(),
+			Subject:   payload.UserID.String(),
+			IssuedAt:  jwt.NewNumericDate(payload.IssuedAt),
+			ExpiresAt: jwt.NewNumericDate(payload.ExpiredAt),
+		},
+	}
+
+	value, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(maker.secretKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	token := &Token{
+		Value:     value,
+		ExpiredAt: payload.ExpiredAt,
+	}
+	return token, payload, nil
+}
+
+// VerifyToken checks if the access token is valid and returns its payload.
+// ErrExpiredToken is returned if the token is authentic but has expired.
+func (maker *JWTMaker) VerifyToken(value string) (*Payload, error) {
+	var claims jwtClaims
+
+	_, err := maker.parser.ParseWithClaims(value, &claims, func(*jwt.Token) (interface{}, error) {
+		return maker.secretKey, nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	id, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	payload := &Payload{
+		ID:        id,
+		SessionID: claims.SessionID,
+		UserID:    userID,
+		Role:      claims.Role,
+		ExpiredAt: claims.ExpiresAt.Time,
+	}
+	if claims.IssuedAt != nil {
+		payload.IssuedAt = claims.IssuedAt.Time
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,120 @@
+package token
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecretKey = "01234567890123456789012345678901"
+
+func newTestPayloadParams() PayloadParams {
+	return PayloadParams{
+		SessionID: uuid.New(),
+		UserID:    uuid.New(),
+		Role:      "admin",
+	}
+}
+
+func TestNewJWTMakerInvalidKeySize(t *testing.T) {
+	maker, err := NewJWTMaker(testSecretKey[:minSecretKeySize-1])
+	require.ErrorIs(t, err, ErrInvalidKeySize)
+	require.Nil(t, maker)
+}
+
+func TestJWTMaker(t *testing.T) {
+	maker, err := NewJWTMaker(testSecretKey)
+	require.NoError(t, err)
+
+	params := newTestPayloadParams()
+	duration := time.Minute
+	expiredAt := time.Now().Add(duration)
+
+	token, payload, err := maker.CreateToken(params, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, token.Value)
+	require.WithinDuration(t, expiredAt, token.ExpiredAt, time.Second)
+	require.Equal(t, payload.ExpiredAt, token.ExpiredAt)
+
+	verified, err := maker.VerifyToken(token.Value)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, params.SessionID, verified.SessionID)
+	require.Equal(t, params.UserID, verified.UserID)
+	require.Equal(t, params.Role, verified.Role)
+	require.WithinDuration(t, payload.IssuedAt, verified.IssuedAt, time.Second)
+	require.WithinDuration(t, expiredAt, verified.ExpiredAt, time.Second)
+}
+
+func TestExpiredJWTToken(t *testing.T) {
+	maker, err := NewJWTMaker(testSecretKey)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(newTestPayloadParams(), -time.Minute)
+	require.NoError(t, err)
+
+	payload, err := maker.VerifyToken(token.Value)
+	require.ErrorIs(t, err, ErrExpiredToken)
+	require.Nil(t, payload)
+}
+
+func TestInvalidJWTToken(t *testing.T) {
+	maker, err := NewJWTMaker(testSecretKey)
+	require.NoError(t, err)
+
+	token, _, err := maker.CreateToken(newTestPayloadParams(), time.Minute)
+	require.NoError(t, err)
+	parts := strings.Split(token.Value, ".")
+
+	otherMaker, err := NewJWTMaker(strings.Repeat("x", minSecretKeySize))
+	require.NoError(t, err)
+	otherToken, _, err := otherMaker.CreateToken(newTestPayloadParams(), time.Minute)
+	require.NoError(t, err)
+
+	noneHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	tamperedClaims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"` + uuid.NewString() + `","role":"admin","exp":9999999999}`))
+
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{
+			name:  "Empty",
+			value: "",
+		},
+		{
+			name:  "OpaqueToken",
+			value: NewToken(time.Minute).Value,
+		},
+		{
+			name:  "SignedWithOtherKey",
+			value: otherToken.Value,
+		},
+		{
+			name:  "TamperedClaims",
+			value: parts[0] + "." + tamperedClaims + "." + parts[2],
+		},
+		{
+			name:  "AlgorithmNone",
+			value: noneHeader + "." + parts[1] + ".",
+		},
+		{
+			name:  "MissingSignature",
+			value: parts[0] + "." + parts[1],
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := maker.VerifyToken(tc.value)
+			require.ErrorIs(t, err, ErrInvalidToken)
+			require.Nil(t, payload)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package token
+
+import "time"
+
+// Maker issues and verifies signed access tokens
+type Maker interface {
+	// CreateToken creates a new signed access token for the session with a specific duration
+	CreateToken(params PayloadParams, duration time.Duration) (*Token, *Payload, error)
+	// VerifyToken checks if the access token is valid and returns its payload
+	VerifyToken(value string) (*Payload, error)
+}
@@ -0,0 +1,47 @@
+package token
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payload contains the claims of a signed access token
+type Payload struct {
+	ID        uuid.UUID
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+	IssuedAt  time.Time
+	ExpiredAt time.Time
+}
+
+// PayloadParams contains the claims given by the caller when issuing an access token
+type PayloadParams struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	Role      string
+}
+
+// NewPayload creates a new token payload with a specific duration
+func NewPayload(params PayloadParams, duration time.Duration) *Payload {
+	now := time.Now()
+
+	payload := &Payload{
+		ID:        uuid.New(),
+		SessionID: params.SessionID,
+		UserID:    params.UserID,
+		Role:      params.Role,
+		IssuedAt:  now,
+		ExpiredAt: now.Add(duration),
+	}
+	return payload
+}
+
+// Valid checks if the token payload is expired or not
+func (payload *Payload) Valid() error {
+	if IsExpired(payload.ExpiredAt) {
+		return ErrExpiredToken
+	}
+	return nil
+}
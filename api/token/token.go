@@ -1,34 +1,92 @@
 package token
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
-
-	"github.com/google/uuid"
-	"github.com/ot07/next-bazaar/util"
 )
 
+// tokenSize is the number of random bytes in a token
+const tokenSize = 32
+
+// APIKeyPrefix marks API keys, so that they can be told apart from session tokens
+const APIKeyPrefix = "nbz_"
+
+// apiKeyDisplayLength is the length of the leading part of an API key that may be shown to identify it
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
 var (
 	ErrExpiredToken = errors.New("token has expired")
+	ErrInvalidToken = errors.New("token is invalid")
 )
 
-// Token contains the token and expired at
+// Token contains the token and expired at.
+// Only the hash of the value should be persisted.
 type Token struct {
-	ID        uuid.UUID `json:"id"`
+	Value     string    `json:"value"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
-// NewToken creates a new token with a specific duration
+// NewToken creates a new random token with a specific duration
 func NewToken(duration time.Duration) *Token {
-	tokenID := util.RandomUUID()
-
 	token := &Token{
-		ID:        tokenID,
+		Value:     randomValue(),
 		ExpiredAt: time.Now().Add(duration),
 	}
 	return token
 }
 
+// NewAPIKey creates a new random API key.
+// It returns the key and its leading part, which may be shown to identify the key.
+func NewAPIKey() (key string, displayPrefix string) {
+	key = APIKeyPrefix + randomValue()
+	return key, key[:apiKeyDisplayLength]
+}
+
+// ParseAPIKey checks the format of the API key and returns its hash.
+// Session tokens never have the format of an API key, since they are shorter.
+func ParseAPIKey(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, APIKeyPrefix) {
+		return nil, ErrInvalidToken
+	}
+	if _, err := ParseToken(strings.TrimPrefix(value, APIKeyPrefix)); err != nil {
+		return nil, err
+	}
+	return HashToken(value), nil
+}
+
+func randomValue() string {
+	b := make([]byte, tokenSize)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("cannot generate random token: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Hash returns the SHA-256 hash of the token value
+func (token *Token) Hash() []byte {
+	return HashToken(token.Value)
+}
+
+// HashToken returns the SHA-256 hash of the token value
+func HashToken(value string) []byte {
+	sum := sha256.Sum256([]byte(value))
+	return sum[:]
+}
+
+// ParseToken checks the format of the token value and returns its hash
+func ParseToken(value string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(b) != tokenSize {
+		return nil, ErrInvalidToken
+	}
+	return HashToken(value), nil
+}
+
 // IsExpired checks if the `expiredAt` is expired or not
 func IsExpired(expiredAt time.Time) bool {
 	return time.Now().After(expiredAt)
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -13,7 +14,7 @@ func TestValidToken(t *testing.T) {
 
 	token := NewToken(duration)
 
-	require.NotZero(t, token.ID)
+	require.NotEmpty(t, token.Value)
 	require.False(t, IsExpired(token.ExpiredAt))
 	require.WithinDuration(t, expiredAt, token.ExpiredAt, time.Second)
 }
@@ -22,6 +23,134 @@ func TestExpiredToken(t *testing.T) {
 	token := NewToken(-time.Minute)
 	require.NotEmpty(t, token)
 
-	require.NotZero(t, token.ID)
+	require.NotEmpty(t, token.Value)
 	require.True(t, IsExpired(token.ExpiredAt))
 }
+
+func TestTokenIsRandom(t *testing.T) {
+	token1 := NewToken(time.Minute)
+	token2 := NewToken(time.Minute)
+
+	require.NotEqual(t, token1.Value, token2.Value)
+	require.NotEqual(t, token1.Hash(), token2.Hash())
+}
+
+func TestTokenHash(t *testing.T) {
+	token := NewToken(time.Minute)
+
+	hash := token.Hash()
+	require.Len(t, hash, 32)
+	require.Equal(t, hash, HashToken(token.Value))
+	require.NotContains(t, string(hash), token.Value)
+}
+
+func TestParseToken(t *testing.T) {
+	token := NewToken(time.Minute)
+
+	testCases := []struct {
+		name  string
+		value string
+		check func(t *testing.T, hash []byte, err error)
+	}{
+		{
+			name:  "OK",
+			value: token.Value,
+			check: func(t *testing.T, hash []byte, err error) {
+				require.NoError(t, err)
+				require.Equal(t, token.Hash(), hash)
+			},
+		},
+		{
+			name:  "Empty",
+			value: "",
+			check: func(t *testing.T, hash []byte, err error) {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				require.Nil(t, hash)
+			},
+		},
+		{
+			name:  "InvalidEncoding",
+			value: "invalid token!",
+			check: func(t *testing.T, hash []byte, err error) {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				require.Nil(t, hash)
+			},
+		},
+		{
+			name:  "TooShort",
+			value: token.Value[:20],
+			check: func(t *testing.T, hash []byte, err error) {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				require.Nil(t, hash)
+			},
+		},
+		{
+			name:  "LegacyUUID",
+			value: "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			check: func(t *testing.T, hash []byte, err error) {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				require.Nil(t, hash)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := ParseToken(tc.value)
+			tc.check(t, hash, err)
+		})
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	key, displayPrefix := NewAPIKey()
+
+	require.True(t, strings.HasPrefix(key, APIKeyPrefix))
+	require.True(t, strings.HasPrefix(key, displayPrefix))
+	require.Len(t, displayPrefix, apiKeyDisplayLength)
+
+	hash, err := ParseAPIKey(key)
+	require.NoError(t, err)
+	require.Equal(t, HashToken(key), hash)
+
+	otherKey, _ := NewAPIKey()
+	require.NotEqual(t, key, otherKey)
+}
+
+func TestParseAPIKeyInvalid(t *testing.T) {
+	key, _ := NewAPIKey()
+
+	testCases := []struct {
+		name  string
+		value string
+	}{
+		{
+			name:  "SessionToken",
+			value: NewToken(time.Minute).Value,
+		},
+		{
+			name:  "SessionTokenWithPrefix",
+			value: APIKeyPrefix + NewToken(time.Minute).Value[len(APIKeyPrefix):],
+		},
+		{
+			name:  "PrefixOnly",
+			value: APIKeyPrefix,
+		},
+		{
+			name:  "Truncated",
+			value: key[:len(key)-4],
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			hash, err := ParseAPIKey(tc.value)
+			require.ErrorIs(t, err, ErrInvalidToken)
+			require.Nil(t, hash)
+		})
+	}
+}
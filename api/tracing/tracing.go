@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracers of the app.
+const InstrumentationName = "github.com/ot07/next-bazaar"
+
+// ServiceName is the name the spans of the app are reported under.
+const ServiceName = "next-bazaar-api"
+
+const (
+	// ExporterNone disables tracing
+	ExporterNone = "none"
+	// ExporterStdout writes the spans as JSON, which is mostly useful for debugging
+	ExporterStdout = "stdout"
+	// ExporterOTLP sends the spans to an OpenTelemetry collector with OTLP over HTTP
+	ExporterOTLP = "otlp"
+)
+
+// otlpTracesPath is the path OpenTelemetry collectors receive traces on.
+const otlpTracesPath = "/v1/traces"
+
+// Start starts a span as a child of the span in ctx.
+//
+// The span is created by the tracer provider of the parent span, so that the spans of a request all end up
+// at the provider the request has been traced with. Spans without a parent are created by the global provider.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracerProvider(ctx).Tracer(InstrumentationName).Start(ctx, name, opts...)
+}
+
+func tracerProvider(ctx context.Context) trace.TracerProvider {
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		return span.TracerProvider()
+	}
+	return otel.GetTracerProvider()
+}
+
+// RecordError marks the span as failed with the error, unless the error is nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// NewPropagator returns the propagator of the W3C trace context and baggage headers.
+func NewPropagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// NewExporter creates the exporter with the given name. The OTLP endpoint is only used by the OTLP exporter,
+// and the writer only by the stdout exporter. A nil exporter is returned if tracing is disabled.
+func NewExporter(name string, otlpEndpoint string, w io.Writer) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(w))
+	case ExporterOTLP:
+		return newOTLPExporter(otlpEndpoint)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", name)
+	}
+}
+
+// newOTLPExporter creates an exporter that sends the spans to the collector at the base URL of the endpoint,
+// e.g. http://localhost:4318. TLS is used unless the scheme of the endpoint is http.
+func newOTLPExporter(endpoint string) (sdktrace.SpanExporter, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid otlp endpoint: %w", err)
+	}
+	if len(endpointURL.Host) == 0 {
+		return nil, fmt.Errorf("invalid otlp endpoint %q: missing host", endpoint)
+	}
+
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(endpointURL.Host),
+		otlptracehttp.WithURLPath(strings.TrimSuffix(endpointURL.Path, "/") + otlpTracesPath),
+	}
+	if endpointURL.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	// Does not connect to the collector, so the context is only used to start the exporter
+	return otlptracehttp.New(context.Background(), opts...)
+}
+
+// NewTracerProvider creates a tracer provider that samples the given ratio of the traces started by the app
+// and sends the spans in batches to the exporter. Traces started by callers are sampled as the caller decided.
+func NewTracerProvider(exporter sdktrace.SpanExporter, sampleRatio float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(ServiceName),
+		)),
+	)
+}
@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func newRecordingProvider() (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)), recorder
+}
+
+func TestStart(t *testing.T) {
+	provider, recorder := newRecordingProvider()
+
+	ctx, parent := provider.Tracer(InstrumentationName).Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	child.End()
+	parent.End()
+
+	// The child is created by the provider of the parent, not by the global provider
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "child", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Equal(t, parent.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+}
+
+func TestRecordError(t *testing.T) {
+	provider, recorder := newRecordingProvider()
+
+	_, span := provider.Tracer(InstrumentationName).Start(context.Background(), "ok")
+	RecordError(span, nil)
+	span.End()
+
+	_, span = provider.Tracer(InstrumentationName).Start(context.Background(), "failed")
+	RecordError(span, errors.New("boom"))
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+	require.Equal(t, "boom", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+}
+
+func TestNewExporter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exporter string
+		check    func(t *testing.T, exporter sdktrace.SpanExporter, err error)
+	}{
+		{
+			name:     "Empty",
+			exporter: "",
+			check: func(t *testing.T, exporter sdktrace.SpanExporter, err error) {
+				require.NoError(t, err)
+				require.Nil(t, exporter)
+			},
+		},
+		{
+			name:     "None",
+			exporter: ExporterNone,
+			check: func(t *testing.T, exporter sdktrace.SpanExporter, err error) {
+				require.NoError(t, err)
+				require.Nil(t, exporter)
+			},
+		},
+		{
+			name:     "Stdout",
+			exporter: ExporterStdout,
+			check: func(t *testing.T, exporter sdktrace.SpanExporter, err error) {
+				require.NoError(t, err)
+				require.NotNil(t, exporter)
+			},
+		},
+		{
+			name:     "OTLP",
+			exporter: ExporterOTLP,
+			check: func(t *testing.T, exporter sdktrace.SpanExporter, err error) {
+				require.NoError(t, err)
+				require.IsType(t, &otlptrace.Exporter{}, exporter)
+			},
+		},
+		{
+			name:     "Unknown",
+			exporter: "unknown",
+			check: func(t *testing.T, exporter sdktrace.SpanExporter, err error) {
+				require.Error(t, err)
+				require.Nil(t, exporter)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporter, err := NewExporter(tc.exporter, "http://localhost:4318", io.Discard)
+			tc.check(t, exporter, err)
+		})
+	}
+}
+
+func TestStdoutExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exporter, err := NewExporter(ExporterStdout, "", &buf)
+	require.NoError(t, err)
+
+	provider := NewTracerProvider(exporter, 1)
+	_, span := provider.Tracer(InstrumentationName).Start(context.Background(), "stdout")
+	span.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	require.Contains(t, buf.String(), `"Name":"stdout"`)
+	require.Contains(t, buf.String(), ServiceName)
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var request coltracepb.ExportTraceServiceRequest
+	var contentType string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, otlpTracesPath, r.URL.Path)
+		contentType = r.Header.Get("Content-Type")
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(body, &request))
+	}))
+	defer collector.Close()
+
+	exporter, err := NewExporter(ExporterOTLP, collector.URL+"/", io.Discard)
+	require.NoError(t, err)
+	provider := NewTracerProvider(exporter, 1)
+
+	ctx, parent := provider.Tracer(InstrumentationName).Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	child.SetAttributes(attribute.Int("count", 3))
+	RecordError(child, errors.New("boom"))
+	child.End()
+	parent.End()
+	require.NoError(t, provider.Shutdown(context.Background()))
+
+	require.Equal(t, "application/x-protobuf", contentType)
+	require.Len(t, request.ResourceSpans, 1)
+	require.Len(t, request.ResourceSpans[0].ScopeSpans, 1)
+	require.Equal(t, InstrumentationName, request.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	exported := spans[0]
+	require.Equal(t, "child", exported.Name)
+	require.Equal(t, parent.SpanContext().TraceID().String(), hex.EncodeToString(exported.TraceId))
+	require.Equal(t, parent.SpanContext().SpanID().String(), hex.EncodeToString(exported.ParentSpanId))
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, exported.Status.Code)
+	require.Equal(t, "boom", exported.Status.Message)
+	require.Len(t, exported.Events, 1)
+	require.Equal(t, "count", exported.Attributes[0].Key)
+	require.Equal(t, int64(3), exported.Attributes[0].Value.GetIntValue())
+
+	require.Equal(t, "parent", spans[1].Name)
+	require.Empty(t, spans[1].ParentSpanId)
+}
+
+func TestOTLPExporterFailure(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer collector.Close()
+
+	provider, _ := newRecordingProvider()
+	_, span := provider.Tracer(InstrumentationName).Start(context.Background(), "span")
+	span.End()
+
+	exporter, err := NewExporter(ExporterOTLP, collector.URL, io.Discard)
+	require.NoError(t, err)
+	err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{span.(sdktrace.ReadOnlySpan)})
+	require.Error(t, err)
+	require.NoError(t, exporter.Shutdown(context.Background()))
+}
+
+func TestNewExporterInvalidOTLPEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"localhost:4318", "http://%zz"} {
+		exporter, err := NewExporter(ExporterOTLP, endpoint, io.Discard)
+		require.Error(t, err)
+		require.Nil(t, exporter)
+	}
+}
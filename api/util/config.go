@@ -1,20 +1,105 @@
 package util
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/ot07/next-bazaar/ratelimit"
 	"github.com/spf13/viper"
 )
 
+const (
+	// AuthModeSession authenticates every request by looking up the session token in the database
+	AuthModeSession = "session"
+	// AuthModeToken authenticates requests with signed access tokens that are verified
+	// without the database. Refresh tokens are still stored so that sessions can be revoked.
+	// Access tokens themselves cannot be revoked, so a logout, role change or password change
+	// only takes effect for them once they expire, which is why their duration is kept short.
+	AuthModeToken = "token"
+
+	// maxAccessTokenDuration bounds how long a revoked session stays usable in token auth mode
+	maxAccessTokenDuration = 15 * time.Minute
+)
+
 // Config stores all configuration of the application.
 // The values are read by viper from a config file or environment variables.
 type Config struct {
-	DBDriver             string
-	DBSource             string
-	ServerAddress        string
-	SessionTokenDuration time.Duration
-	RefreshTokenDuration time.Duration
-	TestAccounts         []testAccount
+	DBDriver                string
+	DBSource                string
+	ServerAddress           string
+	LogLevel                string
+	AuthMode                string
+	AccessTokenSymmetricKey string
+	AccessTokenDuration     time.Duration
+	SessionTokenDuration    time.Duration
+	RefreshTokenDuration    time.Duration
+	Cleanup                 CleanupConfig
+	LoginLockout            LoginLockoutConfig
+	RateLimit               RateLimitConfig
+	Proxy                   ProxyConfig
+	Metrics                 MetricsConfig
+	Tracing                 TracingConfig
+	TestAccounts            []testAccount
+}
+
+// CleanupConfig stores the settings of the background cleanup jobs.
+// A non-positive interval disables the job, the cart retention must be positive.
+type CleanupConfig struct {
+	SessionInterval      time.Duration
+	CartInterval         time.Duration
+	CartRetention        time.Duration
+	LoginFailureInterval time.Duration
+}
+
+// LoginLockoutConfig stores the thresholds of the login brute-force protection.
+// Logins are locked after the maximum number of failures within the failure window, first for the lockout duration,
+// which is doubled with every further failure up to the maximum lockout duration.
+// A non-positive number of attempts disables the limit.
+type LoginLockoutConfig struct {
+	MaxAttemptsPerEmail int
+	MaxAttemptsPerIP    int
+	FailureWindow       time.Duration
+	LockoutDuration     time.Duration
+	MaxLockoutDuration  time.Duration
+}
+
+// RateLimitConfig stores the budgets of the rate limiter.
+// The global budget applies to every request per client IP, the others per authenticated user or client IP.
+type RateLimitConfig struct {
+	Global   ratelimit.Limit
+	Register ratelimit.Limit
+	Login    ratelimit.Limit
+	Write    ratelimit.Limit
+}
+
+// ProxyConfig stores how the client IP is determined behind a reverse proxy such as AWS App Runner.
+// The client IP keys the rate limits and the login lockout, so it must not be spoofable by clients.
+// If a header such as X-Forwarded-For is set, the client IP is the last address in the header,
+// which the proxy appended itself, instead of the remote address. The addresses before it are
+// sent by the client and never used.
+// With the trusted proxy check enabled, the header is only used for requests from the trusted proxies,
+// given as comma-separated IP addresses or CIDR ranges. Otherwise any client can set the header, so the check should
+// be enabled whenever the proxies are known.
+type ProxyConfig struct {
+	Header                  string
+	EnableTrustedProxyCheck bool
+	TrustedProxies          []string
+}
+
+// MetricsConfig stores the settings of the Prometheus metrics endpoint.
+// If a token is set, the metrics can only be scraped with the token as bearer token.
+type MetricsConfig struct {
+	Enabled bool
+	Token   string
+}
+
+// TracingConfig stores the settings of the OpenTelemetry tracing.
+// The exporter is one of none, stdout and otlp. The OTLP endpoint is the base URL of an OTLP/HTTP collector.
+// The sample ratio is the share of traces sampled, unless the caller already decided to sample the trace or not.
+type TracingConfig struct {
+	Exporter     string
+	OTLPEndpoint string
+	SampleRatio  float64
 }
 
 type testAccount struct {
@@ -24,18 +109,47 @@ type testAccount struct {
 }
 
 type flatConfig struct {
-	DBDriver             string        `mapstructure:"DB_DRIVER"`
-	DBSource             string        `mapstructure:"DB_SOURCE"`
-	ServerAddress        string        `mapstructure:"SERVER_ADDRESS"`
-	SessionTokenDuration time.Duration `mapstructure:"SESSION_TOKEN_DURATION"`
-	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
-	TestAccountUsername1 string        `mapstructure:"TEST_ACCOUNT_USERNAME_1"`
-	TestAccountEmail1    string        `mapstructure:"TEST_ACCOUNT_EMAIL_1"`
-	TestAccountUsername2 string        `mapstructure:"TEST_ACCOUNT_USERNAME_2"`
-	TestAccountEmail2    string        `mapstructure:"TEST_ACCOUNT_EMAIL_2"`
-	TestAccountUsername3 string        `mapstructure:"TEST_ACCOUNT_USERNAME_3"`
-	TestAccountEmail3    string        `mapstructure:"TEST_ACCOUNT_EMAIL_3"`
-	TestAccountPassword  string        `mapstructure:"TEST_ACCOUNT_PASSWORD"`
+	DBDriver                  string        `mapstructure:"DB_DRIVER"`
+	DBSource                  string        `mapstructure:"DB_SOURCE"`
+	ServerAddress             string        `mapstructure:"SERVER_ADDRESS"`
+	LogLevel                  string        `mapstructure:"LOG_LEVEL"`
+	AuthMode                  string        `mapstructure:"AUTH_MODE"`
+	AccessTokenSymmetricKey   string        `mapstructure:"ACCESS_TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration       time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	SessionTokenDuration      time.Duration `mapstructure:"SESSION_TOKEN_DURATION"`
+	RefreshTokenDuration      time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	SessionCleanupInterval    time.Duration `mapstructure:"SESSION_CLEANUP_INTERVAL"`
+	CartCleanupInterval       time.Duration `mapstructure:"CART_CLEANUP_INTERVAL"`
+	CartRetention             time.Duration `mapstructure:"CART_RETENTION"`
+	LoginCleanupInterval      time.Duration `mapstructure:"LOGIN_FAILURE_CLEANUP_INTERVAL"`
+	LoginMaxAttemptsPerEmail  int           `mapstructure:"LOGIN_MAX_ATTEMPTS_PER_EMAIL"`
+	LoginMaxAttemptsPerIP     int           `mapstructure:"LOGIN_MAX_ATTEMPTS_PER_IP"`
+	LoginFailureWindow        time.Duration `mapstructure:"LOGIN_FAILURE_WINDOW"`
+	LoginLockoutDuration      time.Duration `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	LoginMaxLockoutDuration   time.Duration `mapstructure:"LOGIN_MAX_LOCKOUT_DURATION"`
+	RateLimitGlobalRequests   int           `mapstructure:"RATE_LIMIT_GLOBAL_REQUESTS"`
+	RateLimitGlobalPeriod     time.Duration `mapstructure:"RATE_LIMIT_GLOBAL_PERIOD"`
+	RateLimitRegisterRequests int           `mapstructure:"RATE_LIMIT_REGISTER_REQUESTS"`
+	RateLimitRegisterPeriod   time.Duration `mapstructure:"RATE_LIMIT_REGISTER_PERIOD"`
+	RateLimitLoginRequests    int           `mapstructure:"RATE_LIMIT_LOGIN_REQUESTS"`
+	RateLimitLoginPeriod      time.Duration `mapstructure:"RATE_LIMIT_LOGIN_PERIOD"`
+	RateLimitWriteRequests    int           `mapstructure:"RATE_LIMIT_WRITE_REQUESTS"`
+	RateLimitWritePeriod      time.Duration `mapstructure:"RATE_LIMIT_WRITE_PERIOD"`
+	ProxyHeader               string        `mapstructure:"PROXY_HEADER"`
+	TrustedProxyCheck         bool          `mapstructure:"TRUSTED_PROXY_CHECK"`
+	TrustedProxies            []string      `mapstructure:"TRUSTED_PROXIES"`
+	MetricsEnabled            bool          `mapstructure:"METRICS_ENABLED"`
+	MetricsToken              string        `mapstructure:"METRICS_TOKEN"`
+	TracingExporter           string        `mapstructure:"TRACING_EXPORTER"`
+	TracingOTLPEndpoint       string        `mapstructure:"TRACING_OTLP_ENDPOINT"`
+	TracingSampleRatio        float64       `mapstructure:"TRACING_SAMPLE_RATIO"`
+	TestAccountUsername1      string        `mapstructure:"TEST_ACCOUNT_USERNAME_1"`
+	TestAccountEmail1         string        `mapstructure:"TEST_ACCOUNT_EMAIL_1"`
+	TestAccountUsername2      string        `mapstructure:"TEST_ACCOUNT_USERNAME_2"`
+	TestAccountEmail2         string        `mapstructure:"TEST_ACCOUNT_EMAIL_2"`
+	TestAccountUsername3      string        `mapstructure:"TEST_ACCOUNT_USERNAME_3"`
+	TestAccountEmail3         string        `mapstructure:"TEST_ACCOUNT_EMAIL_3"`
+	TestAccountPassword       string        `mapstructure:"TEST_ACCOUNT_PASSWORD"`
 }
 
 // LoadConfig reads configuration from file or environment variables.
@@ -46,6 +160,31 @@ func LoadConfig() (config Config, err error) {
 
 	viper.AutomaticEnv()
 
+	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("AUTH_MODE", AuthModeSession)
+	viper.SetDefault("ACCESS_TOKEN_DURATION", 5*time.Minute)
+	viper.SetDefault("SESSION_CLEANUP_INTERVAL", time.Hour)
+	viper.SetDefault("CART_CLEANUP_INTERVAL", 24*time.Hour)
+	viper.SetDefault("CART_RETENTION", 30*24*time.Hour)
+	viper.SetDefault("LOGIN_FAILURE_CLEANUP_INTERVAL", time.Hour)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_EMAIL", 5)
+	viper.SetDefault("LOGIN_MAX_ATTEMPTS_PER_IP", 20)
+	viper.SetDefault("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", time.Minute)
+	viper.SetDefault("LOGIN_MAX_LOCKOUT_DURATION", time.Hour)
+	viper.SetDefault("RATE_LIMIT_GLOBAL_REQUESTS", 300)
+	viper.SetDefault("RATE_LIMIT_GLOBAL_PERIOD", time.Minute)
+	viper.SetDefault("RATE_LIMIT_REGISTER_REQUESTS", 5)
+	viper.SetDefault("RATE_LIMIT_REGISTER_PERIOD", time.Hour)
+	viper.SetDefault("RATE_LIMIT_LOGIN_REQUESTS", 10)
+	viper.SetDefault("RATE_LIMIT_LOGIN_PERIOD", time.Minute)
+	viper.SetDefault("RATE_LIMIT_WRITE_REQUESTS", 60)
+	viper.SetDefault("RATE_LIMIT_WRITE_PERIOD", time.Minute)
+	viper.SetDefault("METRICS_ENABLED", false)
+	viper.SetDefault("TRACING_EXPORTER", "none")
+	viper.SetDefault("TRACING_OTLP_ENDPOINT", "http://localhost:4318")
+	viper.SetDefault("TRACING_SAMPLE_RATIO", 1)
+
 	err = viper.ReadInConfig()
 	if err != nil {
 		return
@@ -53,18 +192,89 @@ func LoadConfig() (config Config, err error) {
 
 	flatConfig := flatConfig{}
 	err = viper.Unmarshal(&flatConfig)
+	if err != nil {
+		return
+	}
 
 	config = flatConfigToConfig(flatConfig)
+	err = config.validate()
 	return
 }
 
+// validate rejects settings that would make the application misbehave instead of failing fast.
+func (config Config) validate() error {
+	// With a non-positive retention every cart would be stale and emptied by the cleanup job
+	if config.Cleanup.CartRetention <= 0 {
+		return fmt.Errorf("CART_RETENTION must be positive, got %s", config.Cleanup.CartRetention)
+	}
+
+	if config.AuthMode == AuthModeToken &&
+		(config.AccessTokenDuration <= 0 || config.AccessTokenDuration > maxAccessTokenDuration) {
+		return fmt.Errorf(
+			"ACCESS_TOKEN_DURATION must be positive and at most %s, got %s",
+			maxAccessTokenDuration, config.AccessTokenDuration,
+		)
+	}
+
+	return nil
+}
+
 func flatConfigToConfig(flatConfig flatConfig) Config {
 	return Config{
-		DBDriver:             flatConfig.DBDriver,
-		DBSource:             flatConfig.DBSource,
-		ServerAddress:        flatConfig.ServerAddress,
-		SessionTokenDuration: flatConfig.SessionTokenDuration,
-		RefreshTokenDuration: flatConfig.RefreshTokenDuration,
+		DBDriver:                flatConfig.DBDriver,
+		DBSource:                flatConfig.DBSource,
+		ServerAddress:           flatConfig.ServerAddress,
+		LogLevel:                flatConfig.LogLevel,
+		AuthMode:                flatConfig.AuthMode,
+		AccessTokenSymmetricKey: flatConfig.AccessTokenSymmetricKey,
+		AccessTokenDuration:     flatConfig.AccessTokenDuration,
+		SessionTokenDuration:    flatConfig.SessionTokenDuration,
+		RefreshTokenDuration:    flatConfig.RefreshTokenDuration,
+		Cleanup: CleanupConfig{
+			SessionInterval:      flatConfig.SessionCleanupInterval,
+			CartInterval:         flatConfig.CartCleanupInterval,
+			CartRetention:        flatConfig.CartRetention,
+			LoginFailureInterval: flatConfig.LoginCleanupInterval,
+		},
+		LoginLockout: LoginLockoutConfig{
+			MaxAttemptsPerEmail: flatConfig.LoginMaxAttemptsPerEmail,
+			MaxAttemptsPerIP:    flatConfig.LoginMaxAttemptsPerIP,
+			FailureWindow:       flatConfig.LoginFailureWindow,
+			LockoutDuration:     flatConfig.LoginLockoutDuration,
+			MaxLockoutDuration:  flatConfig.LoginMaxLockoutDuration,
+		},
+		RateLimit: RateLimitConfig{
+			Global: ratelimit.Limit{
+				Requests: flatConfig.RateLimitGlobalRequests,
+				Period:   flatConfig.RateLimitGlobalPeriod,
+			},
+			Register: ratelimit.Limit{
+				Requests: flatConfig.RateLimitRegisterRequests,
+				Period:   flatConfig.RateLimitRegisterPeriod,
+			},
+			Login: ratelimit.Limit{
+				Requests: flatConfig.RateLimitLoginRequests,
+				Period:   flatConfig.RateLimitLoginPeriod,
+			},
+			Write: ratelimit.Limit{
+				Requests: flatConfig.RateLimitWriteRequests,
+				Period:   flatConfig.RateLimitWritePeriod,
+			},
+		},
+		Proxy: ProxyConfig{
+			Header:                  flatConfig.ProxyHeader,
+			EnableTrustedProxyCheck: flatConfig.TrustedProxyCheck,
+			TrustedProxies:          flatConfig.TrustedProxies,
+		},
+		Metrics: MetricsConfig{
+			Enabled: flatConfig.MetricsEnabled,
+			Token:   flatConfig.MetricsToken,
+		},
+		Tracing: TracingConfig{
+			Exporter:     flatConfig.TracingExporter,
+			OTLPEndpoint: flatConfig.TracingOTLPEndpoint,
+			SampleRatio:  flatConfig.TracingSampleRatio,
+		},
 		TestAccounts: []testAccount{
 			{
 				Username: flatConfig.TestAccountUsername1,
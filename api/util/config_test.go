@@ -0,0 +1,85 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			config: Config{
+				Cleanup: CleanupConfig{CartRetention: 24 * time.Hour},
+			},
+		},
+		{
+			name: "ZeroCartRetention",
+			config: Config{
+				Cleanup: CleanupConfig{CartRetention: 0},
+			},
+			wantErr: true,
+		},
+		{
+			name: "NegativeCartRetention",
+			config: Config{
+				Cleanup: CleanupConfig{CartRetention: -time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TokenMode",
+			config: Config{
+				AuthMode:            AuthModeToken,
+				AccessTokenDuration: 5 * time.Minute,
+				Cleanup:             CleanupConfig{CartRetention: 24 * time.Hour},
+			},
+		},
+		{
+			name: "TokenModeWithoutAccessTokenDuration",
+			config: Config{
+				AuthMode: AuthModeToken,
+				Cleanup:  CleanupConfig{CartRetention: 24 * time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			name: "TokenModeWithLongAccessTokenDuration",
+			config: Config{
+				AuthMode:            AuthModeToken,
+				AccessTokenDuration: time.Hour,
+				Cleanup:             CleanupConfig{CartRetention: 24 * time.Hour},
+			},
+			wantErr: true,
+		},
+		{
+			// The access token duration is not used in session auth mode
+			name: "SessionModeWithoutAccessTokenDuration",
+			config: Config{
+				AuthMode: AuthModeSession,
+				Cleanup:  CleanupConfig{CartRetention: 24 * time.Hour},
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.config.validate()
+			if tc.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -49,6 +49,13 @@ func RandomUUID() uuid.UUID {
 	return uuid.New()
 }
 
+// RandomTokenHash generates a random SHA-256 sized token hash
+func RandomTokenHash() []byte {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return b
+}
+
 // RandomPrice generates a random price
 func RandomPrice() decimal.Decimal {
 	min := 1.00